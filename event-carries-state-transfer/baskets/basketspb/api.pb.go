@@ -9,6 +9,8 @@ package basketspb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -693,11 +695,184 @@ func (x *GetBasketResponse) GetBasket() *Basket {
 	return nil
 }
 
+// HistoryEvent is a stored event with its payload rendered as JSON
+type HistoryEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Payload       string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryEvent) Reset() {
+	*x = HistoryEvent{}
+	mi := &file_basketspb_api_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryEvent) ProtoMessage() {}
+
+func (x *HistoryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_basketspb_api_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryEvent.ProtoReflect.Descriptor instead.
+func (*HistoryEvent) Descriptor() ([]byte, []int) {
+	return file_basketspb_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HistoryEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HistoryEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HistoryEvent) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *HistoryEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *HistoryEvent) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *HistoryEvent) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GetBasketHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBasketHistoryRequest) Reset() {
+	*x = GetBasketHistoryRequest{}
+	mi := &file_basketspb_api_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBasketHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBasketHistoryRequest) ProtoMessage() {}
+
+func (x *GetBasketHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_basketspb_api_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBasketHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetBasketHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_basketspb_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetBasketHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetBasketHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*HistoryEvent        `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBasketHistoryResponse) Reset() {
+	*x = GetBasketHistoryResponse{}
+	mi := &file_basketspb_api_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBasketHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBasketHistoryResponse) ProtoMessage() {}
+
+func (x *GetBasketHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_basketspb_api_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBasketHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetBasketHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_basketspb_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetBasketHistoryResponse) GetEvents() []*HistoryEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
 var File_basketspb_api_proto protoreflect.FileDescriptor
 
 const file_basketspb_api_proto_rawDesc = "" +
 	"\n" +
-	"\x13basketspb/api.proto\x12\tbasketspb\"?\n" +
+	"\x13basketspb/api.proto\x12\tbasketspb\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"?\n" +
 	"\x06Basket\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
 	"\x05items\x18\x02 \x03(\v2\x0f.basketspb.ItemR\x05items\"\xc3\x01\n" +
@@ -738,7 +913,19 @@ const file_basketspb_api_proto_rawDesc = "" +
 	"\x10GetBasketRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
 	"\x11GetBasketResponse\x12)\n" +
-	"\x06basket\x18\x01 \x01(\v2\x11.basketspb.BasketR\x06basket2\xe6\x03\n" +
+	"\x06basket\x18\x01 \x01(\v2\x11.basketspb.BasketR\x06basket\"\xd8\x01\n" +
+	"\fHistoryEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x123\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\")\n" +
+	"\x17GetBasketHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"K\n" +
+	"\x18GetBasketHistoryResponse\x12/\n" +
+	"\x06events\x18\x01 \x03(\v2\x17.basketspb.HistoryEventR\x06events2\xc5\x04\n" +
 	"\rBasketService\x12N\n" +
 	"\vStartBasket\x12\x1d.basketspb.StartBasketRequest\x1a\x1e.basketspb.StartBasketResponse\"\x00\x12Q\n" +
 	"\fCancelBasket\x12\x1e.basketspb.CancelBasketRequest\x1a\x1f.basketspb.CancelBasketResponse\"\x00\x12W\n" +
@@ -746,7 +933,8 @@ const file_basketspb_api_proto_rawDesc = "" +
 	"\aAddItem\x12\x19.basketspb.AddItemRequest\x1a\x1a.basketspb.AddItemResponse\"\x00\x12K\n" +
 	"\n" +
 	"RemoveItem\x12\x1c.basketspb.RemoveItemRequest\x1a\x1d.basketspb.RemoveItemResponse\"\x00\x12H\n" +
-	"\tGetBasket\x12\x1b.basketspb.GetBasketRequest\x1a\x1c.basketspb.GetBasketResponse\"\x00B\x88\x01\n" +
+	"\tGetBasket\x12\x1b.basketspb.GetBasketRequest\x1a\x1c.basketspb.GetBasketResponse\"\x00\x12]\n" +
+	"\x10GetBasketHistory\x12\".basketspb.GetBasketHistoryRequest\x1a#.basketspb.GetBasketHistoryResponse\"\x00B\x88\x01\n" +
 	"\rcom.basketspbB\bApiProtoP\x01Z)eda-in-golang/baskets/basketspb/basketspb\xa2\x02\x03BXX\xaa\x02\tBasketspb\xca\x02\tBasketspb\xe2\x02\x15Basketspb\\GPBMetadata\xea\x02\tBasketspbb\x06proto3"
 
 var (
@@ -761,43 +949,53 @@ func file_basketspb_api_proto_rawDescGZIP() []byte {
 	return file_basketspb_api_proto_rawDescData
 }
 
-var file_basketspb_api_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_basketspb_api_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_basketspb_api_proto_goTypes = []any{
-	(*Basket)(nil),                 // 0: basketspb.Basket
-	(*Item)(nil),                   // 1: basketspb.Item
-	(*StartBasketRequest)(nil),     // 2: basketspb.StartBasketRequest
-	(*StartBasketResponse)(nil),    // 3: basketspb.StartBasketResponse
-	(*CancelBasketRequest)(nil),    // 4: basketspb.CancelBasketRequest
-	(*CancelBasketResponse)(nil),   // 5: basketspb.CancelBasketResponse
-	(*CheckoutBasketRequest)(nil),  // 6: basketspb.CheckoutBasketRequest
-	(*CheckoutBasketResponse)(nil), // 7: basketspb.CheckoutBasketResponse
-	(*AddItemRequest)(nil),         // 8: basketspb.AddItemRequest
-	(*AddItemResponse)(nil),        // 9: basketspb.AddItemResponse
-	(*RemoveItemRequest)(nil),      // 10: basketspb.RemoveItemRequest
-	(*RemoveItemResponse)(nil),     // 11: basketspb.RemoveItemResponse
-	(*GetBasketRequest)(nil),       // 12: basketspb.GetBasketRequest
-	(*GetBasketResponse)(nil),      // 13: basketspb.GetBasketResponse
+	(*Basket)(nil),                   // 0: basketspb.Basket
+	(*Item)(nil),                     // 1: basketspb.Item
+	(*StartBasketRequest)(nil),       // 2: basketspb.StartBasketRequest
+	(*StartBasketResponse)(nil),      // 3: basketspb.StartBasketResponse
+	(*CancelBasketRequest)(nil),      // 4: basketspb.CancelBasketRequest
+	(*CancelBasketResponse)(nil),     // 5: basketspb.CancelBasketResponse
+	(*CheckoutBasketRequest)(nil),    // 6: basketspb.CheckoutBasketRequest
+	(*CheckoutBasketResponse)(nil),   // 7: basketspb.CheckoutBasketResponse
+	(*AddItemRequest)(nil),           // 8: basketspb.AddItemRequest
+	(*AddItemResponse)(nil),          // 9: basketspb.AddItemResponse
+	(*RemoveItemRequest)(nil),        // 10: basketspb.RemoveItemRequest
+	(*RemoveItemResponse)(nil),       // 11: basketspb.RemoveItemResponse
+	(*GetBasketRequest)(nil),         // 12: basketspb.GetBasketRequest
+	(*GetBasketResponse)(nil),        // 13: basketspb.GetBasketResponse
+	(*HistoryEvent)(nil),             // 14: basketspb.HistoryEvent
+	(*GetBasketHistoryRequest)(nil),  // 15: basketspb.GetBasketHistoryRequest
+	(*GetBasketHistoryResponse)(nil), // 16: basketspb.GetBasketHistoryResponse
+	(*timestamppb.Timestamp)(nil),    // 17: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),          // 18: google.protobuf.Struct
 }
 var file_basketspb_api_proto_depIdxs = []int32{
 	1,  // 0: basketspb.Basket.items:type_name -> basketspb.Item
 	0,  // 1: basketspb.GetBasketResponse.basket:type_name -> basketspb.Basket
-	2,  // 2: basketspb.BasketService.StartBasket:input_type -> basketspb.StartBasketRequest
-	4,  // 3: basketspb.BasketService.CancelBasket:input_type -> basketspb.CancelBasketRequest
-	6,  // 4: basketspb.BasketService.CheckoutBasket:input_type -> basketspb.CheckoutBasketRequest
-	8,  // 5: basketspb.BasketService.AddItem:input_type -> basketspb.AddItemRequest
-	10, // 6: basketspb.BasketService.RemoveItem:input_type -> basketspb.RemoveItemRequest
-	12, // 7: basketspb.BasketService.GetBasket:input_type -> basketspb.GetBasketRequest
-	3,  // 8: basketspb.BasketService.StartBasket:output_type -> basketspb.StartBasketResponse
-	5,  // 9: basketspb.BasketService.CancelBasket:output_type -> basketspb.CancelBasketResponse
-	7,  // 10: basketspb.BasketService.CheckoutBasket:output_type -> basketspb.CheckoutBasketResponse
-	9,  // 11: basketspb.BasketService.AddItem:output_type -> basketspb.AddItemResponse
-	11, // 12: basketspb.BasketService.RemoveItem:output_type -> basketspb.RemoveItemResponse
-	13, // 13: basketspb.BasketService.GetBasket:output_type -> basketspb.GetBasketResponse
-	8,  // [8:14] is the sub-list for method output_type
-	2,  // [2:8] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	17, // 2: basketspb.HistoryEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	18, // 3: basketspb.HistoryEvent.metadata:type_name -> google.protobuf.Struct
+	14, // 4: basketspb.GetBasketHistoryResponse.events:type_name -> basketspb.HistoryEvent
+	2,  // 5: basketspb.BasketService.StartBasket:input_type -> basketspb.StartBasketRequest
+	4,  // 6: basketspb.BasketService.CancelBasket:input_type -> basketspb.CancelBasketRequest
+	6,  // 7: basketspb.BasketService.CheckoutBasket:input_type -> basketspb.CheckoutBasketRequest
+	8,  // 8: basketspb.BasketService.AddItem:input_type -> basketspb.AddItemRequest
+	10, // 9: basketspb.BasketService.RemoveItem:input_type -> basketspb.RemoveItemRequest
+	12, // 10: basketspb.BasketService.GetBasket:input_type -> basketspb.GetBasketRequest
+	15, // 11: basketspb.BasketService.GetBasketHistory:input_type -> basketspb.GetBasketHistoryRequest
+	3,  // 12: basketspb.BasketService.StartBasket:output_type -> basketspb.StartBasketResponse
+	5,  // 13: basketspb.BasketService.CancelBasket:output_type -> basketspb.CancelBasketResponse
+	7,  // 14: basketspb.BasketService.CheckoutBasket:output_type -> basketspb.CheckoutBasketResponse
+	9,  // 15: basketspb.BasketService.AddItem:output_type -> basketspb.AddItemResponse
+	11, // 16: basketspb.BasketService.RemoveItem:output_type -> basketspb.RemoveItemResponse
+	13, // 17: basketspb.BasketService.GetBasket:output_type -> basketspb.GetBasketResponse
+	16, // 18: basketspb.BasketService.GetBasketHistory:output_type -> basketspb.GetBasketHistoryResponse
+	12, // [12:19] is the sub-list for method output_type
+	5,  // [5:12] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_basketspb_api_proto_init() }
@@ -811,7 +1009,7 @@ func file_basketspb_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_basketspb_api_proto_rawDesc), len(file_basketspb_api_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,12 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BasketService_StartBasket_FullMethodName    = "/basketspb.BasketService/StartBasket"
-	BasketService_CancelBasket_FullMethodName   = "/basketspb.BasketService/CancelBasket"
-	BasketService_CheckoutBasket_FullMethodName = "/basketspb.BasketService/CheckoutBasket"
-	BasketService_AddItem_FullMethodName        = "/basketspb.BasketService/AddItem"
-	BasketService_RemoveItem_FullMethodName     = "/basketspb.BasketService/RemoveItem"
-	BasketService_GetBasket_FullMethodName      = "/basketspb.BasketService/GetBasket"
+	BasketService_StartBasket_FullMethodName      = "/basketspb.BasketService/StartBasket"
+	BasketService_CancelBasket_FullMethodName     = "/basketspb.BasketService/CancelBasket"
+	BasketService_CheckoutBasket_FullMethodName   = "/basketspb.BasketService/CheckoutBasket"
+	BasketService_AddItem_FullMethodName          = "/basketspb.BasketService/AddItem"
+	BasketService_RemoveItem_FullMethodName       = "/basketspb.BasketService/RemoveItem"
+	BasketService_GetBasket_FullMethodName        = "/basketspb.BasketService/GetBasket"
+	BasketService_GetBasketHistory_FullMethodName = "/basketspb.BasketService/GetBasketHistory"
 )
 
 // BasketServiceClient is the client API for BasketService service.
@@ -37,6 +38,7 @@ type BasketServiceClient interface {
 	AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*AddItemResponse, error)
 	RemoveItem(ctx context.Context, in *RemoveItemRequest, opts ...grpc.CallOption) (*RemoveItemResponse, error)
 	GetBasket(ctx context.Context, in *GetBasketRequest, opts ...grpc.CallOption) (*GetBasketResponse, error)
+	GetBasketHistory(ctx context.Context, in *GetBasketHistoryRequest, opts ...grpc.CallOption) (*GetBasketHistoryResponse, error)
 }
 
 type basketServiceClient struct {
@@ -107,6 +109,16 @@ func (c *basketServiceClient) GetBasket(ctx context.Context, in *GetBasketReques
 	return out, nil
 }
 
+func (c *basketServiceClient) GetBasketHistory(ctx context.Context, in *GetBasketHistoryRequest, opts ...grpc.CallOption) (*GetBasketHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBasketHistoryResponse)
+	err := c.cc.Invoke(ctx, BasketService_GetBasketHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BasketServiceServer is the server API for BasketService service.
 // All implementations must embed UnimplementedBasketServiceServer
 // for forward compatibility.
@@ -117,6 +129,7 @@ type BasketServiceServer interface {
 	AddItem(context.Context, *AddItemRequest) (*AddItemResponse, error)
 	RemoveItem(context.Context, *RemoveItemRequest) (*RemoveItemResponse, error)
 	GetBasket(context.Context, *GetBasketRequest) (*GetBasketResponse, error)
+	GetBasketHistory(context.Context, *GetBasketHistoryRequest) (*GetBasketHistoryResponse, error)
 	mustEmbedUnimplementedBasketServiceServer()
 }
 
@@ -145,6 +158,9 @@ func (UnimplementedBasketServiceServer) RemoveItem(context.Context, *RemoveItemR
 func (UnimplementedBasketServiceServer) GetBasket(context.Context, *GetBasketRequest) (*GetBasketResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetBasket not implemented")
 }
+func (UnimplementedBasketServiceServer) GetBasketHistory(context.Context, *GetBasketHistoryRequest) (*GetBasketHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBasketHistory not implemented")
+}
 func (UnimplementedBasketServiceServer) mustEmbedUnimplementedBasketServiceServer() {}
 func (UnimplementedBasketServiceServer) testEmbeddedByValue()                       {}
 
@@ -274,6 +290,24 @@ func _BasketService_GetBasket_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BasketService_GetBasketHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBasketHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BasketServiceServer).GetBasketHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BasketService_GetBasketHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BasketServiceServer).GetBasketHistory(ctx, req.(*GetBasketHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BasketService_ServiceDesc is the grpc.ServiceDesc for BasketService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -305,6 +339,10 @@ var BasketService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBasket",
 			Handler:    _BasketService_GetBasket_Handler,
 		},
+		{
+			MethodName: "GetBasketHistory",
+			Handler:    _BasketService_GetBasketHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "basketspb/api.proto",
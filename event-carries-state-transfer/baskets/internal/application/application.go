@@ -7,6 +7,7 @@ import (
 
 	"eda-in-golang/baskets/internal/domain"
 	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/es"
 )
 
 type (
@@ -40,6 +41,10 @@ type (
 		ID string
 	}
 
+	GetBasketHistory struct {
+		ID string
+	}
+
 	App interface {
 		StartBasket(ctx context.Context, start StartBasket) error
 		CancelBasket(ctx context.Context, cancel CancelBasket) error
@@ -47,10 +52,12 @@ type (
 		AddItem(ctx context.Context, add AddItem) error
 		RemoveItem(ctx context.Context, remove RemoveItem) error
 		GetBasket(ctx context.Context, get GetBasket) (*domain.Basket, error)
+		GetBasketHistory(ctx context.Context, get GetBasketHistory) ([]es.HistoryEvent, error)
 	}
 
 	Application struct {
 		baskets   domain.BasketRepository
+		history   es.HistoryReader
 		stores    domain.StoreRepository
 		products  domain.ProductRepository
 		publisher ddd.EventPublisher[ddd.Event]
@@ -61,12 +68,14 @@ var _ App = (*Application)(nil)
 
 func New(
 	baskets domain.BasketRepository,
+	history es.HistoryReader,
 	stores domain.StoreRepository,
 	products domain.ProductRepository,
 	publisher ddd.EventPublisher[ddd.Event],
 ) *Application {
 	return &Application{
 		baskets:   baskets,
+		history:   history,
 		stores:    stores,
 		products:  products,
 		publisher: publisher,
@@ -181,3 +190,16 @@ func (a Application) RemoveItem(ctx context.Context, remove RemoveItem) error {
 func (a Application) GetBasket(ctx context.Context, get GetBasket) (*domain.Basket, error) {
 	return a.baskets.Load(ctx, get.ID)
 }
+
+func (a Application) GetBasketHistory(ctx context.Context, get GetBasketHistory) ([]es.HistoryEvent, error) {
+	events, err := a.history.History(ctx, domain.BasketAggregate, get.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get basket history")
+	}
+
+	if len(events) == 0 {
+		return nil, errors.ErrNotFound.Msgf("the basket `%s` has no history", get.ID)
+	}
+
+	return events, nil
+}
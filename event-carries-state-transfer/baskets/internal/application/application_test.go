@@ -6,9 +6,14 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"eda-in-golang/baskets/internal/domain"
 	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/es"
+	"eda-in-golang/internal/memstore"
+	"eda-in-golang/internal/registry"
+	"eda-in-golang/internal/registry/serdes"
 )
 
 // Mock implementations for testing
@@ -90,6 +95,17 @@ func (m *mockEventPublisher) Publish(ctx context.Context, events ...ddd.Event) e
 	return fmt.Errorf("publishFunc not implemented")
 }
 
+type mockHistoryReader struct {
+	historyFunc func(ctx context.Context, aggregateName, aggregateID string) ([]es.HistoryEvent, error)
+}
+
+func (m *mockHistoryReader) History(ctx context.Context, aggregateName, aggregateID string) ([]es.HistoryEvent, error) {
+	if m.historyFunc != nil {
+		return m.historyFunc(ctx, aggregateName, aggregateID)
+	}
+	return nil, fmt.Errorf("historyFunc not implemented")
+}
+
 func TestApplication_StartBasket(t *testing.T) {
 	type fields struct {
 		baskets   *mockBasketRepository
@@ -231,7 +247,7 @@ func TestApplication_StartBasket(t *testing.T) {
 				tt.prepare(&f)
 			}
 
-			app := New(f.baskets, f.stores, f.products, f.publisher)
+			app := New(f.baskets, nil, f.stores, f.products, f.publisher)
 
 			// Act
 			err := app.StartBasket(tt.args.ctx, StartBasket{
@@ -387,7 +403,7 @@ func TestApplication_CancelBasket(t *testing.T) {
 				tt.prepare(&f)
 			}
 
-			app := New(f.baskets, f.stores, f.products, f.publisher)
+			app := New(f.baskets, nil, f.stores, f.products, f.publisher)
 
 			// Act
 			err := app.CancelBasket(tt.args.ctx, CancelBasket{
@@ -565,7 +581,7 @@ func TestApplication_CheckoutBasket(t *testing.T) {
 				tt.prepare(&f)
 			}
 
-			app := New(f.baskets, f.stores, f.products, f.publisher)
+			app := New(f.baskets, nil, f.stores, f.products, f.publisher)
 
 			// Act
 			err := app.CheckoutBasket(tt.args.ctx, CheckoutBasket{
@@ -768,7 +784,7 @@ func TestApplication_AddItem(t *testing.T) {
 				tt.prepare(&f)
 			}
 
-			app := New(f.baskets, f.stores, f.products, f.publisher)
+			app := New(f.baskets, nil, f.stores, f.products, f.publisher)
 
 			// Act
 			err := app.AddItem(tt.args.ctx, AddItem{
@@ -940,7 +956,7 @@ func TestApplication_RemoveItem(t *testing.T) {
 				tt.prepare(&f)
 			}
 
-			app := New(f.baskets, f.stores, f.products, f.publisher)
+			app := New(f.baskets, nil, f.stores, f.products, f.publisher)
 
 			// Act
 			err := app.RemoveItem(tt.args.ctx, RemoveItem{
@@ -1059,7 +1075,7 @@ func TestApplication_GetBasket(t *testing.T) {
 				tt.prepare(&f)
 			}
 
-			app := New(f.baskets, f.stores, f.products, f.publisher)
+			app := New(f.baskets, nil, f.stores, f.products, f.publisher)
 
 			// Act
 			basket, err := app.GetBasket(tt.args.ctx, GetBasket{
@@ -1083,6 +1099,164 @@ func TestApplication_GetBasket(t *testing.T) {
 	}
 }
 
+// newEventSourcedBaskets persists baskets in memory through the registry and
+// snapshot store the baskets module uses
+func newEventSourcedBaskets(t *testing.T) (es.AggregateRepository[*domain.Basket], *memstore.EventStore, *es.SnapshotCounters) {
+	t.Helper()
+
+	reg := registry.New()
+	serde := serdes.NewJsonSerde(reg)
+	require.NoError(t, serde.Register(domain.Basket{}, func(v interface{}) error {
+		basket := v.(*domain.Basket)
+		basket.Items = make(map[string]domain.Item)
+		return nil
+	}))
+	require.NoError(t, serde.Register(domain.BasketStarted{}))
+	require.NoError(t, serde.Register(domain.BasketCanceled{}))
+	require.NoError(t, serde.Register(domain.BasketCheckedOut{}))
+	require.NoError(t, serde.Register(domain.BasketItemAdded{}))
+	require.NoError(t, serde.Register(domain.BasketItemRemoved{}))
+	require.NoError(t, serde.RegisterKey(domain.BasketV1{}.SnapshotName(), domain.BasketV1{}))
+
+	events := memstore.NewEventStore(reg)
+	metrics := es.NewSnapshotCounters()
+	store := es.AggregateStoreWithMiddleware(
+		events,
+		memstore.NewSnapshotStore(reg,
+			es.WithSnapshotStrategy(domain.BasketAggregate, es.AnyOf(
+				es.EveryNEvents(3),
+				es.OnEvents(domain.BasketCheckedOutEvent, domain.BasketCanceledEvent),
+			)),
+			es.WithSnapshotMetrics(metrics),
+		),
+	)
+
+	return es.NewAggregateRepository[*domain.Basket](domain.BasketAggregate, reg, store), events, metrics
+}
+
+func TestApplication_EventSourcedBasket(t *testing.T) {
+	ctx := context.Background()
+	baskets, events, metrics := newEventSourcedBaskets(t)
+	products := &mockProductRepository{
+		findFunc: func(ctx context.Context, productID string) (*domain.Product, error) {
+			return &domain.Product{ID: productID, StoreID: "store-1", Name: "Product " + productID, Price: 10.0}, nil
+		},
+	}
+	stores := &mockStoreRepository{
+		findFunc: func(ctx context.Context, storeID string) (*domain.Store, error) {
+			return &domain.Store{ID: storeID, Name: "Store 1"}, nil
+		},
+	}
+	var published []string
+	publisher := &mockEventPublisher{
+		publishFunc: func(ctx context.Context, events ...ddd.Event) error {
+			for _, event := range events {
+				published = append(published, event.EventName())
+			}
+			return nil
+		},
+	}
+	app := New(baskets, events, stores, products, publisher)
+
+	require.NoError(t, app.StartBasket(ctx, StartBasket{ID: "basket-123", CustomerID: "customer-456"}))
+	require.NoError(t, app.AddItem(ctx, AddItem{ID: "basket-123", ProductID: "product-1", Quantity: 3}))
+	require.NoError(t, app.AddItem(ctx, AddItem{ID: "basket-123", ProductID: "product-2", Quantity: 1}))
+	require.NoError(t, app.RemoveItem(ctx, RemoveItem{ID: "basket-123", ProductID: "product-1", Quantity: 1}))
+	require.NoError(t, app.CheckoutBasket(ctx, CheckoutBasket{ID: "basket-123", PaymentID: "payment-789"}))
+
+	basket, err := app.GetBasket(ctx, GetBasket{ID: "basket-123"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, basket.Version())
+	assert.Equal(t, "customer-456", basket.CustomerID)
+	assert.Equal(t, "payment-789", basket.PaymentID)
+	assert.Equal(t, domain.BasketIsCheckedOut, basket.Status)
+	assert.Equal(t, 2, basket.Items["product-1"].Quantity)
+	assert.Equal(t, 1, basket.Items["product-2"].Quantity)
+	assert.Equal(t, []string{domain.BasketStartedEvent, domain.BasketCheckedOutEvent}, published)
+
+	// a snapshot was taken after the third event and on checkout
+	counts := metrics.Counts(domain.BasketAggregate)
+	assert.Equal(t, int64(2), counts.Taken)
+	assert.Positive(t, counts.Hits)
+
+	assert.ErrorIs(t, app.CancelBasket(ctx, CancelBasket{ID: "basket-123"}), domain.ErrBasketCannotBeCancelled)
+}
+
+func TestApplication_GetBasketHistory(t *testing.T) {
+	tests := map[string]struct {
+		prepare    func(t *testing.T, baskets es.AggregateRepository[*domain.Basket])
+		history    func(events *memstore.EventStore) es.HistoryReader
+		wantEvents []string
+		errMsg     string
+	}{
+		"basket_with_history": {
+			prepare: func(t *testing.T, baskets es.AggregateRepository[*domain.Basket]) {
+				ctx := context.Background()
+				basket, err := domain.StartBasket("basket-123", "customer-456")
+				require.NoError(t, err)
+				require.NoError(t, baskets.Save(ctx, basket))
+
+				basket, err = baskets.Load(ctx, "basket-123")
+				require.NoError(t, err)
+				require.NoError(t, basket.AddItem(
+					&domain.Store{ID: "store-1", Name: "Store 1"},
+					&domain.Product{ID: "product-1", StoreID: "store-1", Name: "Product 1", Price: 10.0},
+					2,
+				))
+				require.NoError(t, baskets.Save(ctx, basket))
+			},
+			wantEvents: []string{domain.BasketStartedEvent, domain.BasketItemAddedEvent},
+		},
+		"basket_without_history": {
+			errMsg: "has no history",
+		},
+		"history_fails": {
+			history: func(*memstore.EventStore) es.HistoryReader {
+				return &mockHistoryReader{
+					historyFunc: func(ctx context.Context, aggregateName, aggregateID string) ([]es.HistoryEvent, error) {
+						return nil, fmt.Errorf("read error")
+					},
+				}
+			},
+			errMsg: "read error",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			baskets, events, _ := newEventSourcedBaskets(t)
+			if tt.prepare != nil {
+				tt.prepare(t, baskets)
+			}
+			var history es.HistoryReader = events
+			if tt.history != nil {
+				history = tt.history(events)
+			}
+			app := New(baskets, history, &mockStoreRepository{}, &mockProductRepository{}, &mockEventPublisher{})
+
+			// Act
+			got, err := app.GetBasketHistory(context.Background(), GetBasketHistory{ID: "basket-123"})
+
+			// Assert
+			if tt.errMsg != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, len(got))
+			for i, event := range got {
+				names[i] = event.Name
+				assert.Equal(t, i+1, event.Version)
+			}
+			assert.Equal(t, tt.wantEvents, names)
+		})
+	}
+}
+
 func TestApplication_New(t *testing.T) {
 	t.Parallel()
 
@@ -1091,13 +1265,15 @@ func TestApplication_New(t *testing.T) {
 	storeRepo := &mockStoreRepository{}
 	productRepo := &mockProductRepository{}
 	publisher := &mockEventPublisher{}
+	history := &mockHistoryReader{}
 
 	// Act
-	app := New(basketRepo, storeRepo, productRepo, publisher)
+	app := New(basketRepo, history, storeRepo, productRepo, publisher)
 
 	// Assert
 	assert.NotNil(t, app)
 	assert.NotNil(t, app.baskets)
+	assert.NotNil(t, app.history)
 	assert.NotNil(t, app.stores)
 	assert.NotNil(t, app.products)
 	assert.NotNil(t, app.publisher)
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	estesting "eda-in-golang/internal/es/testing"
+)
+
+func newTestBasket() *Basket { return NewBasket("basket-id") }
+
+func TestBasket_Checkout(t *testing.T) {
+	item := Item{StoreID: "store-id", ProductID: "product-id", ProductPrice: 9.99, Quantity: 2}
+
+	t.Run("checks_out_an_open_basket", func(t *testing.T) {
+		estesting.Given(newTestBasket,
+			&BasketStarted{CustomerID: "customer-id"},
+			&BasketItemAdded{Item: item},
+		).When(func(basket *Basket) error {
+			_, err := basket.Checkout("payment-id")
+			return err
+		}).Then(t, &BasketCheckedOut{
+			PaymentID:  "payment-id",
+			CustomerID: "customer-id",
+			Items:      map[string]Item{"product-id": item},
+		})
+	})
+
+	t.Run("rejects_an_empty_basket", func(t *testing.T) {
+		estesting.Given(newTestBasket,
+			&BasketStarted{CustomerID: "customer-id"},
+		).When(func(basket *Basket) error {
+			_, err := basket.Checkout("payment-id")
+			return err
+		}).ThenError(t, ErrBasketHasNoItems)
+	})
+
+	t.Run("rejects_a_canceled_basket", func(t *testing.T) {
+		estesting.Given(newTestBasket,
+			&BasketStarted{CustomerID: "customer-id"},
+			&BasketItemAdded{Item: item},
+			&BasketCanceled{},
+		).When(func(basket *Basket) error {
+			_, err := basket.Checkout("payment-id")
+			return err
+		}).ThenError(t, ErrBasketCannotBeModified)
+	})
+}
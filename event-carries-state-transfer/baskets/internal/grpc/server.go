@@ -5,10 +5,13 @@ import (
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"eda-in-golang/baskets/basketspb"
 	"eda-in-golang/baskets/internal/application"
 	"eda-in-golang/baskets/internal/domain"
+	"eda-in-golang/internal/es"
 )
 
 type server struct {
@@ -83,6 +86,24 @@ func (s server) GetBasket(ctx context.Context, request *basketspb.GetBasketReque
 	}, nil
 }
 
+func (s server) GetBasketHistory(ctx context.Context, request *basketspb.GetBasketHistoryRequest) (*basketspb.GetBasketHistoryResponse, error) {
+	events, err := s.app.GetBasketHistory(ctx, application.GetBasketHistory{
+		ID: request.GetId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.historyFromDomain(events)
+	if err != nil {
+		return nil, err
+	}
+
+	return &basketspb.GetBasketHistoryResponse{
+		Events: history,
+	}, nil
+}
+
 func (s server) basketFromDomain(basket *domain.Basket) *basketspb.Basket {
 	protoBasket := &basketspb.Basket{
 		Id: basket.ID(),
@@ -103,3 +124,23 @@ func (s server) basketFromDomain(basket *domain.Basket) *basketspb.Basket {
 
 	return protoBasket
 }
+
+func (s server) historyFromDomain(events []es.HistoryEvent) ([]*basketspb.HistoryEvent, error) {
+	history := make([]*basketspb.HistoryEvent, len(events))
+	for i, event := range events {
+		metadata, err := structpb.NewStruct(event.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		history[i] = &basketspb.HistoryEvent{
+			Id:         event.ID,
+			Name:       event.Name,
+			Version:    int32(event.Version),
+			OccurredAt: timestamppb.New(event.OccurredAt),
+			Payload:    string(event.Payload),
+			Metadata:   metadata,
+		}
+	}
+
+	return history, nil
+}
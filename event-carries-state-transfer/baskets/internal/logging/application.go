@@ -7,6 +7,8 @@ import (
 
 	"eda-in-golang/baskets/internal/application"
 	"eda-in-golang/baskets/internal/domain"
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/es"
 )
 
 type Application struct {
@@ -24,37 +26,45 @@ func LogApplicationAccess(application application.App, logger zerolog.Logger) Ap
 }
 
 func (a Application) StartBasket(ctx context.Context, start application.StartBasket) (err error) {
-	a.logger.Info().Msg("--> Baskets.StartBasket")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Baskets.StartBasket") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Baskets.StartBasket")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Baskets.StartBasket") }()
 	return a.App.StartBasket(ctx, start)
 }
 
 func (a Application) CancelBasket(ctx context.Context, cancel application.CancelBasket) (err error) {
-	a.logger.Info().Msg("--> Baskets.CancelBasket")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Baskets.CancelBasket") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Baskets.CancelBasket")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Baskets.CancelBasket") }()
 	return a.App.CancelBasket(ctx, cancel)
 }
 
 func (a Application) CheckoutBasket(ctx context.Context, checkout application.CheckoutBasket) (err error) {
-	a.logger.Info().Msg("--> Baskets.CheckoutBasket")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Baskets.CheckoutBasket") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Baskets.CheckoutBasket")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Baskets.CheckoutBasket") }()
 	return a.App.CheckoutBasket(ctx, checkout)
 }
 
 func (a Application) AddItem(ctx context.Context, add application.AddItem) (err error) {
-	a.logger.Info().Msg("--> Baskets.AddItem")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Baskets.AddItem") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Baskets.AddItem")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Baskets.AddItem") }()
 	return a.App.AddItem(ctx, add)
 }
 
 func (a Application) RemoveItem(ctx context.Context, remove application.RemoveItem) (err error) {
-	a.logger.Info().Msg("--> Baskets.RemoveItem")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Baskets.RemoveItem") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Baskets.RemoveItem")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Baskets.RemoveItem") }()
 	return a.App.RemoveItem(ctx, remove)
 }
 
 func (a Application) GetBasket(ctx context.Context, get application.GetBasket) (basket *domain.Basket, err error) {
-	a.logger.Info().Msg("--> Baskets.GetBasket")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Baskets.GetBasket") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Baskets.GetBasket")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Baskets.GetBasket") }()
 	return a.App.GetBasket(ctx, get)
 }
+
+func (a Application) GetBasketHistory(ctx context.Context, get application.GetBasketHistory) (events []es.HistoryEvent, err error) {
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Baskets.GetBasketHistory")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Baskets.GetBasketHistory")
+	}()
+	return a.App.GetBasketHistory(ctx, get)
+}
@@ -15,8 +15,8 @@ import (
 	"eda-in-golang/internal/am"
 	"eda-in-golang/internal/ddd"
 	"eda-in-golang/internal/es"
-	"eda-in-golang/internal/jetstream"
 	"eda-in-golang/internal/monolith"
+	"eda-in-golang/internal/prom"
 	"eda-in-golang/internal/registry"
 	"eda-in-golang/internal/registry/serdes"
 	"eda-in-golang/stores/storespb"
@@ -41,11 +41,23 @@ func (m *Module) Startup(ctx context.Context, mono monolith.Monolith) (err error
 	if err = storespb.Registrations(reg); err != nil {
 		return err
 	}
-	eventStream := am.NewEventStream(reg, jetstream.NewStream(mono.Config().Nats.Stream, mono.JS(), mono.Logger()))
+	stream := am.RawMessageStreamWithMiddleware(
+		mono.Stream(mono.Logger()),
+		prom.StreamMetrics(),
+	)
+	eventStream := am.NewEventStream(reg, stream)
 	domainDispatcher := ddd.NewEventDispatcher[ddd.Event]()
+	eventStore := pg.NewEventStore("baskets.events", mono.DB(), reg)
 	aggregateStore := es.AggregateStoreWithMiddleware(
-		pg.NewEventStore("baskets.events", mono.DB(), reg),
-		pg.NewSnapshotStore("baskets.snapshots", mono.DB(), reg),
+		eventStore,
+		pg.NewSnapshotStore("baskets.snapshots", mono.DB(), reg,
+			es.WithSnapshotStrategy(domain.BasketAggregate, es.AnyOf(
+				es.EveryNEvents(3),
+				es.OnEvents(domain.BasketCheckedOutEvent, domain.BasketCanceledEvent),
+			)),
+			es.WithSnapshotMetrics(prom.SnapshotMetrics()),
+		),
+		prom.AggregateStoreMetrics(),
 	)
 	baskets := es.NewAggregateRepository[*domain.Basket](domain.BasketAggregate, reg, aggregateStore)
 	conn, err := grpc.Dial(ctx, mono.Config().Rpc.Address())
@@ -57,7 +69,7 @@ func (m *Module) Startup(ctx context.Context, mono monolith.Monolith) (err error
 
 	// setup application
 	app := logging.LogApplicationAccess(
-		application.New(baskets, stores, products, domainDispatcher),
+		application.New(baskets, eventStore, stores, products, domainDispatcher),
 		mono.Logger(),
 	)
 	domainEventHandlers := logging.LogEventHandlerAccess[ddd.Event](
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"eda-in-golang/internal/config"
+	"eda-in-golang/internal/postgres"
+	"eda-in-golang/internal/tm"
+)
+
+// inboxModules are the modules that keep an inbox
+var inboxModules = []string{"customers", "depot", "ordering", "payments"}
+
+// runInbox lists the messages received by the inboxes of the named modules, or
+// of every module when none are named; by default it answers which messages
+// failed in the last hour
+//
+//	mallbots inbox [-outcome failed] [-since 1h] [-handler name] [-name message] [-limit 100] [module]...
+func runInbox(args []string) (err error) {
+	flags := flag.NewFlagSet("inbox", flag.ContinueOnError)
+	outcome := flags.String("outcome", tm.InboxFailed, "the outcome to list: handled, failed, skipped, or empty for any outcome")
+	since := flags.Duration("since", time.Hour, "how far back to look")
+	handler := flags.String("handler", "", "only list the messages taken by this handler")
+	name := flags.String("name", "", "only list the messages with this name")
+	limit := flags.Int("limit", 100, "the most messages to list for each module")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: mallbots inbox [-outcome failed] [-since 1h] [-handler name] [-name message] [-limit 100] [module]...")
+		flags.PrintDefaults()
+	}
+	if err = flags.Parse(args); err != nil {
+		return err
+	}
+
+	modules := flags.Args()
+	if len(modules) == 0 {
+		modules = inboxModules
+	}
+	for _, module := range modules {
+		if !hasInbox(module) {
+			return fmt.Errorf("the module %q has no inbox", module)
+		}
+	}
+
+	var cfg config.AppConfig
+	cfg, err = config.InitConfig()
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open("pgx", cfg.PG.Conn)
+	if err != nil {
+		return err
+	}
+	defer func(db *sql.DB) {
+		err := db.Close()
+		if err != nil {
+			return
+		}
+	}(db)
+
+	query := tm.FailedSince(time.Now().Add(-*since))
+	query.Outcome = *outcome
+	query.Handler = *handler
+	query.Name = *name
+	query.Limit = *limit
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MODULE\tRECEIVED\tOUTCOME\tDURATION\tHANDLER\tNAME\tID\tERROR")
+	for _, module := range modules {
+		var records []tm.InboxRecord
+		records, err = postgres.NewInboxStore(module+".inbox", db).FindMessages(context.Background(), query)
+		if err != nil {
+			return fmt.Errorf("reading the %s inbox: %w", module, err)
+		}
+		for _, record := range records {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				module,
+				record.ReceivedAt.Format(time.RFC3339),
+				record.Outcome,
+				record.Duration().Round(time.Millisecond),
+				record.Handler,
+				record.Name,
+				record.ID,
+				record.Error,
+			)
+		}
+	}
+
+	return w.Flush()
+}
+
+func hasInbox(module string) bool {
+	for _, m := range inboxModules {
+		if m == module {
+			return true
+		}
+	}
+	return false
+}
@@ -1,18 +1,23 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	"eda-in-golang/baskets"
 	"eda-in-golang/cosec"
@@ -20,8 +25,11 @@ import (
 	"eda-in-golang/depot"
 	"eda-in-golang/internal/config"
 	"eda-in-golang/internal/logger"
+	"eda-in-golang/internal/memstream"
 	"eda-in-golang/internal/monolith"
+	"eda-in-golang/internal/prom"
 	"eda-in-golang/internal/rpc"
+	"eda-in-golang/internal/tracing"
 	"eda-in-golang/internal/waiter"
 	"eda-in-golang/internal/web"
 	"eda-in-golang/notifications"
@@ -31,8 +39,20 @@ import (
 	"eda-in-golang/stores"
 )
 
+// tracingShutdownTimeout bounds how long the remaining spans may take to export
+const tracingShutdownTimeout = 5 * time.Second
+
 func main() {
-	if err := run(); err != nil {
+	var err error
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == "rebuild":
+		err = runRebuild(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "inbox":
+		err = runInbox(os.Args[2:])
+	default:
+		err = run()
+	}
+	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
@@ -46,6 +66,11 @@ func run() (err error) {
 		return err
 	}
 
+	return runMonolith(cfg, waiter.New(waiter.CatchSignals()))
+}
+
+// runMonolith starts every module and runs them until the waiter is canceled
+func runMonolith(cfg config.AppConfig, w waiter.Waiter) (err error) {
 	m := app{cfg: cfg}
 
 	// init infrastructure...
@@ -60,39 +85,56 @@ func run() (err error) {
 			return
 		}
 	}(m.db)
-	// init nats & jetstream
-	m.nc, err = nats.Connect(cfg.Nats.URL)
-	if err != nil {
-		return err
+	m.logger = initLogger(cfg)
+	// init the message transport
+	switch cfg.Transport {
+	case config.TransportJetStream:
+		if cfg.Nats.URL == "" {
+			return fmt.Errorf("the %s transport requires NATS_URL", cfg.Transport)
+		}
+		m.nc, err = nats.Connect(cfg.Nats.URL)
+		if err != nil {
+			return err
+		}
+		defer m.nc.Close()
+		m.js, err = initJetStream(cfg.Nats, m.nc)
+		if err != nil {
+			return err
+		}
+	case config.TransportMemStream:
+		// the modules share a stream in this process; messages that have
+		// not been handled when the application stops are lost
+		m.stream = memstream.NewStream(m.logger)
+	default:
+		return fmt.Errorf("unknown transport %q", cfg.Transport)
 	}
-	defer m.nc.Close()
-	m.js, err = initJetStream(cfg.Nats, m.nc)
+	// init tracing
+	tp, err := initTracing(cfg.Tracing)
 	if err != nil {
 		return err
 	}
-	m.logger = initLogger(cfg)
+	defer func(tp *sdktrace.TracerProvider) {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		// the spans that could not be exported are lost; report it with the
+		// error the application stopped with, if any
+		if shutdownErr := tp.Shutdown(ctx); shutdownErr != nil {
+			err = errors.Join(err, fmt.Errorf("shutting down tracing: %w", shutdownErr))
+		}
+	}(tp)
 	m.rpc = initRpc(cfg.Rpc)
 	m.mux = initMux(cfg.Web)
-	m.waiter = waiter.New(waiter.CatchSignals())
+	m.waiter = w
 
 	// init modules
-	m.modules = []monolith.Module{
-		&baskets.Module{},
-		&customers.Module{},
-		&depot.Module{},
-		&notifications.Module{},
-		&ordering.Module{},
-		&payments.Module{},
-		&stores.Module{},
-		&search.Module{},
-		&cosec.Module{},
-	}
+	m.modules = modules()
 
 	if err = m.startupModules(); err != nil {
 		return err
 	}
 
 	// Mount general web resources
+	m.mux.Handle("/metrics", prom.Handler())
 	m.mux.Mount("/", http.FileServer(http.FS(web.WebUI)))
 
 	fmt.Println("started mallbots application")
@@ -107,6 +149,20 @@ func run() (err error) {
 	return m.waiter.Wait()
 }
 
+func modules() []monolith.Module {
+	return []monolith.Module{
+		&baskets.Module{},
+		&customers.Module{},
+		&depot.Module{},
+		&notifications.Module{},
+		&ordering.Module{},
+		&payments.Module{},
+		&stores.Module{},
+		&search.Module{},
+		&cosec.Module{},
+	}
+}
+
 func initLogger(cfg config.AppConfig) zerolog.Logger {
 	return logger.New(logger.LogConfig{
 		Environment: cfg.Environment,
@@ -114,8 +170,21 @@ func initLogger(cfg config.AppConfig) zerolog.Logger {
 	})
 }
 
+func initTracing(cfg tracing.TracingConfig) (*sdktrace.TracerProvider, error) {
+	tp, err := tracing.NewTracerProvider(context.Background(), cfg)
+	if err != nil {
+		return nil, err
+	}
+	tracing.Register(tp)
+
+	return tp, nil
+}
+
 func initRpc(_ rpc.RpcConfig) *grpc.Server {
-	server := grpc.NewServer()
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(rpc.MessageIDsServerInterceptor()),
+	)
 	reflection.Register(server)
 
 	return server
@@ -14,7 +14,10 @@ import (
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 
+	"eda-in-golang/internal/am"
 	"eda-in-golang/internal/config"
+	"eda-in-golang/internal/jetstream"
+	"eda-in-golang/internal/memstream"
 	"eda-in-golang/internal/monolith"
 	"eda-in-golang/internal/waiter"
 )
@@ -24,6 +27,7 @@ type app struct {
 	db      *sql.DB
 	nc      *nats.Conn
 	js      nats.JetStreamContext
+	stream  *memstream.Stream
 	logger  zerolog.Logger
 	modules []monolith.Module
 	mux     *chi.Mux
@@ -47,6 +51,14 @@ func (a *app) Logger() zerolog.Logger {
 	return a.logger
 }
 
+func (a *app) Stream(logger zerolog.Logger, options ...jetstream.StreamOption) am.RawMessageStream {
+	if a.stream != nil {
+		return a.stream
+	}
+
+	return jetstream.NewStream(a.cfg.Nats.Stream, a.js, logger, options...)
+}
+
 func (a *app) Mux() *chi.Mux {
 	return a.mux
 }
@@ -137,6 +149,13 @@ func (a *app) waitForRPC(ctx context.Context) error {
 }
 
 func (a *app) waitForStream(ctx context.Context) error {
+	if a.stream != nil {
+		fmt.Println("message stream started")
+		defer fmt.Println("message stream stopped")
+		<-ctx.Done()
+		return a.stream.Close()
+	}
+
 	closed := make(chan struct{})
 	a.nc.SetClosedHandler(func(*nats.Conn) {
 		close(closed)
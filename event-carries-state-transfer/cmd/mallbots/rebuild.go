@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"eda-in-golang/internal/config"
+	"eda-in-golang/internal/rebuild"
+	"eda-in-golang/internal/waiter"
+)
+
+const rebuildProgressInterval = time.Second
+
+// runRebuild clears the named read models and rebuilds them from their source
+// events; it may be run alongside the application
+//
+//	mallbots rebuild [-dry-run] [-list] <read model>...
+func runRebuild(args []string) (err error) {
+	flags := flag.NewFlagSet("rebuild", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "replay the events and then discard the rebuilt read model")
+	list := flags.Bool("list", false, "list the read models that can be rebuilt")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: mallbots rebuild [-dry-run] [-list] <read model>...")
+		flags.PrintDefaults()
+	}
+	if err = flags.Parse(args); err != nil {
+		return err
+	}
+
+	var cfg config.AppConfig
+	cfg, err = config.InitConfig()
+	if err != nil {
+		return err
+	}
+
+	m := app{cfg: cfg}
+
+	m.db, err = sql.Open("pgx", cfg.PG.Conn)
+	if err != nil {
+		return err
+	}
+	defer func(db *sql.DB) {
+		err := db.Close()
+		if err != nil {
+			return
+		}
+	}(m.db)
+	// the events of the read models are replayed from JetStream
+	if cfg.Nats.URL == "" {
+		return fmt.Errorf("rebuilding a read model requires NATS_URL")
+	}
+	m.nc, err = nats.Connect(cfg.Nats.URL)
+	if err != nil {
+		return err
+	}
+	defer m.nc.Close()
+	m.js, err = initJetStream(cfg.Nats, m.nc)
+	if err != nil {
+		return err
+	}
+	m.logger = initLogger(cfg)
+	m.waiter = waiter.New(waiter.CatchSignals())
+	m.modules = modules()
+
+	ctx := m.waiter.Context()
+
+	readModels := make(map[string]rebuild.ReadModel)
+	for _, module := range m.modules {
+		rebuildable, ok := module.(rebuild.Module)
+		if !ok {
+			continue
+		}
+		var models []rebuild.ReadModel
+		if models, err = rebuildable.ReadModels(ctx, &m); err != nil {
+			return err
+		}
+		for _, model := range models {
+			readModels[model.ReadModelName()] = model
+		}
+	}
+
+	if *list {
+		names := make([]string, 0, len(readModels))
+		for name := range readModels {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return nil
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("no read models given; use -list to see the read models that can be rebuilt")
+	}
+
+	for _, name := range flags.Args() {
+		if _, exists := readModels[name]; !exists {
+			return fmt.Errorf("unknown read model %q; use -list to see the read models that can be rebuilt", name)
+		}
+	}
+
+	for _, name := range flags.Args() {
+		if err = rebuildReadModel(ctx, readModels[name], *dryRun); err != nil {
+			return fmt.Errorf("rebuilding %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func rebuildReadModel(ctx context.Context, model rebuild.ReadModel, dryRun bool) error {
+	name := model.ReadModelName()
+	if dryRun {
+		fmt.Printf("rebuilding %s (dry run)\n", name)
+	} else {
+		fmt.Printf("rebuilding %s\n", name)
+	}
+
+	start := time.Now()
+	lastReport := start
+	total := 0
+
+	err := model.Rebuild(ctx, dryRun, func(position int64, replayed int) {
+		total = replayed
+		if time.Since(lastReport) >= rebuildProgressInterval {
+			lastReport = time.Now()
+			fmt.Printf("  %s: %d events replayed; at position %d\n", name, replayed, position)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		fmt.Printf("replayed %d events for %s in %s; changes discarded\n", total, name, time.Since(start).Round(time.Millisecond))
+	} else {
+		fmt.Printf("rebuilt %s from %d events in %s\n", name, total, time.Since(start).Round(time.Millisecond))
+	}
+
+	return nil
+}
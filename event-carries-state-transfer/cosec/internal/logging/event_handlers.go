@@ -5,6 +5,7 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"eda-in-golang/internal/am"
 	"eda-in-golang/internal/ddd"
 )
 
@@ -25,7 +26,9 @@ func LogEventHandlerAccess[T ddd.Event](handlers ddd.EventHandler[T], label stri
 }
 
 func (h EventHandlers[T]) HandleEvent(ctx context.Context, event T) (err error) {
-	h.logger.Info().Msgf("--> COSEC.%s.On(%s)", h.label, event.EventName())
-	defer func() { h.logger.Info().Err(err).Msgf("<-- COSEC.%s.On(%s)", h.label, event.EventName()) }()
+	h.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msgf("--> COSEC.%s.On(%s)", h.label, event.EventName())
+	defer func() {
+		h.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msgf("<-- COSEC.%s.On(%s)", h.label, event.EventName())
+	}()
 	return h.EventHandler.HandleEvent(ctx, event)
 }
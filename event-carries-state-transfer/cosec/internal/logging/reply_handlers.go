@@ -5,6 +5,7 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"eda-in-golang/internal/am"
 	"eda-in-golang/internal/ddd"
 	"eda-in-golang/internal/sec"
 )
@@ -26,7 +27,9 @@ func LogReplyHandlerAccess[T any](orc sec.Orchestrator[T], label string, logger
 }
 
 func (h sagaReplyHandlers[T]) HandleReply(ctx context.Context, reply ddd.Reply) (err error) {
-	h.logger.Info().Msgf("--> COSEC.%s.HandleReply(%s)", h.label, reply.ReplyName())
-	defer func() { h.logger.Info().Err(err).Msgf("<-- COSEC.%s.HandleReply(%s)", h.label, reply.ReplyName()) }()
+	h.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msgf("--> COSEC.%s.HandleReply(%s)", h.label, reply.ReplyName())
+	defer func() {
+		h.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msgf("<-- COSEC.%s.HandleReply(%s)", h.label, reply.ReplyName())
+	}()
 	return h.Orchestrator.HandleReply(ctx, reply)
 }
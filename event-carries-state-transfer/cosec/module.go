@@ -13,8 +13,8 @@ import (
 	"eda-in-golang/depot/depotpb"
 	"eda-in-golang/internal/am"
 	"eda-in-golang/internal/ddd"
-	"eda-in-golang/internal/jetstream"
 	"eda-in-golang/internal/monolith"
+	"eda-in-golang/internal/prom"
 	"eda-in-golang/internal/registry"
 	"eda-in-golang/internal/registry/serdes"
 	"eda-in-golang/internal/sec"
@@ -46,7 +46,10 @@ func (Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 	if err = paymentspb.Registrations(reg); err != nil {
 		return err
 	}
-	stream := jetstream.NewStream(mono.Config().Nats.Stream, mono.JS(), mono.Logger())
+	stream := am.RawMessageStreamWithMiddleware(
+		mono.Stream(mono.Logger()),
+		prom.StreamMetrics(),
+	)
 	eventStream := am.NewEventStream(reg, stream)
 	commandStream := am.NewCommandStream(reg, stream)
 	replyStream := am.NewReplyStream(reg, stream)
@@ -55,7 +58,9 @@ func (Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 
 	// setup application
 	orchestrator := logging.LogReplyHandlerAccess[*models.CreateOrderData](
-		sec.NewOrchestrator[*models.CreateOrderData](internal.NewCreateOrderSaga(), sagaRepo, commandStream),
+		sec.NewOrchestrator[*models.CreateOrderData](internal.NewCreateOrderSaga(), sagaRepo, commandStream,
+			sec.OrchestratorMetrics(prom.SagaMetrics()),
+		),
 		"CreateOrderSaga", mono.Logger(),
 	)
 	integrationEventHandlers := logging.LogEventHandlerAccess[ddd.Event](
@@ -0,0 +1,200 @@
+package cosec
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/cosec/internal"
+	"eda-in-golang/cosec/internal/handlers"
+	"eda-in-golang/cosec/internal/models"
+	"eda-in-golang/customers/customerspb"
+	"eda-in-golang/depot/depotpb"
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/memstream"
+	"eda-in-golang/internal/registry"
+	"eda-in-golang/internal/sec"
+	"eda-in-golang/ordering/orderingpb"
+	"eda-in-golang/payments/paymentspb"
+)
+
+// testSagaStore keeps the sagas in memory
+type testSagaStore struct {
+	sagas map[string]sec.SagaKontext[[]byte]
+	mu    sync.Mutex
+}
+
+func (s *testSagaStore) Load(_ context.Context, sagaName, sagaID string) (*sec.SagaKontext[[]byte], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sagaCtx, exists := s.sagas[sagaName+sagaID]
+	if !exists {
+		return nil, errors.New("saga not found")
+	}
+
+	return &sagaCtx, nil
+}
+
+func (s *testSagaStore) Save(_ context.Context, sagaName string, sagaCtx *sec.SagaKontext[[]byte]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sagas[sagaName+sagaCtx.ID] = *sagaCtx
+
+	return nil
+}
+
+func (s *testSagaStore) saga(sagaID string) (sec.SagaKontext[[]byte], bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sagaCtx, exists := s.sagas[internal.CreateOrderSagaName+sagaID]
+
+	return sagaCtx, exists
+}
+
+// participants stand in for the modules the saga sends its commands to; they
+// record the commands in the order they were received
+type participants struct {
+	failing  map[string]bool
+	commands []string
+	mu       sync.Mutex
+}
+
+func (p *participants) HandleCommand(_ context.Context, cmd ddd.Command) (ddd.Reply, error) {
+	p.mu.Lock()
+	p.commands = append(p.commands, cmd.CommandName())
+	p.mu.Unlock()
+
+	if p.failing[cmd.CommandName()] {
+		return nil, errors.New("command failed")
+	}
+
+	if cmd.CommandName() == depotpb.CreateShoppingListCommand {
+		return ddd.NewReply(depotpb.CreatedShoppingListReply, &depotpb.CreatedShoppingList{Id: "shopping-id"}), nil
+	}
+
+	return nil, nil
+}
+
+func (p *participants) received() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]string(nil), p.commands...)
+}
+
+// startSaga wires the module the way Startup does, over an in-process stream,
+// and publishes an OrderCreated event to start a saga
+func startSaga(t *testing.T, p *participants) (*testSagaStore, string) {
+	t.Helper()
+
+	reg := registry.New()
+	require.NoError(t, registrations(reg))
+	require.NoError(t, orderingpb.Registrations(reg))
+	require.NoError(t, customerspb.Registrations(reg))
+	require.NoError(t, depotpb.Registrations(reg))
+	require.NoError(t, paymentspb.Registrations(reg))
+
+	stream := memstream.NewStream(zerolog.Nop())
+	t.Cleanup(func() { _ = stream.Close() })
+	eventStream := am.NewEventStream(reg, stream)
+	commandStream := am.NewCommandStream(reg, stream)
+	replyStream := am.NewReplyStream(reg, stream)
+	sagaStore := &testSagaStore{sagas: make(map[string]sec.SagaKontext[[]byte])}
+
+	orchestrator := sec.NewOrchestrator[*models.CreateOrderData](
+		internal.NewCreateOrderSaga(),
+		sec.NewSagaRepository[*models.CreateOrderData](reg, sagaStore),
+		commandStream,
+	)
+	require.NoError(t, handlers.RegisterIntegrationEventHandlers(eventStream, handlers.NewIntegrationEventHandlers(orchestrator)))
+	require.NoError(t, handlers.RegisterReplyHandlers(replyStream, orchestrator))
+
+	commandHandler := am.NewCommandMessageHandler(reg, replyStream, p)
+	for _, channel := range []string{
+		customerspb.CommandChannel,
+		depotpb.CommandChannel,
+		orderingpb.CommandChannel,
+		paymentspb.CommandChannel,
+	} {
+		require.NoError(t, stream.Subscribe(channel, commandHandler))
+	}
+
+	event := ddd.NewEvent(orderingpb.OrderCreatedEvent, &orderingpb.OrderCreated{
+		Id:         "order-id",
+		CustomerId: "customer-id",
+		PaymentId:  "payment-id",
+		Items: []*orderingpb.OrderCreated_Item{
+			{ProductId: "product-id", StoreId: "store-id", Price: 10, Quantity: 2},
+		},
+	})
+	require.NoError(t, eventStream.Publish(context.Background(), orderingpb.OrderAggregateChannel, event))
+
+	return sagaStore, event.ID()
+}
+
+func TestCreateOrderSaga(t *testing.T) {
+	tests := map[string]struct {
+		failing      []string
+		commands     []string
+		compensating bool
+	}{
+		"Approved": {
+			commands: []string{
+				customerspb.AuthorizeCustomerCommand,
+				depotpb.CreateShoppingListCommand,
+				paymentspb.ConfirmPaymentCommand,
+				depotpb.InitiateShoppingCommand,
+				orderingpb.ApproveOrderCommand,
+			},
+		},
+		"PaymentFails": {
+			failing: []string{paymentspb.ConfirmPaymentCommand},
+			commands: []string{
+				customerspb.AuthorizeCustomerCommand,
+				depotpb.CreateShoppingListCommand,
+				paymentspb.ConfirmPaymentCommand,
+				depotpb.CancelShoppingListCommand,
+				orderingpb.RejectOrderCommand,
+			},
+			compensating: true,
+		},
+		"CustomerNotAuthorized": {
+			failing: []string{customerspb.AuthorizeCustomerCommand},
+			commands: []string{
+				customerspb.AuthorizeCustomerCommand,
+				orderingpb.RejectOrderCommand,
+			},
+			compensating: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &participants{failing: make(map[string]bool)}
+			for _, command := range tt.failing {
+				p.failing[command] = true
+			}
+
+			sagaStore, sagaID := startSaga(t, p)
+
+			require.Eventually(t, func() bool {
+				sagaCtx, exists := sagaStore.saga(sagaID)
+				return exists && sagaCtx.Done
+			}, 5*time.Second, 10*time.Millisecond, "the saga did not complete")
+
+			sagaCtx, _ := sagaStore.saga(sagaID)
+			assert.Equal(t, tt.compensating, sagaCtx.Compensating)
+			assert.Equal(t, tt.commands, p.received())
+		})
+	}
+}
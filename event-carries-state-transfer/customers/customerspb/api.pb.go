@@ -9,6 +9,8 @@ package customerspb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -513,6 +515,86 @@ func (*AuthorizeCustomerResponse) Descriptor() ([]byte, []int) {
 	return file_customerspb_api_proto_rawDescGZIP(), []int{10}
 }
 
+type ForgetCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForgetCustomerRequest) Reset() {
+	*x = ForgetCustomerRequest{}
+	mi := &file_customerspb_api_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForgetCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForgetCustomerRequest) ProtoMessage() {}
+
+func (x *ForgetCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customerspb_api_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForgetCustomerRequest.ProtoReflect.Descriptor instead.
+func (*ForgetCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_customerspb_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ForgetCustomerRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ForgetCustomerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForgetCustomerResponse) Reset() {
+	*x = ForgetCustomerResponse{}
+	mi := &file_customerspb_api_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForgetCustomerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForgetCustomerResponse) ProtoMessage() {}
+
+func (x *ForgetCustomerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_customerspb_api_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForgetCustomerResponse.ProtoReflect.Descriptor instead.
+func (*ForgetCustomerResponse) Descriptor() ([]byte, []int) {
+	return file_customerspb_api_proto_rawDescGZIP(), []int{12}
+}
+
 type GetCustomerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -522,7 +604,7 @@ type GetCustomerRequest struct {
 
 func (x *GetCustomerRequest) Reset() {
 	*x = GetCustomerRequest{}
-	mi := &file_customerspb_api_proto_msgTypes[11]
+	mi := &file_customerspb_api_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -534,7 +616,7 @@ func (x *GetCustomerRequest) String() string {
 func (*GetCustomerRequest) ProtoMessage() {}
 
 func (x *GetCustomerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_customerspb_api_proto_msgTypes[11]
+	mi := &file_customerspb_api_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -547,7 +629,7 @@ func (x *GetCustomerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCustomerRequest.ProtoReflect.Descriptor instead.
 func (*GetCustomerRequest) Descriptor() ([]byte, []int) {
-	return file_customerspb_api_proto_rawDescGZIP(), []int{11}
+	return file_customerspb_api_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetCustomerRequest) GetId() string {
@@ -566,7 +648,7 @@ type GetCustomerResponse struct {
 
 func (x *GetCustomerResponse) Reset() {
 	*x = GetCustomerResponse{}
-	mi := &file_customerspb_api_proto_msgTypes[12]
+	mi := &file_customerspb_api_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -578,7 +660,7 @@ func (x *GetCustomerResponse) String() string {
 func (*GetCustomerResponse) ProtoMessage() {}
 
 func (x *GetCustomerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_customerspb_api_proto_msgTypes[12]
+	mi := &file_customerspb_api_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,7 +673,7 @@ func (x *GetCustomerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCustomerResponse.ProtoReflect.Descriptor instead.
 func (*GetCustomerResponse) Descriptor() ([]byte, []int) {
-	return file_customerspb_api_proto_rawDescGZIP(), []int{12}
+	return file_customerspb_api_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetCustomerResponse) GetCustomer() *Customer {
@@ -601,11 +683,184 @@ func (x *GetCustomerResponse) GetCustomer() *Customer {
 	return nil
 }
 
+// HistoryEvent is a stored event with its payload rendered as JSON
+type HistoryEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Payload       string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryEvent) Reset() {
+	*x = HistoryEvent{}
+	mi := &file_customerspb_api_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryEvent) ProtoMessage() {}
+
+func (x *HistoryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_customerspb_api_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryEvent.ProtoReflect.Descriptor instead.
+func (*HistoryEvent) Descriptor() ([]byte, []int) {
+	return file_customerspb_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *HistoryEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HistoryEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HistoryEvent) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *HistoryEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *HistoryEvent) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *HistoryEvent) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GetCustomerHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerHistoryRequest) Reset() {
+	*x = GetCustomerHistoryRequest{}
+	mi := &file_customerspb_api_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerHistoryRequest) ProtoMessage() {}
+
+func (x *GetCustomerHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_customerspb_api_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetCustomerHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_customerspb_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetCustomerHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCustomerHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*HistoryEvent        `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerHistoryResponse) Reset() {
+	*x = GetCustomerHistoryResponse{}
+	mi := &file_customerspb_api_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerHistoryResponse) ProtoMessage() {}
+
+func (x *GetCustomerHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_customerspb_api_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetCustomerHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_customerspb_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetCustomerHistoryResponse) GetEvents() []*HistoryEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
 var File_customerspb_api_proto protoreflect.FileDescriptor
 
 const file_customerspb_api_proto_rawDesc = "" +
 	"\n" +
-	"\x15customerspb/api.proto\x12\vcustomerspb\"g\n" +
+	"\x15customerspb/api.proto\x12\vcustomerspb\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"g\n" +
 	"\bCustomer\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
@@ -631,18 +886,35 @@ const file_customerspb_api_proto_rawDesc = "" +
 	"\x17ChangeSmsNumberResponse\"*\n" +
 	"\x18AuthorizeCustomerRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\x1b\n" +
-	"\x19AuthorizeCustomerResponse\"$\n" +
+	"\x19AuthorizeCustomerResponse\"'\n" +
+	"\x15ForgetCustomerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x16ForgetCustomerResponse\"$\n" +
 	"\x12GetCustomerRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
 	"\x13GetCustomerResponse\x121\n" +
-	"\bcustomer\x18\x01 \x01(\v2\x15.customerspb.CustomerR\bcustomer2\xcc\x04\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x15.customerspb.CustomerR\bcustomer\"\xd8\x01\n" +
+	"\fHistoryEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x123\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\"+\n" +
+	"\x19GetCustomerHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"O\n" +
+	"\x1aGetCustomerHistoryResponse\x121\n" +
+	"\x06events\x18\x01 \x03(\v2\x19.customerspb.HistoryEventR\x06events2\x92\x06\n" +
 	"\x10CustomersService\x12a\n" +
 	"\x10RegisterCustomer\x12$.customerspb.RegisterCustomerRequest\x1a%.customerspb.RegisterCustomerResponse\"\x00\x12[\n" +
 	"\x0eEnableCustomer\x12\".customerspb.EnableCustomerRequest\x1a#.customerspb.EnableCustomerResponse\"\x00\x12^\n" +
 	"\x0fDisableCustomer\x12#.customerspb.DisableCustomerRequest\x1a$.customerspb.DisableCustomerResponse\"\x00\x12^\n" +
 	"\x0fChangeSmsNumber\x12#.customerspb.ChangeSmsNumberRequest\x1a$.customerspb.ChangeSmsNumberResponse\"\x00\x12d\n" +
 	"\x11AuthorizeCustomer\x12%.customerspb.AuthorizeCustomerRequest\x1a&.customerspb.AuthorizeCustomerResponse\"\x00\x12R\n" +
-	"\vGetCustomer\x12\x1f.customerspb.GetCustomerRequest\x1a .customerspb.GetCustomerResponse\"\x00B\x98\x01\n" +
+	"\vGetCustomer\x12\x1f.customerspb.GetCustomerRequest\x1a .customerspb.GetCustomerResponse\"\x00\x12g\n" +
+	"\x12GetCustomerHistory\x12&.customerspb.GetCustomerHistoryRequest\x1a'.customerspb.GetCustomerHistoryResponse\"\x00\x12[\n" +
+	"\x0eForgetCustomer\x12\".customerspb.ForgetCustomerRequest\x1a#.customerspb.ForgetCustomerResponse\"\x00B\x98\x01\n" +
 	"\x0fcom.customerspbB\bApiProtoP\x01Z/eda-in-golang/customers/customerspb/customerspb\xa2\x02\x03CXX\xaa\x02\vCustomerspb\xca\x02\vCustomerspb\xe2\x02\x17Customerspb\\GPBMetadata\xea\x02\vCustomerspbb\x06proto3"
 
 var (
@@ -657,41 +929,55 @@ func file_customerspb_api_proto_rawDescGZIP() []byte {
 	return file_customerspb_api_proto_rawDescData
 }
 
-var file_customerspb_api_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_customerspb_api_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_customerspb_api_proto_goTypes = []any{
-	(*Customer)(nil),                  // 0: customerspb.Customer
-	(*RegisterCustomerRequest)(nil),   // 1: customerspb.RegisterCustomerRequest
-	(*RegisterCustomerResponse)(nil),  // 2: customerspb.RegisterCustomerResponse
-	(*EnableCustomerRequest)(nil),     // 3: customerspb.EnableCustomerRequest
-	(*EnableCustomerResponse)(nil),    // 4: customerspb.EnableCustomerResponse
-	(*DisableCustomerRequest)(nil),    // 5: customerspb.DisableCustomerRequest
-	(*DisableCustomerResponse)(nil),   // 6: customerspb.DisableCustomerResponse
-	(*ChangeSmsNumberRequest)(nil),    // 7: customerspb.ChangeSmsNumberRequest
-	(*ChangeSmsNumberResponse)(nil),   // 8: customerspb.ChangeSmsNumberResponse
-	(*AuthorizeCustomerRequest)(nil),  // 9: customerspb.AuthorizeCustomerRequest
-	(*AuthorizeCustomerResponse)(nil), // 10: customerspb.AuthorizeCustomerResponse
-	(*GetCustomerRequest)(nil),        // 11: customerspb.GetCustomerRequest
-	(*GetCustomerResponse)(nil),       // 12: customerspb.GetCustomerResponse
+	(*Customer)(nil),                   // 0: customerspb.Customer
+	(*RegisterCustomerRequest)(nil),    // 1: customerspb.RegisterCustomerRequest
+	(*RegisterCustomerResponse)(nil),   // 2: customerspb.RegisterCustomerResponse
+	(*EnableCustomerRequest)(nil),      // 3: customerspb.EnableCustomerRequest
+	(*EnableCustomerResponse)(nil),     // 4: customerspb.EnableCustomerResponse
+	(*DisableCustomerRequest)(nil),     // 5: customerspb.DisableCustomerRequest
+	(*DisableCustomerResponse)(nil),    // 6: customerspb.DisableCustomerResponse
+	(*ChangeSmsNumberRequest)(nil),     // 7: customerspb.ChangeSmsNumberRequest
+	(*ChangeSmsNumberResponse)(nil),    // 8: customerspb.ChangeSmsNumberResponse
+	(*AuthorizeCustomerRequest)(nil),   // 9: customerspb.AuthorizeCustomerRequest
+	(*AuthorizeCustomerResponse)(nil),  // 10: customerspb.AuthorizeCustomerResponse
+	(*ForgetCustomerRequest)(nil),      // 11: customerspb.ForgetCustomerRequest
+	(*ForgetCustomerResponse)(nil),     // 12: customerspb.ForgetCustomerResponse
+	(*GetCustomerRequest)(nil),         // 13: customerspb.GetCustomerRequest
+	(*GetCustomerResponse)(nil),        // 14: customerspb.GetCustomerResponse
+	(*HistoryEvent)(nil),               // 15: customerspb.HistoryEvent
+	(*GetCustomerHistoryRequest)(nil),  // 16: customerspb.GetCustomerHistoryRequest
+	(*GetCustomerHistoryResponse)(nil), // 17: customerspb.GetCustomerHistoryResponse
+	(*timestamppb.Timestamp)(nil),      // 18: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),            // 19: google.protobuf.Struct
 }
 var file_customerspb_api_proto_depIdxs = []int32{
 	0,  // 0: customerspb.GetCustomerResponse.customer:type_name -> customerspb.Customer
-	1,  // 1: customerspb.CustomersService.RegisterCustomer:input_type -> customerspb.RegisterCustomerRequest
-	3,  // 2: customerspb.CustomersService.EnableCustomer:input_type -> customerspb.EnableCustomerRequest
-	5,  // 3: customerspb.CustomersService.DisableCustomer:input_type -> customerspb.DisableCustomerRequest
-	7,  // 4: customerspb.CustomersService.ChangeSmsNumber:input_type -> customerspb.ChangeSmsNumberRequest
-	9,  // 5: customerspb.CustomersService.AuthorizeCustomer:input_type -> customerspb.AuthorizeCustomerRequest
-	11, // 6: customerspb.CustomersService.GetCustomer:input_type -> customerspb.GetCustomerRequest
-	2,  // 7: customerspb.CustomersService.RegisterCustomer:output_type -> customerspb.RegisterCustomerResponse
-	4,  // 8: customerspb.CustomersService.EnableCustomer:output_type -> customerspb.EnableCustomerResponse
-	6,  // 9: customerspb.CustomersService.DisableCustomer:output_type -> customerspb.DisableCustomerResponse
-	8,  // 10: customerspb.CustomersService.ChangeSmsNumber:output_type -> customerspb.ChangeSmsNumberResponse
-	10, // 11: customerspb.CustomersService.AuthorizeCustomer:output_type -> customerspb.AuthorizeCustomerResponse
-	12, // 12: customerspb.CustomersService.GetCustomer:output_type -> customerspb.GetCustomerResponse
-	7,  // [7:13] is the sub-list for method output_type
-	1,  // [1:7] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	18, // 1: customerspb.HistoryEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	19, // 2: customerspb.HistoryEvent.metadata:type_name -> google.protobuf.Struct
+	15, // 3: customerspb.GetCustomerHistoryResponse.events:type_name -> customerspb.HistoryEvent
+	1,  // 4: customerspb.CustomersService.RegisterCustomer:input_type -> customerspb.RegisterCustomerRequest
+	3,  // 5: customerspb.CustomersService.EnableCustomer:input_type -> customerspb.EnableCustomerRequest
+	5,  // 6: customerspb.CustomersService.DisableCustomer:input_type -> customerspb.DisableCustomerRequest
+	7,  // 7: customerspb.CustomersService.ChangeSmsNumber:input_type -> customerspb.ChangeSmsNumberRequest
+	9,  // 8: customerspb.CustomersService.AuthorizeCustomer:input_type -> customerspb.AuthorizeCustomerRequest
+	13, // 9: customerspb.CustomersService.GetCustomer:input_type -> customerspb.GetCustomerRequest
+	16, // 10: customerspb.CustomersService.GetCustomerHistory:input_type -> customerspb.GetCustomerHistoryRequest
+	11, // 11: customerspb.CustomersService.ForgetCustomer:input_type -> customerspb.ForgetCustomerRequest
+	2,  // 12: customerspb.CustomersService.RegisterCustomer:output_type -> customerspb.RegisterCustomerResponse
+	4,  // 13: customerspb.CustomersService.EnableCustomer:output_type -> customerspb.EnableCustomerResponse
+	6,  // 14: customerspb.CustomersService.DisableCustomer:output_type -> customerspb.DisableCustomerResponse
+	8,  // 15: customerspb.CustomersService.ChangeSmsNumber:output_type -> customerspb.ChangeSmsNumberResponse
+	10, // 16: customerspb.CustomersService.AuthorizeCustomer:output_type -> customerspb.AuthorizeCustomerResponse
+	14, // 17: customerspb.CustomersService.GetCustomer:output_type -> customerspb.GetCustomerResponse
+	17, // 18: customerspb.CustomersService.GetCustomerHistory:output_type -> customerspb.GetCustomerHistoryResponse
+	12, // 19: customerspb.CustomersService.ForgetCustomer:output_type -> customerspb.ForgetCustomerResponse
+	12, // [12:20] is the sub-list for method output_type
+	4,  // [4:12] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_customerspb_api_proto_init() }
@@ -705,7 +991,7 @@ func file_customerspb_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_customerspb_api_proto_rawDesc), len(file_customerspb_api_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
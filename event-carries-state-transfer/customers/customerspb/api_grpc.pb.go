@@ -19,12 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CustomersService_RegisterCustomer_FullMethodName  = "/customerspb.CustomersService/RegisterCustomer"
-	CustomersService_EnableCustomer_FullMethodName    = "/customerspb.CustomersService/EnableCustomer"
-	CustomersService_DisableCustomer_FullMethodName   = "/customerspb.CustomersService/DisableCustomer"
-	CustomersService_ChangeSmsNumber_FullMethodName   = "/customerspb.CustomersService/ChangeSmsNumber"
-	CustomersService_AuthorizeCustomer_FullMethodName = "/customerspb.CustomersService/AuthorizeCustomer"
-	CustomersService_GetCustomer_FullMethodName       = "/customerspb.CustomersService/GetCustomer"
+	CustomersService_RegisterCustomer_FullMethodName   = "/customerspb.CustomersService/RegisterCustomer"
+	CustomersService_EnableCustomer_FullMethodName     = "/customerspb.CustomersService/EnableCustomer"
+	CustomersService_DisableCustomer_FullMethodName    = "/customerspb.CustomersService/DisableCustomer"
+	CustomersService_ChangeSmsNumber_FullMethodName    = "/customerspb.CustomersService/ChangeSmsNumber"
+	CustomersService_AuthorizeCustomer_FullMethodName  = "/customerspb.CustomersService/AuthorizeCustomer"
+	CustomersService_GetCustomer_FullMethodName        = "/customerspb.CustomersService/GetCustomer"
+	CustomersService_GetCustomerHistory_FullMethodName = "/customerspb.CustomersService/GetCustomerHistory"
+	CustomersService_ForgetCustomer_FullMethodName     = "/customerspb.CustomersService/ForgetCustomer"
 )
 
 // CustomersServiceClient is the client API for CustomersService service.
@@ -37,6 +39,8 @@ type CustomersServiceClient interface {
 	ChangeSmsNumber(ctx context.Context, in *ChangeSmsNumberRequest, opts ...grpc.CallOption) (*ChangeSmsNumberResponse, error)
 	AuthorizeCustomer(ctx context.Context, in *AuthorizeCustomerRequest, opts ...grpc.CallOption) (*AuthorizeCustomerResponse, error)
 	GetCustomer(ctx context.Context, in *GetCustomerRequest, opts ...grpc.CallOption) (*GetCustomerResponse, error)
+	GetCustomerHistory(ctx context.Context, in *GetCustomerHistoryRequest, opts ...grpc.CallOption) (*GetCustomerHistoryResponse, error)
+	ForgetCustomer(ctx context.Context, in *ForgetCustomerRequest, opts ...grpc.CallOption) (*ForgetCustomerResponse, error)
 }
 
 type customersServiceClient struct {
@@ -107,6 +111,26 @@ func (c *customersServiceClient) GetCustomer(ctx context.Context, in *GetCustome
 	return out, nil
 }
 
+func (c *customersServiceClient) GetCustomerHistory(ctx context.Context, in *GetCustomerHistoryRequest, opts ...grpc.CallOption) (*GetCustomerHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCustomerHistoryResponse)
+	err := c.cc.Invoke(ctx, CustomersService_GetCustomerHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customersServiceClient) ForgetCustomer(ctx context.Context, in *ForgetCustomerRequest, opts ...grpc.CallOption) (*ForgetCustomerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForgetCustomerResponse)
+	err := c.cc.Invoke(ctx, CustomersService_ForgetCustomer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CustomersServiceServer is the server API for CustomersService service.
 // All implementations must embed UnimplementedCustomersServiceServer
 // for forward compatibility.
@@ -117,6 +141,8 @@ type CustomersServiceServer interface {
 	ChangeSmsNumber(context.Context, *ChangeSmsNumberRequest) (*ChangeSmsNumberResponse, error)
 	AuthorizeCustomer(context.Context, *AuthorizeCustomerRequest) (*AuthorizeCustomerResponse, error)
 	GetCustomer(context.Context, *GetCustomerRequest) (*GetCustomerResponse, error)
+	GetCustomerHistory(context.Context, *GetCustomerHistoryRequest) (*GetCustomerHistoryResponse, error)
+	ForgetCustomer(context.Context, *ForgetCustomerRequest) (*ForgetCustomerResponse, error)
 	mustEmbedUnimplementedCustomersServiceServer()
 }
 
@@ -145,6 +171,12 @@ func (UnimplementedCustomersServiceServer) AuthorizeCustomer(context.Context, *A
 func (UnimplementedCustomersServiceServer) GetCustomer(context.Context, *GetCustomerRequest) (*GetCustomerResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetCustomer not implemented")
 }
+func (UnimplementedCustomersServiceServer) GetCustomerHistory(context.Context, *GetCustomerHistoryRequest) (*GetCustomerHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCustomerHistory not implemented")
+}
+func (UnimplementedCustomersServiceServer) ForgetCustomer(context.Context, *ForgetCustomerRequest) (*ForgetCustomerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ForgetCustomer not implemented")
+}
 func (UnimplementedCustomersServiceServer) mustEmbedUnimplementedCustomersServiceServer() {}
 func (UnimplementedCustomersServiceServer) testEmbeddedByValue()                          {}
 
@@ -274,6 +306,42 @@ func _CustomersService_GetCustomer_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CustomersService_GetCustomerHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCustomerHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomersServiceServer).GetCustomerHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomersService_GetCustomerHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomersServiceServer).GetCustomerHistory(ctx, req.(*GetCustomerHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CustomersService_ForgetCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForgetCustomerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomersServiceServer).ForgetCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CustomersService_ForgetCustomer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomersServiceServer).ForgetCustomer(ctx, req.(*ForgetCustomerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CustomersService_ServiceDesc is the grpc.ServiceDesc for CustomersService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -305,6 +373,14 @@ var CustomersService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCustomer",
 			Handler:    _CustomersService_GetCustomer_Handler,
 		},
+		{
+			MethodName: "GetCustomerHistory",
+			Handler:    _CustomersService_GetCustomerHistory_Handler,
+		},
+		{
+			MethodName: "ForgetCustomer",
+			Handler:    _CustomersService_ForgetCustomer_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "customerspb/api.proto",
@@ -12,6 +12,7 @@ const (
 	CustomerSmsChangedEvent = "customersapi.CustomerSmsChanged"
 	CustomerEnabledEvent    = "customersapi.CustomerEnabled"
 	CustomerDisabledEvent   = "customersapi.CustomerDisabled"
+	CustomerForgottenEvent  = "customersapi.CustomerForgotten"
 
 	CommandChannel = "mallbots.customers.commands"
 
@@ -34,6 +35,9 @@ func Registrations(reg registry.Registry) error {
 	if err := serde.Register(&CustomerDisabled{}); err != nil {
 		return err
 	}
+	if err := serde.Register(&CustomerForgotten{}); err != nil {
+		return err
+	}
 
 	// commands
 	if err := serde.Register(&AuthorizeCustomer{}); err != nil {
@@ -46,5 +50,12 @@ func (*CustomerRegistered) Key() string { return CustomerRegisteredEvent }
 func (*CustomerSmsChanged) Key() string { return CustomerSmsChangedEvent }
 func (*CustomerEnabled) Key() string    { return CustomerEnabledEvent }
 func (*CustomerDisabled) Key() string   { return CustomerDisabledEvent }
+func (*CustomerForgotten) Key() string  { return CustomerForgottenEvent }
+
+// the names and SMS numbers are encrypted with the key of the customer
+func (m *CustomerRegistered) PersonalDataSubject() string   { return m.GetId() }
+func (m *CustomerRegistered) PersonalDataFields() []*string { return []*string{&m.Name, &m.SmsNumber} }
+func (m *CustomerSmsChanged) PersonalDataSubject() string   { return m.GetId() }
+func (m *CustomerSmsChanged) PersonalDataFields() []*string { return []*string{&m.SmsNumber} }
 
 func (*AuthorizeCustomer) Key() string { return AuthorizeCustomerCommand }
@@ -221,6 +221,50 @@ func (x *CustomerDisabled) GetId() string {
 	return ""
 }
 
+type CustomerForgotten struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerForgotten) Reset() {
+	*x = CustomerForgotten{}
+	mi := &file_customerspb_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerForgotten) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerForgotten) ProtoMessage() {}
+
+func (x *CustomerForgotten) ProtoReflect() protoreflect.Message {
+	mi := &file_customerspb_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerForgotten.ProtoReflect.Descriptor instead.
+func (*CustomerForgotten) Descriptor() ([]byte, []int) {
+	return file_customerspb_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CustomerForgotten) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 type AuthorizeCustomer struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -230,7 +274,7 @@ type AuthorizeCustomer struct {
 
 func (x *AuthorizeCustomer) Reset() {
 	*x = AuthorizeCustomer{}
-	mi := &file_customerspb_events_proto_msgTypes[4]
+	mi := &file_customerspb_events_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -242,7 +286,7 @@ func (x *AuthorizeCustomer) String() string {
 func (*AuthorizeCustomer) ProtoMessage() {}
 
 func (x *AuthorizeCustomer) ProtoReflect() protoreflect.Message {
-	mi := &file_customerspb_events_proto_msgTypes[4]
+	mi := &file_customerspb_events_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -255,7 +299,7 @@ func (x *AuthorizeCustomer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthorizeCustomer.ProtoReflect.Descriptor instead.
 func (*AuthorizeCustomer) Descriptor() ([]byte, []int) {
-	return file_customerspb_events_proto_rawDescGZIP(), []int{4}
+	return file_customerspb_events_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *AuthorizeCustomer) GetId() string {
@@ -283,6 +327,8 @@ const file_customerspb_events_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\"\n" +
 	"\x10CustomerDisabled\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"#\n" +
+	"\x11CustomerForgotten\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"#\n" +
 	"\x11AuthorizeCustomer\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02idB\x9b\x01\n" +
 	"\x0fcom.customerspbB\vEventsProtoP\x01Z/eda-in-golang/customers/customerspb/customerspb\xa2\x02\x03CXX\xaa\x02\vCustomerspb\xca\x02\vCustomerspb\xe2\x02\x17Customerspb\\GPBMetadata\xea\x02\vCustomerspbb\x06proto3"
@@ -299,13 +345,14 @@ func file_customerspb_events_proto_rawDescGZIP() []byte {
 	return file_customerspb_events_proto_rawDescData
 }
 
-var file_customerspb_events_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_customerspb_events_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_customerspb_events_proto_goTypes = []any{
 	(*CustomerRegistered)(nil), // 0: customerspb.CustomerRegistered
 	(*CustomerSmsChanged)(nil), // 1: customerspb.CustomerSmsChanged
 	(*CustomerEnabled)(nil),    // 2: customerspb.CustomerEnabled
 	(*CustomerDisabled)(nil),   // 3: customerspb.CustomerDisabled
-	(*AuthorizeCustomer)(nil),  // 4: customerspb.AuthorizeCustomer
+	(*CustomerForgotten)(nil),  // 4: customerspb.CustomerForgotten
+	(*AuthorizeCustomer)(nil),  // 5: customerspb.AuthorizeCustomer
 }
 var file_customerspb_events_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -326,7 +373,7 @@ func file_customerspb_events_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_customerspb_events_proto_rawDesc), len(file_customerspb_events_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
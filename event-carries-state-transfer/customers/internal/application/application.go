@@ -3,8 +3,11 @@ package application
 import (
 	"context"
 
+	"github.com/stackus/errors"
+
 	"eda-in-golang/customers/internal/domain"
 	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/es"
 )
 
 type (
@@ -30,25 +33,44 @@ type (
 		ID string
 	}
 
+	GetCustomerHistory struct {
+		ID string
+	}
+
+	ForgetCustomer struct {
+		ID string
+	}
+
 	App interface {
 		RegisterCustomer(ctx context.Context, register RegisterCustomer) error
 		AuthorizeCustomer(ctx context.Context, authorize AuthorizeCustomer) error
 		GetCustomer(ctx context.Context, get GetCustomer) (*domain.Customer, error)
 		EnableCustomer(ctx context.Context, enable EnableCustomer) error
 		DisableCustomer(ctx context.Context, disable DisableCustomer) error
+		GetCustomerHistory(ctx context.Context, get GetCustomerHistory) ([]es.HistoryEvent, error)
+		ForgetCustomer(ctx context.Context, forget ForgetCustomer) error
 	}
 
 	Application struct {
 		customers       domain.CustomerRepository
+		keys            domain.CustomerKeyRepository
+		history         es.HistoryReader
 		domainPublisher ddd.EventPublisher[ddd.AggregateEvent]
 	}
 )
 
 var _ App = (*Application)(nil)
 
-func New(customers domain.CustomerRepository, domainPublisher ddd.EventPublisher[ddd.AggregateEvent]) *Application {
+func New(
+	customers domain.CustomerRepository,
+	keys domain.CustomerKeyRepository,
+	history es.HistoryReader,
+	domainPublisher ddd.EventPublisher[ddd.AggregateEvent],
+) *Application {
 	return &Application{
 		customers:       customers,
+		keys:            keys,
+		history:         history,
 		domainPublisher: domainPublisher,
 	}
 }
@@ -63,6 +85,11 @@ func (a Application) RegisterCustomer(ctx context.Context, register RegisterCust
 		return err
 	}
 
+	// the key is needed to serialize the personal data in the events
+	if err = a.keys.Create(ctx, customer.ID()); err != nil {
+		return err
+	}
+
 	// publish domain events
 	if err = a.domainPublisher.Publish(ctx, customer.Events()...); err != nil {
 		return err
@@ -133,6 +160,51 @@ func (a Application) DisableCustomer(ctx context.Context, disable DisableCustome
 	return nil
 }
 
+// ForgetCustomer erases the personal data of the customer. Forgetting the key
+// leaves the data in the published events unreadable, and consumers are told
+// to purge what they have cached.
+func (a Application) ForgetCustomer(ctx context.Context, forget ForgetCustomer) error {
+	customer, err := a.customers.Find(ctx, forget.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = customer.Forget(); err != nil {
+		return err
+	}
+
+	if err = a.customers.Update(ctx, customer); err != nil {
+		return err
+	}
+
+	if err = a.keys.Forget(ctx, customer.ID()); err != nil {
+		return err
+	}
+
+	// publish domain events
+	if err = a.domainPublisher.Publish(ctx, customer.Events()...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (a Application) GetCustomer(ctx context.Context, get GetCustomer) (*domain.Customer, error) {
 	return a.customers.Find(ctx, get.ID)
 }
+
+// GetCustomerHistory reads the customer integration events; customers are not
+// event sourced so these are the only record of the changes made to them. Only
+// the events published within the window of the history reader are returned.
+func (a Application) GetCustomerHistory(ctx context.Context, get GetCustomerHistory) ([]es.HistoryEvent, error) {
+	events, err := a.history.History(ctx, domain.CustomerAggregate, get.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get customer history")
+	}
+
+	if len(events) == 0 {
+		return nil, errors.ErrNotFound.Msgf("the customer `%s` has no history", get.ID)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/customers/internal/domain"
+	"eda-in-golang/internal/ddd"
+)
+
+// calls records the order the fakes were called in
+type calls []string
+
+type testCustomerRepository struct {
+	customers map[string]*domain.Customer
+	calls     *calls
+}
+
+func (r testCustomerRepository) Save(_ context.Context, customer *domain.Customer) error {
+	*r.calls = append(*r.calls, "customers.Save")
+	r.customers[customer.ID()] = customer
+	return nil
+}
+
+func (r testCustomerRepository) Find(_ context.Context, customerID string) (*domain.Customer, error) {
+	customer, exists := r.customers[customerID]
+	if !exists {
+		return nil, fmt.Errorf("customer %s not found", customerID)
+	}
+	return customer, nil
+}
+
+func (r testCustomerRepository) Update(_ context.Context, customer *domain.Customer) error {
+	*r.calls = append(*r.calls, "customers.Update")
+	r.customers[customer.ID()] = customer
+	return nil
+}
+
+type testCustomerKeyRepository struct {
+	calls *calls
+	err   error
+}
+
+func (r testCustomerKeyRepository) Create(_ context.Context, customerID string) error {
+	*r.calls = append(*r.calls, "keys.Create "+customerID)
+	return r.err
+}
+
+func (r testCustomerKeyRepository) Forget(_ context.Context, customerID string) error {
+	*r.calls = append(*r.calls, "keys.Forget "+customerID)
+	return r.err
+}
+
+type testPublisher struct {
+	calls *calls
+}
+
+func (p testPublisher) Publish(_ context.Context, events ...ddd.AggregateEvent) error {
+	for _, event := range events {
+		*p.calls = append(*p.calls, "publish "+event.EventName())
+	}
+	return nil
+}
+
+func newTestApplication(keysErr error) (*Application, testCustomerRepository, *calls) {
+	c := &calls{}
+	customers := testCustomerRepository{customers: make(map[string]*domain.Customer), calls: c}
+	app := New(customers, testCustomerKeyRepository{calls: c, err: keysErr}, nil, testPublisher{calls: c})
+	return app, customers, c
+}
+
+func TestApplication_RegisterCustomer(t *testing.T) {
+	t.Run("CreatesTheKeyBeforePublishing", func(t *testing.T) {
+		app, _, c := newTestApplication(nil)
+
+		err := app.RegisterCustomer(context.Background(), RegisterCustomer{ID: "customer-id", Name: "Jane", SmsNumber: "555-0100"})
+		require.NoError(t, err)
+
+		assert.Equal(t, calls{
+			"customers.Save",
+			"keys.Create customer-id",
+			"publish " + domain.CustomerRegisteredEvent,
+		}, *c)
+	})
+	t.Run("DoesNotPublishWithoutAKey", func(t *testing.T) {
+		app, _, c := newTestApplication(fmt.Errorf("key store down"))
+
+		err := app.RegisterCustomer(context.Background(), RegisterCustomer{ID: "customer-id", Name: "Jane", SmsNumber: "555-0100"})
+		assert.Error(t, err)
+		assert.NotContains(t, *c, "publish "+domain.CustomerRegisteredEvent)
+	})
+}
+
+func TestApplication_ForgetCustomer(t *testing.T) {
+	t.Run("ForgetsTheCustomerAndItsKey", func(t *testing.T) {
+		app, customers, c := newTestApplication(nil)
+		customer, err := domain.RegisterCustomer("customer-id", "Jane", "555-0100")
+		require.NoError(t, err)
+		customer.ClearEvents()
+		customers.customers[customer.ID()] = customer
+
+		require.NoError(t, app.ForgetCustomer(context.Background(), ForgetCustomer{ID: "customer-id"}))
+
+		assert.Equal(t, calls{
+			"customers.Update",
+			"keys.Forget customer-id",
+			"publish " + domain.CustomerForgottenEvent,
+		}, *c)
+		assert.Empty(t, customers.customers["customer-id"].Name)
+		assert.Empty(t, customers.customers["customer-id"].SmsNumber)
+	})
+	t.Run("UnknownCustomer", func(t *testing.T) {
+		app, _, c := newTestApplication(nil)
+
+		assert.Error(t, app.ForgetCustomer(context.Background(), ForgetCustomer{ID: "customer-id"}))
+		assert.Empty(t, *c)
+	})
+}
@@ -88,3 +88,17 @@ func (c *Customer) Disable() error {
 
 	return nil
 }
+
+// Forget removes the personal data of the customer; the copies held in the
+// published events are made unreadable by forgetting the customer key
+func (c *Customer) Forget() error {
+	c.Name = ""
+	c.SmsNumber = ""
+	c.Enabled = false
+
+	c.AddEvent(CustomerForgottenEvent, &CustomerForgotten{
+		Customer: c,
+	})
+
+	return nil
+}
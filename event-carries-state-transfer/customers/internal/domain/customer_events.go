@@ -6,6 +6,7 @@ const (
 	CustomerAuthorizedEvent = "customers.CustomerAuthorized"
 	CustomerEnabledEvent    = "customers.CustomerEnabled"
 	CustomerDisabledEvent   = "customers.CustomerDisabled"
+	CustomerForgottenEvent  = "customers.CustomerForgotten"
 )
 
 type CustomerRegistered struct {
@@ -47,3 +48,11 @@ type CustomerDisabled struct {
 func (CustomerDisabled) Key() string {
 	return CustomerDisabledEvent
 }
+
+type CustomerForgotten struct {
+	Customer *Customer
+}
+
+func (CustomerForgotten) Key() string {
+	return CustomerForgottenEvent
+}
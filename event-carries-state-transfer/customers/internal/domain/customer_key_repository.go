@@ -0,0 +1,10 @@
+package domain
+
+import (
+	"context"
+)
+
+type CustomerKeyRepository interface {
+	Create(ctx context.Context, customerID string) error
+	Forget(ctx context.Context, customerID string) error
+}
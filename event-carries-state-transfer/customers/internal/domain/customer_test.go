@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomer_Forget(t *testing.T) {
+	customer, err := RegisterCustomer("customer-id", "Jane", "555-0100")
+	require.NoError(t, err)
+	customer.ClearEvents()
+
+	require.NoError(t, customer.Forget())
+
+	assert.Empty(t, customer.Name)
+	assert.Empty(t, customer.SmsNumber)
+	assert.False(t, customer.Enabled)
+
+	events := customer.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, CustomerForgottenEvent, events[0].EventName())
+	assert.Equal(t, customer, events[0].Payload().(*CustomerForgotten).Customer)
+}
@@ -5,10 +5,13 @@ import (
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"eda-in-golang/customers/customerspb"
 	"eda-in-golang/customers/internal/application"
 	"eda-in-golang/customers/internal/domain"
+	"eda-in-golang/internal/es"
 )
 
 type server struct {
@@ -69,6 +72,31 @@ func (s server) DisableCustomer(ctx context.Context, request *customerspb.Disabl
 	return &customerspb.DisableCustomerResponse{}, err
 }
 
+func (s server) ForgetCustomer(ctx context.Context, request *customerspb.ForgetCustomerRequest,
+) (*customerspb.ForgetCustomerResponse, error) {
+	err := s.app.ForgetCustomer(ctx, application.ForgetCustomer{ID: request.GetId()})
+	return &customerspb.ForgetCustomerResponse{}, err
+}
+
+func (s server) GetCustomerHistory(ctx context.Context, request *customerspb.GetCustomerHistoryRequest,
+) (*customerspb.GetCustomerHistoryResponse, error) {
+	events, err := s.app.GetCustomerHistory(ctx, application.GetCustomerHistory{
+		ID: request.GetId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.historyFromDomain(events)
+	if err != nil {
+		return nil, err
+	}
+
+	return &customerspb.GetCustomerHistoryResponse{
+		Events: history,
+	}, nil
+}
+
 func (s server) customerFromDomain(customer *domain.Customer) *customerspb.Customer {
 	return &customerspb.Customer{
 		Id:        customer.ID(),
@@ -77,3 +105,23 @@ func (s server) customerFromDomain(customer *domain.Customer) *customerspb.Custo
 		Enabled:   customer.Enabled,
 	}
 }
+
+func (s server) historyFromDomain(events []es.HistoryEvent) ([]*customerspb.HistoryEvent, error) {
+	history := make([]*customerspb.HistoryEvent, len(events))
+	for i, event := range events {
+		metadata, err := structpb.NewStruct(event.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		history[i] = &customerspb.HistoryEvent{
+			Id:         event.ID,
+			Name:       event.Name,
+			Version:    int32(event.Version),
+			OccurredAt: timestamppb.New(event.OccurredAt),
+			Payload:    string(event.Payload),
+			Metadata:   metadata,
+		}
+	}
+
+	return history, nil
+}
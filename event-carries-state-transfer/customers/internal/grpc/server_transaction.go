@@ -83,6 +83,28 @@ func (s serverTx) DisableCustomer(ctx context.Context, request *customerspb.Disa
 	return next.DisableCustomer(ctx, request)
 }
 
+func (s serverTx) ForgetCustomer(ctx context.Context, request *customerspb.ForgetCustomerRequest) (resp *customerspb.ForgetCustomerResponse, err error) {
+	ctx = s.c.Scoped(ctx)
+	defer func(tx *sql.Tx) {
+		err = s.closeTx(tx, err)
+	}(di.Get(ctx, "tx").(*sql.Tx))
+
+	next := server{app: di.Get(ctx, "app").(application.App)}
+
+	return next.ForgetCustomer(ctx, request)
+}
+
+func (s serverTx) GetCustomerHistory(ctx context.Context, request *customerspb.GetCustomerHistoryRequest) (resp *customerspb.GetCustomerHistoryResponse, err error) {
+	ctx = s.c.Scoped(ctx)
+	defer func(tx *sql.Tx) {
+		err = s.closeTx(tx, err)
+	}(di.Get(ctx, "tx").(*sql.Tx))
+
+	next := server{app: di.Get(ctx, "app").(application.App)}
+
+	return next.GetCustomerHistory(ctx, request)
+}
+
 func (s serverTx) closeTx(tx *sql.Tx, err error) error {
 	if p := recover(); p != nil {
 		_ = tx.Rollback()
@@ -27,6 +27,7 @@ func RegisterDomainEventHandlers(subscriber ddd.EventSubscriber[ddd.AggregateEve
 		domain.CustomerSmsChangedEvent,
 		domain.CustomerEnabledEvent,
 		domain.CustomerDisabledEvent,
+		domain.CustomerForgottenEvent,
 	)
 }
 
@@ -40,6 +41,8 @@ func (h domainHandlers[T]) HandleEvent(ctx context.Context, event T) error {
 		return h.onCustomerEnabled(ctx, event)
 	case domain.CustomerDisabledEvent:
 		return h.onCustomerDisabled(ctx, event)
+	case domain.CustomerForgottenEvent:
+		return h.onCustomerForgotten(ctx, event)
 	}
 	return nil
 }
@@ -80,3 +83,11 @@ func (h domainHandlers[T]) onCustomerDisabled(ctx context.Context, event ddd.Agg
 		}),
 	)
 }
+
+func (h domainHandlers[T]) onCustomerForgotten(ctx context.Context, event ddd.AggregateEvent) error {
+	return h.publisher.Publish(ctx, customerspb.CustomerAggregateChannel,
+		ddd.NewEvent(customerspb.CustomerForgottenEvent, &customerspb.CustomerForgotten{
+			Id: event.AggregateID(),
+		}),
+	)
+}
@@ -7,6 +7,8 @@ import (
 
 	"eda-in-golang/customers/internal/application"
 	"eda-in-golang/customers/internal/domain"
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/es"
 )
 
 type Application struct {
@@ -24,33 +26,57 @@ func LogApplicationAccess(application application.App, logger zerolog.Logger) Ap
 }
 
 func (a Application) RegisterCustomer(ctx context.Context, register application.RegisterCustomer) (err error) {
-	a.logger.Info().Msg("--> Customers.RegisterCustomer")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Customers.RegisterCustomer") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Customers.RegisterCustomer")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Customers.RegisterCustomer")
+	}()
 	return a.App.RegisterCustomer(ctx, register)
 }
 
 func (a Application) AuthorizeCustomer(ctx context.Context, authorize application.AuthorizeCustomer) (err error) {
-	a.logger.Info().Msg("--> Customers.AuthorizeCustomer")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Customers.AuthorizeCustomer") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Customers.AuthorizeCustomer")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Customers.AuthorizeCustomer")
+	}()
 	return a.App.AuthorizeCustomer(ctx, authorize)
 }
 
 func (a Application) GetCustomer(ctx context.Context, get application.GetCustomer) (customer *domain.Customer,
 	err error,
 ) {
-	a.logger.Info().Msg("--> Customers.GetCustomer")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Customers.GetCustomer") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Customers.GetCustomer")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Customers.GetCustomer") }()
 	return a.App.GetCustomer(ctx, get)
 }
 
 func (a Application) EnableCustomer(ctx context.Context, enable application.EnableCustomer) (err error) {
-	a.logger.Info().Msg("--> Customers.EnableCustomer")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Customers.EnableCustomer") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Customers.EnableCustomer")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Customers.EnableCustomer")
+	}()
 	return a.App.EnableCustomer(ctx, enable)
 }
 
 func (a Application) DisableCustomer(ctx context.Context, disable application.DisableCustomer) (err error) {
-	a.logger.Info().Msg("--> Customers.DisableCustomer")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Customers.DisableCustomer") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Customers.DisableCustomer")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Customers.DisableCustomer")
+	}()
 	return a.App.DisableCustomer(ctx, disable)
 }
+
+func (a Application) ForgetCustomer(ctx context.Context, forget application.ForgetCustomer) (err error) {
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Customers.ForgetCustomer")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Customers.ForgetCustomer")
+	}()
+	return a.App.ForgetCustomer(ctx, forget)
+}
+
+func (a Application) GetCustomerHistory(ctx context.Context, get application.GetCustomerHistory) (events []es.HistoryEvent, err error) {
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Customers.GetCustomerHistory")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Customers.GetCustomerHistory")
+	}()
+	return a.App.GetCustomerHistory(ctx, get)
+}
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+
+	"eda-in-golang/customers/internal/domain"
+	"eda-in-golang/internal/postgres"
+	"eda-in-golang/internal/registry"
+)
+
+// CustomerKeyRepository keeps the customer keys in the store and in the cache
+// used by the registry; the registry cannot see a key created inside a
+// transaction that has not been committed yet
+type CustomerKeyRepository struct {
+	store postgres.KeyStore
+	cache *registry.KeyCache
+}
+
+var _ domain.CustomerKeyRepository = (*CustomerKeyRepository)(nil)
+
+func NewCustomerKeyRepository(tableName string, db postgres.DB, cache *registry.KeyCache) CustomerKeyRepository {
+	return CustomerKeyRepository{
+		store: postgres.NewKeyStore(tableName, db),
+		cache: cache,
+	}
+}
+
+func (r CustomerKeyRepository) Create(ctx context.Context, customerID string) error {
+	key, err := r.store.Create(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	r.cache.Add(customerID, key)
+
+	return nil
+}
+
+func (r CustomerKeyRepository) Forget(ctx context.Context, customerID string) error {
+	if err := r.store.Forget(ctx, customerID); err != nil {
+		return err
+	}
+
+	r.cache.Evict(customerID)
+
+	return nil
+}
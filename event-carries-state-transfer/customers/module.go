@@ -18,8 +18,10 @@ import (
 	"eda-in-golang/internal/am"
 	"eda-in-golang/internal/ddd"
 	"eda-in-golang/internal/di"
+	"eda-in-golang/internal/es"
 	"eda-in-golang/internal/jetstream"
 	"eda-in-golang/internal/monolith"
+	"eda-in-golang/internal/prom"
 	"eda-in-golang/internal/registry"
 	"eda-in-golang/internal/tm"
 )
@@ -33,8 +35,13 @@ func (Module) Name() string {
 func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 	container := di.New()
 	// setup Driven adapters
+	container.AddSingleton("keys", func(c di.Container) (any, error) {
+		// customers registered before their personal data was encrypted get a
+		// key the first time one is needed
+		return registry.NewKeyCache(pg.NewKeyStore("customers.customer_keys", c.Get("db").(*sql.DB)).FindOrCreate), nil
+	})
 	container.AddSingleton("registry", func(c di.Container) (any, error) {
-		reg := registry.New()
+		reg := registry.New(registry.WithPersonalData(c.Get("keys").(*registry.KeyCache)))
 		if err := customerspb.Registrations(reg); err != nil {
 			return nil, err
 		}
@@ -43,8 +50,27 @@ func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error)
 	container.AddSingleton("logger", func(c di.Container) (any, error) {
 		return mono.Logger(), nil
 	})
+	container.AddSingleton("rawStream", func(c di.Container) (any, error) {
+		return mono.Stream(mono.Logger(), jetstream.ConfirmPublish()), nil
+	})
 	container.AddSingleton("stream", func(c di.Container) (any, error) {
-		return jetstream.NewStream(mono.Config().Nats.Stream, mono.JS(), mono.Logger()), nil
+		return am.RawMessageStreamWithMiddleware(
+			c.Get("rawStream").(am.RawMessageStream),
+			prom.StreamMetrics(),
+		), nil
+	})
+	container.AddSingleton("history", func(c di.Container) (any, error) {
+		// the history is read back from JetStream; the in-process stream does
+		// not keep the messages it has delivered
+		stream, ok := c.Get("rawStream").(*jetstream.Stream)
+		if !ok {
+			return es.NoHistory{}, nil
+		}
+		return jetstream.NewHistoryReader(
+			stream,
+			customerspb.CustomerAggregateChannel,
+			c.Get("registry").(registry.Registry),
+		), nil
 	})
 	container.AddSingleton("domainDispatcher", func(c di.Container) (any, error) {
 		return ddd.NewEventDispatcher[ddd.AggregateEvent](), nil
@@ -57,6 +83,25 @@ func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error)
 			c.Get("stream").(am.RawMessageStream),
 			pg.NewOutboxStore("customers.outbox", c.Get("db").(*sql.DB)),
 			c.Get("logger").(zerolog.Logger),
+			tm.OutboxBatchSize(mono.Config().Outbox.BatchSize),
+			tm.OutboxPollingInterval(mono.Config().Outbox.PollingInterval),
+			tm.OutboxWorkers(mono.Config().Outbox.Workers),
+			tm.OutboxLease(mono.Config().Outbox.Lease),
+			tm.OutboxOrderedBySubject(mono.Config().Outbox.OrderedBySubject),
+			tm.OutboxNotifications(pg.NewOutboxListener("customers.outbox", c.Get("db").(*sql.DB), c.Get("logger").(zerolog.Logger))),
+			tm.OutboxBacklogMetrics(pg.NewOutboxStore("customers.outbox", c.Get("db").(*sql.DB)), prom.OutboxMetrics("customers.outbox")),
+		), nil
+	})
+	container.AddSingleton("retention", func(c di.Container) (any, error) {
+		db := c.Get("db").(*sql.DB)
+		return tm.NewRetention(
+			pg.NewOutboxStore("customers.outbox", db),
+			pg.NewInboxStore("customers.inbox", db),
+			c.Get("logger").(zerolog.Logger),
+			tm.RetentionInterval(mono.Config().Retention.Interval),
+			tm.RetentionBatchSize(mono.Config().Retention.BatchSize),
+			tm.OutboxRetention(mono.Config().Retention.Outbox),
+			tm.InboxRetention(mono.Config().Retention.Inbox),
 		), nil
 	})
 	container.AddScoped("tx", func(c di.Container) (any, error) {
@@ -66,6 +111,13 @@ func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error)
 	container.AddScoped("customers", func(c di.Container) (any, error) {
 		return postgres.NewCustomerRepository("customers.customers", c.Get("tx").(*sql.Tx)), nil
 	})
+	container.AddScoped("customerKeys", func(c di.Container) (any, error) {
+		return postgres.NewCustomerKeyRepository(
+			"customers.customer_keys",
+			c.Get("tx").(*sql.Tx),
+			c.Get("keys").(*registry.KeyCache),
+		), nil
+	})
 	container.AddScoped("txStream", func(c di.Container) (any, error) {
 		tx := c.Get("tx").(*sql.Tx)
 		outboxStore := pg.NewOutboxStore("customers.outbox", tx)
@@ -84,7 +136,12 @@ func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error)
 	container.AddScoped("inboxMiddleware", func(c di.Container) (any, error) {
 		tx := c.Get("tx").(*sql.Tx)
 		inboxStore := pg.NewInboxStore("customers.inbox", tx)
-		return tm.NewInboxHandlerMiddleware(inboxStore), nil
+		return tm.NewInboxHandlerMiddleware(
+			inboxStore,
+			tm.InboxHandlerName("customers"),
+			tm.InboxFailures(pg.NewInboxStore("customers.inbox", c.Get("db").(*sql.DB))),
+			tm.InboxDuplicateMetrics(prom.InboxMetrics()),
+		), nil
 	})
 
 	// setup application
@@ -92,6 +149,8 @@ func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error)
 		return logging.LogApplicationAccess(
 			application.New(
 				c.Get("customers").(domain.CustomerRepository),
+				c.Get("customerKeys").(domain.CustomerKeyRepository),
+				c.Get("history").(es.HistoryReader),
 				c.Get("domainDispatcher").(*ddd.EventDispatcher[ddd.AggregateEvent]),
 			),
 			c.Get("logger").(zerolog.Logger),
@@ -119,6 +178,7 @@ func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error)
 		return err
 	}
 	startOutboxProcessor(ctx, container)
+	mono.Waiter().Add(container.Get("retention").(tm.Retention).Start)
 
 	return nil
 }
@@ -42,7 +42,7 @@ func RegisterIntegrationEventHandlers(subscriber am.EventSubscriber, handler ddd
 		storespb.ProductPriceIncreasedEvent,
 		storespb.ProductPriceDecreasedEvent,
 		storespb.ProductRemovedEvent,
-	}, am.GroupName("depot-products"))
+	}, am.GroupName("depot-products"), am.ShardBy{Key: ddd.AggregateIDKey, Workers: 4})
 }
 
 func (h integrationHandlers[T]) HandleEvent(ctx context.Context, event T) error {
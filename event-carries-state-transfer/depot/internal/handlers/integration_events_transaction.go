@@ -50,5 +50,5 @@ func RegisterIntegrationEventHandlersTx(container di.Container) error {
 		storespb.ProductAddedEvent,
 		storespb.ProductRebrandedEvent,
 		storespb.ProductRemovedEvent,
-	}, am.GroupName("depot-products"))
+	}, am.GroupName("depot-products"), am.ShardBy{Key: ddd.AggregateIDKey, Workers: 4})
 }
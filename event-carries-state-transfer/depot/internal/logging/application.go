@@ -9,6 +9,7 @@ import (
 	"eda-in-golang/depot/internal/application/commands"
 	"eda-in-golang/depot/internal/application/queries"
 	"eda-in-golang/depot/internal/domain"
+	"eda-in-golang/internal/am"
 )
 
 type Application struct {
@@ -26,33 +27,41 @@ func LogApplicationAccess(application application.App, logger zerolog.Logger) Ap
 }
 
 func (a Application) CreateShoppingList(ctx context.Context, cmd commands.CreateShoppingList) (err error) {
-	a.logger.Info().Msg("--> Depot.CreateShoppingList")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Depot.CreateShoppingList") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Depot.CreateShoppingList")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Depot.CreateShoppingList")
+	}()
 	return a.App.CreateShoppingList(ctx, cmd)
 }
 
 func (a Application) CancelShoppingList(ctx context.Context, cmd commands.CancelShoppingList) (err error) {
-	a.logger.Info().Msg("--> Depot.CancelShoppingList")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Depot.CancelShoppingList") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Depot.CancelShoppingList")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Depot.CancelShoppingList")
+	}()
 	return a.App.CancelShoppingList(ctx, cmd)
 }
 
 func (a Application) AssignShoppingList(ctx context.Context, cmd commands.AssignShoppingList) (err error) {
-	a.logger.Info().Msg("--> Depot.AssignShoppingList")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Depot.AssignShoppingList") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Depot.AssignShoppingList")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Depot.AssignShoppingList")
+	}()
 	return a.App.AssignShoppingList(ctx, cmd)
 }
 
 func (a Application) CompleteShoppingList(ctx context.Context, cmd commands.CompleteShoppingList) (err error) {
-	a.logger.Info().Msg("--> Depot.CompleteShoppingList")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Depot.CompleteShoppingList") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Depot.CompleteShoppingList")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Depot.CompleteShoppingList")
+	}()
 	return a.App.CompleteShoppingList(ctx, cmd)
 }
 
 func (a Application) GetShoppingList(ctx context.Context, query queries.GetShoppingList) (list *domain.ShoppingList,
 	err error,
 ) {
-	a.logger.Info().Msg("--> Depot.GetShoppingList")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Depot.GetShoppingList") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Depot.GetShoppingList")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Depot.GetShoppingList") }()
 	return a.App.GetShoppingList(ctx, query)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"eda-in-golang/internal/am"
 	"eda-in-golang/internal/ddd"
 )
 
@@ -25,7 +26,9 @@ func LogCommandHandlerAccess[T ddd.Command](handlers ddd.CommandHandler[T], labe
 }
 
 func (h CommandHandlers[T]) HandleCommand(ctx context.Context, command T) (reply ddd.Reply, err error) {
-	h.logger.Info().Msgf("--> Depot.%s.On(%s)", h.label, command.CommandName())
-	defer func() { h.logger.Info().Err(err).Msgf("<-- Depot.%s.On(%s)", h.label, command.CommandName()) }()
+	h.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msgf("--> Depot.%s.On(%s)", h.label, command.CommandName())
+	defer func() {
+		h.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msgf("<-- Depot.%s.On(%s)", h.label, command.CommandName())
+	}()
 	return h.CommandHandler.HandleCommand(ctx, command)
 }
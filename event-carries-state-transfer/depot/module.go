@@ -20,6 +20,7 @@ import (
 	"eda-in-golang/internal/di"
 	"eda-in-golang/internal/jetstream"
 	"eda-in-golang/internal/monolith"
+	"eda-in-golang/internal/prom"
 	"eda-in-golang/internal/registry"
 	"eda-in-golang/internal/tm"
 	"eda-in-golang/stores/storespb"
@@ -51,7 +52,10 @@ func (Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 		return mono.Logger(), nil
 	})
 	container.AddSingleton("stream", func(c di.Container) (any, error) {
-		return jetstream.NewStream(mono.Config().Nats.Stream, mono.JS(), mono.Logger()), nil
+		return am.RawMessageStreamWithMiddleware(
+			mono.Stream(mono.Logger(), jetstream.ConfirmPublish()),
+			prom.StreamMetrics(),
+		), nil
 	})
 	container.AddSingleton("domainDispatcher", func(c di.Container) (any, error) {
 		return ddd.NewEventDispatcher[ddd.AggregateEvent](), nil
@@ -67,6 +71,25 @@ func (Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 			c.Get("stream").(am.RawMessageStream),
 			pg.NewOutboxStore("depot.outbox", c.Get("db").(*sql.DB)),
 			c.Get("logger").(zerolog.Logger),
+			tm.OutboxBatchSize(mono.Config().Outbox.BatchSize),
+			tm.OutboxPollingInterval(mono.Config().Outbox.PollingInterval),
+			tm.OutboxWorkers(mono.Config().Outbox.Workers),
+			tm.OutboxLease(mono.Config().Outbox.Lease),
+			tm.OutboxOrderedBySubject(mono.Config().Outbox.OrderedBySubject),
+			tm.OutboxNotifications(pg.NewOutboxListener("depot.outbox", c.Get("db").(*sql.DB), c.Get("logger").(zerolog.Logger))),
+			tm.OutboxBacklogMetrics(pg.NewOutboxStore("depot.outbox", c.Get("db").(*sql.DB)), prom.OutboxMetrics("depot.outbox")),
+		), nil
+	})
+	container.AddSingleton("retention", func(c di.Container) (any, error) {
+		db := c.Get("db").(*sql.DB)
+		return tm.NewRetention(
+			pg.NewOutboxStore("depot.outbox", db),
+			pg.NewInboxStore("depot.inbox", db),
+			c.Get("logger").(zerolog.Logger),
+			tm.RetentionInterval(mono.Config().Retention.Interval),
+			tm.RetentionBatchSize(mono.Config().Retention.BatchSize),
+			tm.OutboxRetention(mono.Config().Retention.Outbox),
+			tm.InboxRetention(mono.Config().Retention.Inbox),
 		), nil
 	})
 	container.AddScoped("tx", func(c di.Container) (any, error) {
@@ -102,7 +125,12 @@ func (Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 	container.AddScoped("inboxMiddleware", func(c di.Container) (any, error) {
 		tx := c.Get("tx").(*sql.Tx)
 		inboxStore := pg.NewInboxStore("depot.inbox", tx)
-		return tm.NewInboxHandlerMiddleware(inboxStore), nil
+		return tm.NewInboxHandlerMiddleware(
+			inboxStore,
+			tm.InboxHandlerName("depot"),
+			tm.InboxFailures(pg.NewInboxStore("depot.inbox", c.Get("db").(*sql.DB))),
+			tm.InboxDuplicateMetrics(prom.InboxMetrics()),
+		), nil
 	})
 	container.AddScoped("shoppingLists", func(c di.Container) (any, error) {
 		return postgres.NewShoppingListRepository(
@@ -171,6 +199,7 @@ func (Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 		return err
 	}
 	startOutboxProcessor(ctx, container)
+	mono.Waiter().Add(container.Get("retention").(tm.Retention).Start)
 
 	return nil
 }
@@ -18,13 +18,26 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "mallbots" <<-EOSQL
   CREATE TRIGGER created_at_customers_trgr BEFORE UPDATE ON customers.customers FOR EACH ROW EXECUTE PROCEDURE created_at_trigger();
   CREATE TRIGGER updated_at_customers_trgr BEFORE UPDATE ON customers.customers FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
 
+  CREATE TABLE customers.customer_keys
+  (
+      subject      text NOT NULL,
+      key          bytea,
+      created_at   timestamptz NOT NULL DEFAULT NOW(),
+      forgotten_at timestamptz,
+      PRIMARY KEY (subject)
+  );
+
   CREATE TABLE customers.inbox
   (
-    id          text NOT NULL,
-    name        text NOT NULL,
-    subject     text NOT NULL,
-    data        bytea NOT NULL,
-    received_at timestamptz NOT NULL,
+    id           text NOT NULL,
+    name         text NOT NULL,
+    subject      text NOT NULL,
+    data         bytea NOT NULL,
+    received_at  timestamptz NOT NULL,
+    processed_at timestamptz,
+    outcome      text,
+    error        text,
+    handler      text,
     PRIMARY KEY (id)
   );
 
@@ -35,11 +48,20 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "mallbots" <<-EOSQL
     subject      text NOT NULL,
     data         bytea NOT NULL,
     published_at timestamptz,
+    attempts     int NOT NULL DEFAULT 0,
+    last_error   text,
+    locked_until timestamptz,
+    seq          bigserial,
+    created_at   timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
     PRIMARY KEY (id)
   );
 
-  CREATE INDEX customers_unpublished_idx ON customers.outbox (published_at) WHERE published_at IS NULL;
+  CREATE INDEX customers_unpublished_idx ON customers.outbox (seq) WHERE published_at IS NULL;
+  CREATE INDEX customers_published_idx ON customers.outbox (published_at) WHERE published_at IS NOT NULL;
+  CREATE INDEX customers_received_idx ON customers.inbox (received_at);
+  CREATE INDEX customers_outcome_idx ON customers.inbox (outcome, received_at);
 
   GRANT USAGE ON SCHEMA customers TO mallbots_user;
   GRANT INSERT, UPDATE, DELETE, SELECT ON ALL TABLES IN SCHEMA customers TO mallbots_user;
+  GRANT USAGE ON ALL SEQUENCES IN SCHEMA customers TO mallbots_user;
 EOSQL
@@ -50,11 +50,15 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "mallbots" <<-EOSQL
 
   CREATE TABLE depot.inbox
   (
-    id          text NOT NULL,
-    name        text NOT NULL,
-    subject     text NOT NULL,
-    data        bytea NOT NULL,
-    received_at timestamptz NOT NULL,
+    id           text NOT NULL,
+    name         text NOT NULL,
+    subject      text NOT NULL,
+    data         bytea NOT NULL,
+    received_at  timestamptz NOT NULL,
+    processed_at timestamptz,
+    outcome      text,
+    error        text,
+    handler      text,
     PRIMARY KEY (id)
   );
 
@@ -65,11 +69,20 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "mallbots" <<-EOSQL
     subject      text NOT NULL,
     data         bytea NOT NULL,
     published_at timestamptz,
+    attempts     int NOT NULL DEFAULT 0,
+    last_error   text,
+    locked_until timestamptz,
+    seq          bigserial,
+    created_at   timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
     PRIMARY KEY (id)
   );
 
-  CREATE INDEX depot_unpublished_idx ON depot.outbox (published_at) WHERE published_at IS NULL;
+  CREATE INDEX depot_unpublished_idx ON depot.outbox (seq) WHERE published_at IS NULL;
+  CREATE INDEX depot_published_idx ON depot.outbox (published_at) WHERE published_at IS NOT NULL;
+  CREATE INDEX depot_received_idx ON depot.inbox (received_at);
+  CREATE INDEX depot_outcome_idx ON depot.inbox (outcome, received_at);
 
   GRANT USAGE ON SCHEMA depot TO mallbots_user;
   GRANT INSERT, UPDATE, DELETE, SELECT ON ALL TABLES IN SCHEMA depot TO mallbots_user;
+  GRANT USAGE ON ALL SEQUENCES IN SCHEMA depot TO mallbots_user;
 EOSQL
@@ -23,16 +23,21 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "mallbots" <<-EOSQL
 
   CREATE TABLE ordering.events
   (
-      stream_id      text        NOT NULL,
-      stream_name    text        NOT NULL,
-      stream_version int         NOT NULL,
-      event_id       text        NOT NULL,
-      event_name     text        NOT NULL,
-      event_data     bytea       NOT NULL,
-      occurred_at    timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+      stream_id       text        NOT NULL,
+      stream_name     text        NOT NULL,
+      stream_version  int         NOT NULL,
+      event_id        text        NOT NULL,
+      event_name      text        NOT NULL,
+      event_version   int         NOT NULL DEFAULT 1,
+      event_data      bytea       NOT NULL,
+      metadata        jsonb       NOT NULL DEFAULT '{}',
+      occurred_at     timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+      global_position bigserial,
       PRIMARY KEY (stream_id, stream_name, stream_version)
   );
 
+  CREATE UNIQUE INDEX ordering_events_position_idx ON ordering.events (global_position);
+
   CREATE TABLE ordering.snapshots
   (
       stream_id        text        NOT NULL,
@@ -48,11 +53,15 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "mallbots" <<-EOSQL
 
   CREATE TABLE ordering.inbox
   (
-    id          text NOT NULL,
-    name        text NOT NULL,
-    subject     text NOT NULL,
-    data        bytea NOT NULL,
-    received_at timestamptz NOT NULL,
+    id           text NOT NULL,
+    name         text NOT NULL,
+    subject      text NOT NULL,
+    data         bytea NOT NULL,
+    received_at  timestamptz NOT NULL,
+    processed_at timestamptz,
+    outcome      text,
+    error        text,
+    handler      text,
     PRIMARY KEY (id)
   );
 
@@ -63,11 +72,20 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "mallbots" <<-EOSQL
     subject      text NOT NULL,
     data         bytea NOT NULL,
     published_at timestamptz,
+    attempts     int NOT NULL DEFAULT 0,
+    last_error   text,
+    locked_until timestamptz,
+    seq          bigserial,
+    created_at   timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
     PRIMARY KEY (id)
   );
 
-  CREATE INDEX ordering_unpublished_idx ON ordering.outbox (published_at) WHERE published_at IS NULL;
+  CREATE INDEX ordering_unpublished_idx ON ordering.outbox (seq) WHERE published_at IS NULL;
+  CREATE INDEX ordering_published_idx ON ordering.outbox (published_at) WHERE published_at IS NOT NULL;
+  CREATE INDEX ordering_received_idx ON ordering.inbox (received_at);
+  CREATE INDEX ordering_outcome_idx ON ordering.inbox (outcome, received_at);
 
   GRANT USAGE ON SCHEMA ordering TO mallbots_user;
   GRANT INSERT, UPDATE, DELETE, SELECT ON ALL TABLES IN SCHEMA ordering TO mallbots_user;
+  GRANT USAGE ON ALL SEQUENCES IN SCHEMA ordering TO mallbots_user;
 EOSQL
@@ -62,4 +62,8 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "mallbots" <<-EOSQL
 
   GRANT USAGE ON SCHEMA search TO mallbots_user;
   GRANT INSERT, UPDATE, DELETE, SELECT ON ALL TABLES IN SCHEMA search TO mallbots_user;
+
+  -- rebuilds of the orders read model replace the table with a new one
+  GRANT CREATE ON SCHEMA search TO mallbots_user;
+  ALTER TABLE search.orders OWNER TO mallbots_user;
 EOSQL
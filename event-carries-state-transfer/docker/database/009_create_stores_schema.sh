@@ -40,16 +40,21 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "mallbots" <<-EOSQL
 
   CREATE TABLE stores.events
   (
-      stream_id      text        NOT NULL,
-      stream_name    text        NOT NULL,
-      stream_version int         NOT NULL,
-      event_id       text        NOT NULL,
-      event_name     text        NOT NULL,
-      event_data     bytea       NOT NULL,
-      occurred_at    timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+      stream_id       text        NOT NULL,
+      stream_name     text        NOT NULL,
+      stream_version  int         NOT NULL,
+      event_id        text        NOT NULL,
+      event_name      text        NOT NULL,
+      event_version   int         NOT NULL DEFAULT 1,
+      event_data      bytea       NOT NULL,
+      metadata        jsonb       NOT NULL DEFAULT '{}',
+      occurred_at     timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+      global_position bigserial,
       PRIMARY KEY (stream_id, stream_name, stream_version)
   );
 
+  CREATE UNIQUE INDEX stores_events_position_idx ON stores.events (global_position);
+
   CREATE TABLE stores.snapshots
   (
       stream_id        text        NOT NULL,
@@ -63,13 +68,27 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "mallbots" <<-EOSQL
 
   CREATE TRIGGER updated_at_snapshots_trgr BEFORE UPDATE ON stores.snapshots FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
 
+  CREATE TABLE stores.checkpoints
+  (
+      name       text        NOT NULL,
+      position   bigint      NOT NULL,
+      updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+      PRIMARY KEY (name)
+  );
+
+  CREATE TRIGGER updated_at_checkpoints_trgr BEFORE UPDATE ON stores.checkpoints FOR EACH ROW EXECUTE PROCEDURE updated_at_trigger();
+
   CREATE TABLE stores.inbox
   (
-    id          text NOT NULL,
-    name        text NOT NULL,
-    subject     text NOT NULL,
-    data        bytea NOT NULL,
-    received_at timestamptz NOT NULL,
+    id           text NOT NULL,
+    name         text NOT NULL,
+    subject      text NOT NULL,
+    data         bytea NOT NULL,
+    received_at  timestamptz NOT NULL,
+    processed_at timestamptz,
+    outcome      text,
+    error        text,
+    handler      text,
     PRIMARY KEY (id)
   );
 
@@ -80,11 +99,20 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "mallbots" <<-EOSQL
     subject      text NOT NULL,
     data         bytea NOT NULL,
     published_at timestamptz,
+    attempts     int NOT NULL DEFAULT 0,
+    last_error   text,
+    locked_until timestamptz,
+    seq          bigserial,
+    created_at   timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
     PRIMARY KEY (id)
   );
 
-  CREATE INDEX stores_unpublished_idx ON stores.outbox (published_at) WHERE published_at IS NULL;
+  CREATE INDEX stores_unpublished_idx ON stores.outbox (seq) WHERE published_at IS NULL;
+  CREATE INDEX stores_published_idx ON stores.outbox (published_at) WHERE published_at IS NOT NULL;
+  CREATE INDEX stores_received_idx ON stores.inbox (received_at);
+  CREATE INDEX stores_outcome_idx ON stores.inbox (outcome, received_at);
 
   GRANT USAGE ON SCHEMA stores TO mallbots_user;
   GRANT INSERT, UPDATE, DELETE, SELECT ON ALL TABLES IN SCHEMA stores TO mallbots_user;
+  GRANT USAGE ON ALL SEQUENCES IN SCHEMA stores TO mallbots_user;
 EOSQL
@@ -20,11 +20,15 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "mallbots" <<-EOSQL
 
   CREATE TABLE cosec.inbox
   (
-    id          text NOT NULL,
-    name        text NOT NULL,
-    subject     text NOT NULL,
-    data        bytea NOT NULL,
-    received_at timestamptz NOT NULL,
+    id           text NOT NULL,
+    name         text NOT NULL,
+    subject      text NOT NULL,
+    data         bytea NOT NULL,
+    received_at  timestamptz NOT NULL,
+    processed_at timestamptz,
+    outcome      text,
+    error        text,
+    handler      text,
     PRIMARY KEY (id)
   );
 
@@ -35,11 +39,20 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "mallbots" <<-EOSQL
     subject      text NOT NULL,
     data         bytea NOT NULL,
     published_at timestamptz,
+    attempts     int NOT NULL DEFAULT 0,
+    last_error   text,
+    locked_until timestamptz,
+    seq          bigserial,
+    created_at   timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
     PRIMARY KEY (id)
   );
 
-  CREATE INDEX cosec_unpublished_idx ON baskets.outbox (published_at) WHERE published_at IS NULL;
+  CREATE INDEX cosec_unpublished_idx ON cosec.outbox (seq) WHERE published_at IS NULL;
+  CREATE INDEX cosec_published_idx ON cosec.outbox (published_at) WHERE published_at IS NOT NULL;
+  CREATE INDEX cosec_received_idx ON cosec.inbox (received_at);
+  CREATE INDEX cosec_outcome_idx ON cosec.inbox (outcome, received_at);
 
   GRANT USAGE ON SCHEMA cosec TO mallbots_user;
   GRANT INSERT, UPDATE, DELETE, SELECT ON ALL TABLES IN SCHEMA cosec TO mallbots_user;
+  GRANT USAGE ON ALL SEQUENCES IN SCHEMA cosec TO mallbots_user;
 EOSQL
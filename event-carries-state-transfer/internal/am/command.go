@@ -85,12 +85,14 @@ func (h commandMsgHandler) HandleMessage(ctx context.Context, msg IncomingRawMes
 
 	destination := commandMsg.Metadata().Get(CommandReplyChannelHandler).(string)
 
-	reply, err := h.handler.HandleCommand(ctx, commandMsg)
-	if err != nil {
-		return h.publishReply(ctx, destination, h.failure(reply, commandMsg))
-	}
+	return handleInSpan(ctx, commandMsg, commandMsg.metadata, func(ctx context.Context) error {
+		reply, err := h.handler.HandleCommand(ctx, commandMsg)
+		if err != nil {
+			return h.publishReply(ctx, destination, h.failure(reply, commandMsg))
+		}
 
-	return h.publishReply(ctx, destination, h.success(reply, commandMsg))
+		return h.publishReply(ctx, destination, h.success(reply, commandMsg))
+	})
 }
 
 func (h commandMsgHandler) publishReply(ctx context.Context, destination string, reply ddd.Reply) error {
@@ -10,6 +10,7 @@ import (
 
 	"eda-in-golang/internal/ddd"
 	"eda-in-golang/internal/registry"
+	"eda-in-golang/internal/tracing"
 )
 
 type (
@@ -56,8 +57,12 @@ func NewCommandStream(reg registry.Registry, stream RawMessageStream) CommandStr
 	}
 }
 
-func (s commandStream) Publish(ctx context.Context, topicName string, command ddd.Command) error {
-	metadata, err := structpb.NewStruct(command.Metadata())
+func (s commandStream) Publish(ctx context.Context, topicName string, command ddd.Command) (err error) {
+	md := stampMessageIDs(ctx, command.ID(), command.Metadata())
+	ctx, span := startPublishSpan(ctx, topicName, command.CommandName(), command.ID(), md)
+	defer func() { tracing.End(span, err) }()
+
+	metadata, err := structpb.NewStruct(md)
 	if err != nil {
 		return err
 	}
@@ -127,7 +132,9 @@ func (s commandStream) Subscribe(topicName string, handler CommandMessageHandler
 			msg:        msg,
 		}
 
-		return handler.HandleMessage(ctx, commandMsg)
+		return handleInSpan(ctx, commandMsg, commandMsg.metadata, func(ctx context.Context) error {
+			return handler.HandleMessage(ctx, commandMsg)
+		})
 	})
 
 	return s.stream.Subscribe(topicName, fn, options...)
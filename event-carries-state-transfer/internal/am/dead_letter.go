@@ -0,0 +1,35 @@
+package am
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	DeadLetterHeaderPrefix     = "Dead-Letter-"
+	DeadLetterSubjectHeader    = DeadLetterHeaderPrefix + "Subject"
+	DeadLetterErrorHeader      = DeadLetterHeaderPrefix + "Error"
+	DeadLetterDeliveriesHeader = DeadLetterHeaderPrefix + "Deliveries"
+	DeadLetterGroupHeader      = DeadLetterHeaderPrefix + "Group"
+
+	// ReplayGroupHeader names the group a dead-lettered message is replayed
+	// for; the other subscribers to the subject drop the message
+	ReplayGroupHeader = "Replay-Group"
+)
+
+type (
+	// DeadLetterMessage is a message that exhausted its redeliveries; Subject
+	// returns the subject it was originally published to
+	DeadLetterMessage interface {
+		RawMessage
+		Reason() string
+		Deliveries() int
+		GroupName() string
+		DeadAt() time.Time
+	}
+
+	DeadLetterQueue interface {
+		DeadLetters(ctx context.Context, subject string, limit int) ([]DeadLetterMessage, error)
+		Replay(ctx context.Context, subject string, ids ...string) error
+	}
+)
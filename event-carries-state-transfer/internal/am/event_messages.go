@@ -10,6 +10,7 @@ import (
 
 	"eda-in-golang/internal/ddd"
 	"eda-in-golang/internal/registry"
+	"eda-in-golang/internal/tracing"
 )
 
 type (
@@ -53,8 +54,12 @@ func NewEventStream(reg registry.Registry, stream RawMessageStream) EventStream
 	}
 }
 
-func (s eventStream) Publish(ctx context.Context, topicName string, event ddd.Event) error {
-	metadata, err := structpb.NewStruct(event.Metadata())
+func (s eventStream) Publish(ctx context.Context, topicName string, event ddd.Event) (err error) {
+	md := stampMessageIDs(ctx, event.ID(), event.Metadata())
+	ctx, span := startPublishSpan(ctx, topicName, event.EventName(), event.ID(), md)
+	defer func() { tracing.End(span, err) }()
+
+	metadata, err := structpb.NewStruct(md)
 	if err != nil {
 		return err
 	}
@@ -125,7 +130,9 @@ func (s eventStream) Subscribe(topicName string, handler MessageHandler[Incoming
 			msg:        msg,
 		}
 
-		return handler.HandleMessage(ctx, eventMsg)
+		return handleInSpan(ctx, eventMsg, eventMsg.metadata, func(ctx context.Context) error {
+			return handler.HandleMessage(ctx, eventMsg)
+		})
 	})
 
 	return s.stream.Subscribe(topicName, fn, options...)
@@ -179,5 +186,7 @@ func (h eventMsgHandler) HandleMessage(ctx context.Context, msg IncomingRawMessa
 		msg:        msg,
 	}
 
-	return h.handler.HandleEvent(ctx, eventMsg)
+	return handleInSpan(ctx, eventMsg, eventMsg.metadata, func(ctx context.Context) error {
+		return h.handler.HandleEvent(ctx, eventMsg)
+	})
 }
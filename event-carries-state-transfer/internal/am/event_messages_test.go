@@ -0,0 +1,83 @@
+package am_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/memstream"
+	"eda-in-golang/internal/registry"
+	"eda-in-golang/internal/registry/serdes"
+)
+
+type orderCreated struct {
+	OrderID string
+}
+
+type orderNotified struct {
+	OrderID string
+}
+
+func (orderCreated) Key() string  { return "test.OrderCreated" }
+func (orderNotified) Key() string { return "test.OrderNotified" }
+
+func TestEventStream_MessageIDs(t *testing.T) {
+	reg := registry.New()
+	serde := serdes.NewJsonSerde(reg)
+	require.NoError(t, serde.Register(orderCreated{}))
+	require.NoError(t, serde.Register(orderNotified{}))
+
+	events := am.NewEventStream(reg, memstream.NewStream(zerolog.Nop()))
+
+	received := make(chan am.IncomingEventMessage, 2)
+	record := func(ctx context.Context, msg am.IncomingEventMessage) error {
+		received <- msg
+		return nil
+	}
+
+	// the first handler publishes an event of its own
+	require.NoError(t, events.Subscribe("test.orders", am.MessageHandlerFunc[am.IncomingEventMessage](
+		func(ctx context.Context, msg am.IncomingEventMessage) error {
+			if err := record(ctx, msg); err != nil {
+				return err
+			}
+			payload := msg.Payload().(*orderCreated)
+			return events.Publish(ctx, "test.notifications", ddd.NewEvent(
+				orderNotified{}.Key(), &orderNotified{OrderID: payload.OrderID},
+			))
+		}),
+	))
+	require.NoError(t, events.Subscribe("test.notifications", am.MessageHandlerFunc[am.IncomingEventMessage](record)))
+
+	ctx := am.WithMessageIDs(context.Background(), am.MessageIDs{CorrelationID: "request-id", MessageID: "request-id"})
+	first := ddd.NewEvent(orderCreated{}.Key(), &orderCreated{OrderID: "order-id"})
+	require.NoError(t, events.Publish(ctx, "test.orders", first))
+
+	messages := make(map[string]am.IncomingEventMessage)
+	for range 2 {
+		select {
+		case msg := <-received:
+			messages[msg.EventName()] = msg
+		case <-time.After(time.Second):
+			t.Fatal("the events were not handled")
+		}
+	}
+
+	created := messages[orderCreated{}.Key()]
+	require.NotNil(t, created)
+	assert.Equal(t, first.ID(), created.ID())
+	assert.Equal(t, "request-id", created.Metadata().Get(am.CorrelationIDKey))
+	assert.Equal(t, "request-id", created.Metadata().Get(am.CausationIDKey))
+
+	notified := messages[orderNotified{}.Key()]
+	require.NotNil(t, notified)
+	assert.Equal(t, "request-id", notified.Metadata().Get(am.CorrelationIDKey), "the correlation ID was not carried over")
+	assert.Equal(t, created.ID(), notified.Metadata().Get(am.CausationIDKey), "the causation ID is not the ID of the handled message")
+	assert.Equal(t, notified.ID(), notified.Metadata().Get(am.MessageIDKey))
+}
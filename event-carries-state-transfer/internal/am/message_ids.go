@@ -0,0 +1,92 @@
+package am
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"eda-in-golang/internal/ddd"
+)
+
+const (
+	CorrelationIDKey = "correlation-id"
+	CausationIDKey   = "causation-id"
+	MessageIDKey     = "message-id"
+)
+
+type (
+	// MessageIDs tie together the messages that follow from one another. The
+	// correlation ID is shared by every message that follows from the first
+	// one, and the causation ID is the ID of the message that was being
+	// handled when a message was published.
+	MessageIDs struct {
+		CorrelationID string
+		CausationID   string
+		MessageID     string
+	}
+
+	messageIDsKey struct{}
+)
+
+var _ zerolog.LogObjectMarshaler = (*MessageIDs)(nil)
+
+// WithMessageIDs returns a context that publishes messages as following from
+// the message with the IDs
+func WithMessageIDs(ctx context.Context, ids MessageIDs) context.Context {
+	return context.WithValue(ctx, messageIDsKey{}, ids)
+}
+
+// MessageIDsFrom returns the IDs of the message being handled, if any
+func MessageIDsFrom(ctx context.Context) MessageIDs {
+	ids, _ := ctx.Value(messageIDsKey{}).(MessageIDs)
+	return ids
+}
+
+// MarshalZerologObject adds the IDs that are set to log events
+func (ids MessageIDs) MarshalZerologObject(e *zerolog.Event) {
+	if ids.CorrelationID != "" {
+		e.Str(CorrelationIDKey, ids.CorrelationID)
+	}
+	if ids.CausationID != "" {
+		e.Str(CausationIDKey, ids.CausationID)
+	}
+	if ids.MessageID != "" {
+		e.Str(MessageIDKey, ids.MessageID)
+	}
+}
+
+// stampMessageIDs returns a copy of the metadata with the IDs of an outgoing
+// message; a message published outside of a handler starts a new correlation
+func stampMessageIDs(ctx context.Context, messageID string, metadata ddd.Metadata) ddd.Metadata {
+	stamped := make(ddd.Metadata, len(metadata)+3)
+	for key, value := range metadata {
+		stamped[key] = value
+	}
+
+	ids := MessageIDsFrom(ctx)
+	if ids.CorrelationID == "" {
+		ids.CorrelationID = messageID
+	}
+	if ids.MessageID == "" {
+		ids.MessageID = messageID
+	}
+
+	stamped.Set(CorrelationIDKey, ids.CorrelationID)
+	stamped.Set(CausationIDKey, ids.MessageID)
+	stamped.Set(MessageIDKey, messageID)
+
+	return stamped
+}
+
+// messageContext returns a context with the IDs of an incoming message so that
+// the messages published while handling it follow from it
+func messageContext(ctx context.Context, messageID string, metadata ddd.Metadata) context.Context {
+	ids := MessageIDs{MessageID: messageID}
+	ids.CorrelationID, _ = metadata.Get(CorrelationIDKey).(string)
+	ids.CausationID, _ = metadata.Get(CausationIDKey).(string)
+	if ids.CorrelationID == "" {
+		ids.CorrelationID = messageID
+	}
+
+	return WithMessageIDs(ctx, ids)
+}
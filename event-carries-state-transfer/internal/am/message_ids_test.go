@@ -0,0 +1,76 @@
+package am
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"eda-in-golang/internal/ddd"
+)
+
+func TestStampMessageIDs(t *testing.T) {
+	t.Run("OutsideOfAHandler", func(t *testing.T) {
+		metadata := ddd.Metadata{"key": "value"}
+
+		stamped := stampMessageIDs(context.Background(), "message-id", metadata)
+
+		assert.Equal(t, ddd.Metadata{
+			"key":            "value",
+			CorrelationIDKey: "message-id",
+			CausationIDKey:   "message-id",
+			MessageIDKey:     "message-id",
+		}, stamped)
+		assert.Equal(t, ddd.Metadata{"key": "value"}, metadata, "the metadata passed in was modified")
+	})
+	t.Run("WhileHandlingAMessage", func(t *testing.T) {
+		ctx := WithMessageIDs(context.Background(), MessageIDs{
+			CorrelationID: "correlation-id",
+			CausationID:   "causation-id",
+			MessageID:     "incoming-id",
+		})
+
+		stamped := stampMessageIDs(ctx, "message-id", nil)
+
+		assert.Equal(t, "correlation-id", stamped.Get(CorrelationIDKey))
+		assert.Equal(t, "incoming-id", stamped.Get(CausationIDKey))
+		assert.Equal(t, "message-id", stamped.Get(MessageIDKey))
+	})
+	t.Run("ReplacesStaleIDs", func(t *testing.T) {
+		ctx := WithMessageIDs(context.Background(), MessageIDs{CorrelationID: "correlation-id", MessageID: "incoming-id"})
+
+		stamped := stampMessageIDs(ctx, "message-id", ddd.Metadata{
+			CorrelationIDKey: "other-id",
+			CausationIDKey:   "other-id",
+			MessageIDKey:     "other-id",
+		})
+
+		assert.Equal(t, "correlation-id", stamped.Get(CorrelationIDKey))
+		assert.Equal(t, "incoming-id", stamped.Get(CausationIDKey))
+		assert.Equal(t, "message-id", stamped.Get(MessageIDKey))
+	})
+}
+
+func TestMessageContext(t *testing.T) {
+	t.Run("ContinuesTheCorrelation", func(t *testing.T) {
+		ctx := messageContext(context.Background(), "message-id", ddd.Metadata{
+			CorrelationIDKey: "correlation-id",
+			CausationIDKey:   "causation-id",
+		})
+
+		assert.Equal(t, MessageIDs{
+			CorrelationID: "correlation-id",
+			CausationID:   "causation-id",
+			MessageID:     "message-id",
+		}, MessageIDsFrom(ctx))
+	})
+	t.Run("StartsACorrelation", func(t *testing.T) {
+		// a message published before message IDs were stamped
+		ctx := messageContext(context.Background(), "message-id", ddd.Metadata{})
+
+		assert.Equal(t, MessageIDs{
+			CorrelationID: "message-id",
+			MessageID:     "message-id",
+		}, MessageIDsFrom(ctx))
+	})
+}
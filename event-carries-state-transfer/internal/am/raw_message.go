@@ -51,7 +51,7 @@ func RawMessageStreamWithMiddleware(stream RawMessageStream, mws ...RawMessageSt
 		s = mws[i](s)
 	}
 
-	return stream
+	return s
 }
 
 func RawMessageHandlerWithMiddleware(handler RawMessageHandler, mws ...RawMessageHandlerMiddleware) RawMessageHandler {
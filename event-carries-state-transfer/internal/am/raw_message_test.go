@@ -0,0 +1,58 @@
+package am
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRawStream struct {
+	published []string
+}
+
+func (s *testRawStream) Publish(_ context.Context, topicName string, _ RawMessage) error {
+	s.published = append(s.published, "stream "+topicName)
+	return nil
+}
+
+func (s *testRawStream) Subscribe(string, MessageHandler[IncomingRawMessage], ...SubscriberOption) error {
+	return nil
+}
+
+type testStreamMiddleware struct {
+	RawMessageStream
+	name string
+	log  *[]string
+}
+
+func (m testStreamMiddleware) Publish(ctx context.Context, topicName string, msg RawMessage) error {
+	*m.log = append(*m.log, m.name)
+	return m.RawMessageStream.Publish(ctx, topicName, msg)
+}
+
+func TestRawMessageStreamWithMiddleware(t *testing.T) {
+	stream := &testRawStream{}
+	middleware := func(name string) RawMessageStreamMiddleware {
+		return func(next RawMessageStream) RawMessageStream {
+			return testStreamMiddleware{RawMessageStream: next, name: name, log: &stream.published}
+		}
+	}
+
+	t.Run("AppliesTheMiddleware", func(t *testing.T) {
+		stream.published = nil
+		s := RawMessageStreamWithMiddleware(stream, middleware("outbox"), middleware("metrics"))
+
+		require.NoError(t, s.Publish(context.Background(), "test.topic", rawMessage{id: "message-id"}))
+		assert.Equal(t, []string{"outbox", "metrics", "stream test.topic"}, stream.published)
+	})
+	t.Run("WithoutMiddleware", func(t *testing.T) {
+		stream.published = nil
+		s := RawMessageStreamWithMiddleware(stream)
+
+		assert.Same(t, stream, s)
+		require.NoError(t, s.Publish(context.Background(), "test.topic", rawMessage{id: "message-id"}))
+		assert.Equal(t, []string{"stream test.topic"}, stream.published)
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 	"eda-in-golang/internal/ddd"
 	"eda-in-golang/internal/registry"
+	"eda-in-golang/internal/tracing"
 )
 
 type (
@@ -52,8 +53,12 @@ func NewReplyStream(reg registry.Registry, stream RawMessageStream) ReplyStream
 	}
 }
 
-func (s *replyStream) Publish(ctx context.Context, topicName string, reply ddd.Reply) error {
-	metadata, err := structpb.NewStruct(reply.Metadata())
+func (s *replyStream) Publish(ctx context.Context, topicName string, reply ddd.Reply) (err error) {
+	md := stampMessageIDs(ctx, reply.ID(), reply.Metadata())
+	ctx, span := startPublishSpan(ctx, topicName, reply.ReplyName(), reply.ID(), md)
+	defer func() { tracing.End(span, err) }()
+
+	metadata, err := structpb.NewStruct(md)
 	if err != nil {
 		return err
 	}
@@ -77,9 +82,10 @@ func (s *replyStream) Publish(ctx context.Context, topicName string, reply ddd.R
 	}
 
 	return s.stream.Publish(ctx, topicName, &rawMessage{
-		id:   reply.ID(),
-		name: reply.ReplyName(),
-		data: data,
+		id:      reply.ID(),
+		name:    reply.ReplyName(),
+		subject: topicName,
+		data:    data,
 	})
 }
 
@@ -128,7 +134,9 @@ func (s *replyStream) Subscribe(topicName string, handler MessageHandler[Incomin
 			msg:        msg,
 		}
 
-		return handler.HandleMessage(ctx, replyMsg)
+		return handleInSpan(ctx, replyMsg, replyMsg.metadata, func(ctx context.Context) error {
+			return handler.HandleMessage(ctx, replyMsg)
+		})
 	})
 
 	return s.stream.Subscribe(topicName, fn, options...)
@@ -0,0 +1,138 @@
+package am
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stackus/errors"
+
+	"eda-in-golang/internal/ddd"
+)
+
+const (
+	CommandRequestIDHandler = CommandHandlerPrefix + "REQUEST_ID"
+	ReplyRequestIDHandler   = ReplyHandlerPrefix + "REQUEST_ID"
+)
+
+const defaultRequestTimeout = 5 * time.Second
+
+var (
+	// ErrFailureReply is returned along with the reply when the command
+	// handler replied with a failure
+	ErrFailureReply = errors.Wrap(errors.ErrInternal, "the command handler replied with a failure")
+	// ErrNoReply is returned when no reply arrived before the request timed out
+	ErrNoReply = errors.Wrap(errors.ErrDeadlineExceeded, "no reply was received for the command")
+)
+
+type (
+	// Requester publishes commands and waits for their replies. Replies are
+	// sent to a reply topic of its own; the request ID it adds to each command
+	// comes back on the reply by way of the usual command to reply headers.
+	Requester interface {
+		Request(ctx context.Context, cmd Command) (ddd.Reply, error)
+	}
+
+	RequesterOption func(r *requester)
+
+	requester struct {
+		publisher  CommandPublisher
+		replyTopic string
+		timeout    time.Duration
+		pending    map[string]chan ddd.Reply
+		mu         sync.Mutex
+	}
+)
+
+var _ Requester = (*requester)(nil)
+
+// NewRequester subscribes to a reply topic made unique by adding a random
+// token to replyTopic, which must be a topic the stream accepts. The publisher
+// must publish right away; a command left in an outbox until a transaction
+// commits would not be published while the requester waits for its reply.
+func NewRequester(publisher CommandPublisher, subscriber ReplySubscriber, replyTopic string, options ...RequesterOption) (Requester, error) {
+	r := &requester{
+		publisher:  publisher,
+		replyTopic: replyTopic + "." + uuid.New().String(),
+		timeout:    defaultRequestTimeout,
+		pending:    make(map[string]chan ddd.Reply),
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	err := subscriber.Subscribe(r.replyTopic, MessageHandlerFunc[IncomingReplyMessage](r.handleReply))
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// RequestTimeout sets how long Request waits for a reply when the context has
+// no earlier deadline
+func RequestTimeout(timeout time.Duration) RequesterOption {
+	return func(r *requester) {
+		if timeout > 0 {
+			r.timeout = timeout
+		}
+	}
+}
+
+func (r *requester) Request(ctx context.Context, cmd Command) (ddd.Reply, error) {
+	requestID := cmd.ID()
+	replyC := make(chan ddd.Reply, 1)
+
+	r.mu.Lock()
+	r.pending[requestID] = replyC
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.pending, requestID)
+		r.mu.Unlock()
+	}()
+
+	cmd.Metadata().Set(CommandReplyChannelHandler, r.replyTopic)
+	cmd.Metadata().Set(CommandRequestIDHandler, requestID)
+
+	if err := r.publisher.Publish(ctx, cmd.Destination(), cmd); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	select {
+	case reply := <-replyC:
+		if outcome, _ := reply.Metadata().Get(ReplyOutcomeHandler).(string); outcome != OutcomeSuccess {
+			return reply, ErrFailureReply
+		}
+		return reply, nil
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, errors.Wrapf(ErrNoReply, "command %s", cmd.CommandName())
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// handleReply hands the reply to the waiting request; replies to requests that
+// have already given up are dropped
+func (r *requester) handleReply(_ context.Context, msg IncomingReplyMessage) error {
+	requestID, _ := msg.Metadata().Get(ReplyRequestIDHandler).(string)
+
+	r.mu.Lock()
+	replyC, exists := r.pending[requestID]
+	r.mu.Unlock()
+
+	if exists {
+		select {
+		case replyC <- msg:
+		default:
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package am_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stackus/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/memstream"
+	"eda-in-golang/internal/registry"
+	"eda-in-golang/internal/registry/serdes"
+)
+
+type reserveStock struct {
+	ProductID string
+	Quantity  int
+}
+
+type stockReserved struct {
+	ReservationID string
+}
+
+func (reserveStock) Key() string  { return "test.ReserveStock" }
+func (stockReserved) Key() string { return "test.StockReserved" }
+
+func TestRequester_Request(t *testing.T) {
+	reg := registry.New()
+	serde := serdes.NewJsonSerde(reg)
+	require.NoError(t, serde.Register(reserveStock{}))
+	require.NoError(t, serde.Register(stockReserved{}))
+
+	stream := memstream.NewStream(zerolog.Nop())
+	commands := am.NewCommandStream(reg, stream)
+	replies := am.NewReplyStream(reg, stream)
+
+	handler := ddd.CommandHandlerFunc[ddd.Command](func(ctx context.Context, cmd ddd.Command) (ddd.Reply, error) {
+		payload := cmd.Payload().(*reserveStock)
+		switch {
+		case payload.Quantity > 10:
+			return nil, errors.ErrFailedPrecondition.Msg("not enough stock")
+		case payload.Quantity == 0:
+			time.Sleep(100 * time.Millisecond)
+		}
+		return ddd.NewReply(stockReserved{}.Key(), &stockReserved{ReservationID: "reservation-id"}), nil
+	})
+	require.NoError(t, stream.Subscribe("test.commands", am.NewCommandMessageHandler(reg, replies, handler)))
+
+	requester, err := am.NewRequester(commands, replies, "test.replies", am.RequestTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+
+	request := func(quantity int) (ddd.Reply, error) {
+		return requester.Request(context.Background(), am.NewCommand(
+			reserveStock{}.Key(), "test.commands", &reserveStock{ProductID: "product-id", Quantity: quantity},
+		))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		reply, err := request(1)
+		require.NoError(t, err)
+		assert.Equal(t, stockReserved{}.Key(), reply.ReplyName())
+		assert.Equal(t, &stockReserved{ReservationID: "reservation-id"}, reply.Payload())
+	})
+	t.Run("Failure", func(t *testing.T) {
+		reply, err := request(11)
+		assert.ErrorIs(t, err, am.ErrFailureReply)
+		require.NotNil(t, reply)
+		assert.Equal(t, am.FailureReply, reply.ReplyName())
+	})
+	t.Run("Timeout", func(t *testing.T) {
+		_, err := request(0)
+		assert.ErrorIs(t, err, am.ErrNoReply)
+	})
+}
@@ -0,0 +1,119 @@
+package am
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/stackus/errors"
+)
+
+var defaultRetryInitialDelay = 500 * time.Millisecond
+var defaultRetryMaxDelay = 30 * time.Second
+var defaultRetryMultiplier = 2.0
+var defaultRetryJitter = 0.2
+
+type (
+	// RetryPolicy decides how failed deliveries are handled; transient failures
+	// are redelivered with an exponential backoff and permanent failures are
+	// not redelivered at all
+	RetryPolicy struct {
+		initialDelay time.Duration
+		maxDelay     time.Duration
+		multiplier   float64
+		jitter       float64
+		isPermanent  func(err error) bool
+	}
+
+	RetryPolicyOption func(p *RetryPolicy)
+)
+
+func NewRetryPolicy(options ...RetryPolicyOption) RetryPolicy {
+	p := RetryPolicy{
+		initialDelay: defaultRetryInitialDelay,
+		maxDelay:     defaultRetryMaxDelay,
+		multiplier:   defaultRetryMultiplier,
+		jitter:       defaultRetryJitter,
+		isPermanent:  IsPermanentError,
+	}
+
+	for _, option := range options {
+		option(&p)
+	}
+
+	return p
+}
+
+func RetryInitialDelay(delay time.Duration) RetryPolicyOption {
+	return func(p *RetryPolicy) {
+		p.initialDelay = delay
+	}
+}
+
+func RetryMaxDelay(delay time.Duration) RetryPolicyOption {
+	return func(p *RetryPolicy) {
+		p.maxDelay = delay
+	}
+}
+
+func RetryMultiplier(multiplier float64) RetryPolicyOption {
+	return func(p *RetryPolicy) {
+		p.multiplier = multiplier
+	}
+}
+
+// RetryJitter randomizes each delay by up to the given fraction of itself
+func RetryJitter(jitter float64) RetryPolicyOption {
+	return func(p *RetryPolicy) {
+		p.jitter = math.Max(0, math.Min(1, jitter))
+	}
+}
+
+// RetryClassifier replaces the function used to recognize permanent errors
+func RetryClassifier(isPermanent func(err error) bool) RetryPolicyOption {
+	return func(p *RetryPolicy) {
+		p.isPermanent = isPermanent
+	}
+}
+
+// Delay returns how long to wait before the next delivery given the number of
+// deliveries that have been attempted so far; it is never more than the max
+// delay
+func (p RetryPolicy) Delay(deliveries int) time.Duration {
+	if deliveries < 1 {
+		deliveries = 1
+	}
+
+	delay := float64(p.initialDelay) * math.Pow(p.multiplier, float64(deliveries-1))
+	if p.jitter > 0 {
+		delay += delay * p.jitter * (2*rand.Float64() - 1)
+	}
+
+	// the jitter may not take the delay past the max
+	if p.maxDelay > 0 {
+		delay = math.Min(delay, float64(p.maxDelay))
+	}
+
+	return time.Duration(delay)
+}
+
+func (p RetryPolicy) IsPermanent(err error) bool {
+	return p.isPermanent != nil && p.isPermanent(err)
+}
+
+func (p RetryPolicy) configureSubscriberConfig(cfg *SubscriberConfig) {
+	cfg.retryPolicy = &p
+}
+
+// IsPermanentError treats client errors such as errors.ErrBadRequest or
+// errors.ErrNotFound as permanent; a redelivery would fail the same way.
+// Timeouts, rate limiting and conflicts are expected to clear up on their own.
+func IsPermanentError(err error) bool {
+	switch code := errors.HTTPCode(err); code {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+		return false
+	default:
+		return code >= 400 && code < 500
+	}
+}
@@ -0,0 +1,42 @@
+package am
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	const samples = 1000
+
+	policy := NewRetryPolicy(
+		RetryInitialDelay(time.Second),
+		RetryMaxDelay(10*time.Second),
+		RetryMultiplier(2),
+		RetryJitter(0.5),
+	)
+
+	type testCase struct {
+		deliveries int
+		min, max   time.Duration
+	}
+
+	tests := map[string]testCase{
+		"FirstDelivery": {deliveries: 1, min: 500 * time.Millisecond, max: 1500 * time.Millisecond},
+		"Backoff":       {deliveries: 3, min: 2 * time.Second, max: 6 * time.Second},
+		// the jitter is applied before the max; it never takes the delay past it
+		"AtTheMax":   {deliveries: 5, min: 5 * time.Second, max: 10 * time.Second},
+		"PastTheMax": {deliveries: 20, min: 5 * time.Second, max: 10 * time.Second},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			for range samples {
+				delay := policy.Delay(tc.deliveries)
+				assert.GreaterOrEqual(t, delay, tc.min)
+				assert.LessOrEqual(t, delay, tc.max)
+			}
+		})
+	}
+}
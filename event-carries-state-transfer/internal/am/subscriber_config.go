@@ -1,6 +1,9 @@
 package am
 
-import "time"
+import (
+	"hash/fnv"
+	"time"
+)
 
 type AckType int
 
@@ -18,6 +21,10 @@ type SubscriberConfig struct {
 	ackType      AckType
 	ackWait      time.Duration
 	maxRedeliver int
+	deadLetter   string
+	retryPolicy  *RetryPolicy
+	shardKey     string
+	shardWorkers int
 }
 
 func NewSubscriberConfig(options []SubscriberOption) SubscriberConfig {
@@ -41,6 +48,9 @@ func NewSubscriberConfig(options []SubscriberOption) SubscriberConfig {
 // - Consumer group names
 // - Acknowledgment types (auto/manual)
 // - Ack wait times and redelivery limits
+// - Dead-letter subjects for messages that exhaust their redeliveries
+// - Retry policies with backoff for failed deliveries
+// - Sharding messages by a metadata key across parallel workers
 type SubscriberOption interface {
 	configureSubscriberConfig(*SubscriberConfig)
 }
@@ -65,6 +75,22 @@ func (c SubscriberConfig) MaxRedeliver() int {
 	return c.maxRedeliver
 }
 
+func (c SubscriberConfig) DeadLetter() string {
+	return c.deadLetter
+}
+
+func (c SubscriberConfig) RetryPolicy() *RetryPolicy {
+	return c.retryPolicy
+}
+
+func (c SubscriberConfig) ShardKey() string {
+	return c.shardKey
+}
+
+func (c SubscriberConfig) ShardWorkers() int {
+	return c.shardWorkers
+}
+
 type MessageFilter []string
 
 func (s MessageFilter) configureSubscriberConfig(cfg *SubscriberConfig) {
@@ -92,3 +118,40 @@ type MaxRedeliver int
 func (i MaxRedeliver) configureSubscriberConfig(cfg *SubscriberConfig) {
 	cfg.maxRedeliver = int(i)
 }
+
+// DeadLetter is the subject that messages are republished to after their
+// final failed delivery
+type DeadLetter string
+
+func (s DeadLetter) configureSubscriberConfig(cfg *SubscriberConfig) {
+	cfg.deadLetter = string(s)
+}
+
+// ShardBy handles messages on a number of workers, picking the worker by the
+// value of a metadata key such as ddd.AggregateIDKey. Messages with the same
+// value are handled one at a time in the order they were delivered, and
+// messages with different values are handled in parallel. Messages without the
+// key all share one worker. A message that fails and is redelivered may be
+// handled after the messages that were delivered behind it.
+type ShardBy struct {
+	Key     string
+	Workers int
+}
+
+func (s ShardBy) configureSubscriberConfig(cfg *SubscriberConfig) {
+	if s.Key != "" && s.Workers > 1 {
+		cfg.shardKey = s.Key
+		cfg.shardWorkers = s.Workers
+	}
+}
+
+// Shard returns the worker of the shards, numbered from zero, that handles the
+// message
+func Shard(msg RawMessage, key string, shards int) int {
+	value, _ := RawMessageMetadata(msg).Get(key).(string)
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(value))
+
+	return int(h.Sum32() % uint32(shards))
+}
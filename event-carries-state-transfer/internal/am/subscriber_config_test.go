@@ -0,0 +1,49 @@
+package am
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"eda-in-golang/internal/ddd"
+)
+
+func TestShard(t *testing.T) {
+	message := func(id string, metadata map[string]any) RawMessage {
+		md, err := structpb.NewStruct(metadata)
+		require.NoError(t, err)
+		data, err := proto.Marshal(&EventMessageData{Metadata: md})
+		require.NoError(t, err)
+		return rawMessage{id: id, name: "test.Event", subject: "test.events", data: data}
+	}
+
+	const shards = 8
+
+	first := Shard(message("message-1", map[string]any{ddd.AggregateIDKey: "order-1"}), ddd.AggregateIDKey, shards)
+	assert.Equal(t, first, Shard(message("message-2", map[string]any{ddd.AggregateIDKey: "order-1"}), ddd.AggregateIDKey, shards),
+		"messages with the same key were given to different shards")
+
+	seen := make(map[int]struct{})
+	for _, id := range []string{"order-2", "order-3", "order-4", "order-5", "order-6", "order-7"} {
+		shard := Shard(message(id, map[string]any{ddd.AggregateIDKey: id}), ddd.AggregateIDKey, shards)
+		assert.True(t, shard >= 0 && shard < shards)
+		seen[shard] = struct{}{}
+	}
+	assert.Greater(t, len(seen), 1, "messages with different keys were all given to the same shard")
+
+	unkeyed := Shard(message("message-3", nil), ddd.AggregateIDKey, shards)
+	assert.Equal(t, unkeyed, Shard(message("message-4", map[string]any{"other": "value"}), ddd.AggregateIDKey, shards),
+		"messages without the key were given to different shards")
+}
+
+func TestShardBy(t *testing.T) {
+	cfg := NewSubscriberConfig([]SubscriberOption{ShardBy{Key: ddd.AggregateIDKey, Workers: 4}})
+	assert.Equal(t, ddd.AggregateIDKey, cfg.ShardKey())
+	assert.Equal(t, 4, cfg.ShardWorkers())
+
+	cfg = NewSubscriberConfig([]SubscriberOption{ShardBy{Key: ddd.AggregateIDKey, Workers: 1}})
+	assert.Equal(t, 0, cfg.ShardWorkers(), "a single worker does not need sharding")
+}
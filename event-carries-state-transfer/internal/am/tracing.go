@@ -0,0 +1,69 @@
+package am
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/tracing"
+)
+
+const tracerName = "eda-in-golang/internal/am"
+
+// startPublishSpan starts the span for publishing a message and adds the trace
+// context to the outgoing metadata
+func startPublishSpan(ctx context.Context, topicName, messageName, messageID string, metadata ddd.Metadata) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "publish "+messageName,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingDestinationName(topicName),
+			semconv.MessagingMessageID(messageID),
+			semconv.MessagingOperationTypeSend,
+		),
+	)
+
+	tracing.Inject(ctx, metadata)
+
+	return ctx, span
+}
+
+// handleInSpan runs the handler in a span for the incoming message. The span
+// belongs to the trace of a span already started for the message, such as the
+// one for the inbox, or otherwise to the trace carried by the message metadata.
+func handleInSpan(ctx context.Context, msg Message, metadata ddd.Metadata, handle func(ctx context.Context) error) error {
+	ctx = messageContext(ctx, msg.ID(), metadata)
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = tracing.Extract(ctx, metadata)
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "handle "+msg.MessageName(),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingDestinationName(msg.Subject()),
+			semconv.MessagingMessageID(msg.ID()),
+			semconv.MessagingOperationTypeProcess,
+		),
+	)
+
+	err := handle(ctx)
+	tracing.End(span, err)
+
+	return err
+}
+
+// RawMessageMetadata returns the metadata of a serialized event, command or
+// reply, which share the same layout, so that the trace of a raw message can be
+// followed without deserializing its payload
+func RawMessageMetadata(msg RawMessage) ddd.Metadata {
+	var data EventMessageData
+	if err := proto.Unmarshal(msg.Data(), &data); err != nil {
+		return ddd.Metadata{}
+	}
+
+	return data.GetMetadata().AsMap()
+}
@@ -8,26 +8,53 @@ import (
 	"github.com/stackus/dotenv"
 
 	"eda-in-golang/internal/rpc"
+	"eda-in-golang/internal/tracing"
 	"eda-in-golang/internal/web"
 )
 
+// the transports the modules may exchange messages over
+const (
+	TransportJetStream = "jetstream"
+	TransportMemStream = "memstream"
+)
+
 type (
 	PGConfig struct {
 		Conn string `required:"true"`
 	}
 
+	// NatsConfig is required by the jetstream transport
 	NatsConfig struct {
-		URL    string `required:"true"`
+		URL    string
 		Stream string `default:"mallbots"`
 	}
 
+	OutboxConfig struct {
+		BatchSize        int           `envconfig:"BATCH_SIZE" default:"10"`
+		PollingInterval  time.Duration `envconfig:"POLLING_INTERVAL" default:"500ms"`
+		Workers          int           `default:"1"`
+		Lease            time.Duration `default:"30s"`
+		OrderedBySubject bool          `envconfig:"ORDERED_BY_SUBJECT" default:"true"`
+	}
+
+	RetentionConfig struct {
+		Interval  time.Duration `default:"1h"`
+		BatchSize int           `envconfig:"BATCH_SIZE" default:"1000"`
+		Outbox    time.Duration `default:"168h"`
+		Inbox     time.Duration `default:"168h"`
+	}
+
 	AppConfig struct {
 		Environment     string
 		LogLevel        string `envconfig:"LOG_LEVEL" default:"DEBUG"`
+		Transport       string `default:"jetstream"`
 		PG              PGConfig
 		Nats            NatsConfig
+		Outbox          OutboxConfig
+		Retention       RetentionConfig
 		Rpc             rpc.RpcConfig
 		Web             web.WebConfig
+		Tracing         tracing.TracingConfig
 		ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"30s"`
 	}
 )
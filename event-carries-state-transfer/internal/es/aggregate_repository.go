@@ -3,27 +3,67 @@ package es
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"github.com/stackus/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"eda-in-golang/internal/ddd"
 	"eda-in-golang/internal/registry"
+	"eda-in-golang/internal/tracing"
 )
 
+const defaultUpdateRetries = 3
+
+const tracerName = "eda-in-golang/internal/es"
+
 // Load() and Save() are the only methods we will use with event-sourced aggregates and their event streams.
 type AggregateRepository[T EventSourcedAggregate] struct {
 	aggregateName string
 	registry      registry.Registry
 	store         AggregateStore
+	updateRetries int
 }
 
-func NewAggregateRepository[T EventSourcedAggregate](aggregateName string, registry registry.Registry, store AggregateStore) AggregateRepository[T] {
+type AggregateRepositoryOption func(cfg *aggregateRepositoryCfg)
+
+type aggregateRepositoryCfg struct {
+	updateRetries int
+}
+
+func NewAggregateRepository[T EventSourcedAggregate](aggregateName string, registry registry.Registry, store AggregateStore, options ...AggregateRepositoryOption) AggregateRepository[T] {
+	cfg := aggregateRepositoryCfg{
+		updateRetries: defaultUpdateRetries,
+	}
+
+	for _, option := range options {
+		option(&cfg)
+	}
+
 	return AggregateRepository[T]{
 		aggregateName: aggregateName,
 		registry:      registry,
 		store:         store,
+		updateRetries: cfg.updateRetries,
+	}
+}
+
+// UpdateRetries sets how many more times Update will try a command after it
+// runs into ErrConcurrencyConflict
+func UpdateRetries(retries int) AggregateRepositoryOption {
+	return func(cfg *aggregateRepositoryCfg) {
+		if retries >= 0 {
+			cfg.updateRetries = retries
+		}
 	}
 }
 
 func (r AggregateRepository[T]) Load(ctx context.Context, aggregateID string) (agg T, err error) {
+	ctx, span := r.startSpan(ctx, "load", aggregateID)
+	defer func() { tracing.End(span, err) }()
+
 	fmt.Println("[Step 6] Repo → Store.Load: building aggregate from registry")
 	var v any
 	// Build the aggregate from the registry
@@ -52,7 +92,37 @@ func (r AggregateRepository[T]) Load(ctx context.Context, aggregateID string) (a
 	return agg, nil
 }
 
-func (r AggregateRepository[T]) Save(ctx context.Context, aggregate T) error {
+// LoadAtVersion loads the aggregate as it was once the event with the given
+// version had been applied; an aggregate that did not exist yet is returned at
+// version 0
+func (r AggregateRepository[T]) LoadAtVersion(ctx context.Context, aggregateID string, version int) (T, error) {
+	if version < 1 {
+		var agg T
+		return agg, errors.ErrInvalidArgument.Msgf("version %d is not a valid aggregate version", version)
+	}
+
+	return r.Load(WithLoadBound(ctx, LoadBound{Version: version}), aggregateID)
+}
+
+// LoadAsOf loads the aggregate as it was at the given time; an aggregate that
+// did not exist yet is returned at version 0
+func (r AggregateRepository[T]) LoadAsOf(ctx context.Context, aggregateID string, asOf time.Time) (T, error) {
+	if asOf.IsZero() {
+		var agg T
+		return agg, errors.ErrInvalidArgument.Msg("a point in time is required")
+	}
+
+	return r.Load(WithLoadBound(ctx, LoadBound{AsOf: asOf}), aggregateID)
+}
+
+func (r AggregateRepository[T]) Save(ctx context.Context, aggregate T) (err error) {
+	ctx, span := r.startSpan(ctx, "save", aggregate.ID())
+	span.SetAttributes(
+		attribute.Int("aggregate.version", aggregate.Version()),
+		attribute.Int("aggregate.pending_version", aggregate.PendingVersion()),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	fmt.Println("[Step 9] Repo.Save: checking for pending events")
 	if aggregate.Version() == aggregate.PendingVersion() {
 		fmt.Println("[Step 10] Repo.Save: no pending events, skipping save")
@@ -67,7 +137,7 @@ func (r AggregateRepository[T]) Save(ctx context.Context, aggregate T) error {
 	for i, event := range events {
 		fmt.Printf("[Step 12.2] Repo → Aggregate: applying event %d/%d (%s)\n", i+1, len(events), event.EventName())
 		// Apply any new events the aggregate has created onto itself.
-		if err := aggregate.ApplyEvent(event); err != nil {
+		if err = aggregate.ApplyEvent(event); err != nil {
 			fmt.Printf("[Step 12.3] Repo → Aggregate: ERROR applying event %d: %v\n", i+1, err)
 			return err
 		}
@@ -76,7 +146,7 @@ func (r AggregateRepository[T]) Save(ctx context.Context, aggregate T) error {
 
 	fmt.Println("[Step 13] Repo → Store.Save: delegating to aggregate store (with middleware)")
 	// Pass the updated aggregate into the store.Save() method so that it can be serialized into the database.
-	err := r.store.Save(ctx, aggregate)
+	err = r.store.Save(ctx, aggregate)
 	if err != nil {
 		fmt.Printf("[Step 13.1] Repo → Store.Save: ERROR from store: %v\n", err)
 		return err
@@ -91,3 +161,33 @@ func (r AggregateRepository[T]) Save(ctx context.Context, aggregate T) error {
 	// Return nil if everything was successful
 	return nil
 }
+
+func (r AggregateRepository[T]) startSpan(ctx context.Context, operation, aggregateID string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, r.aggregateName+" "+operation,
+		trace.WithAttributes(
+			attribute.String("aggregate.name", r.aggregateName),
+			attribute.String("aggregate.id", aggregateID),
+		),
+	)
+}
+
+// Update loads the aggregate, runs the command against it and saves it. When
+// another writer saved the aggregate first, the aggregate is reloaded and the
+// command is run again. The command must only change the aggregate because it
+// may be run more than once.
+func (r AggregateRepository[T]) Update(ctx context.Context, aggregateID string, command func(aggregate T) error) (agg T, err error) {
+	for attempt := 0; ; attempt++ {
+		if agg, err = r.Load(ctx, aggregateID); err != nil {
+			return agg, err
+		}
+
+		if err = command(agg); err != nil {
+			return agg, err
+		}
+
+		err = r.Save(ctx, agg)
+		if err == nil || !errors.Is(err, ErrConcurrencyConflict) || attempt >= r.updateRetries {
+			return agg, err
+		}
+	}
+}
@@ -3,9 +3,15 @@ package es
 import (
 	"context"
 
+	"github.com/stackus/errors"
+
 	"eda-in-golang/internal/ddd"
 )
 
+// ErrConcurrencyConflict is returned by an AggregateStore when the aggregate
+// has been changed by another writer since it was loaded
+var ErrConcurrencyConflict = errors.Wrap(errors.ErrConflict, "the aggregate has been modified by another writer")
+
 type EventSourcedAggregate interface {
 	ddd.IDer
 	AggregateName() string
@@ -0,0 +1,54 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/stackus/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"eda-in-golang/internal/ddd"
+)
+
+// ErrHistoryNotKept is returned by NoHistory
+var ErrHistoryNotKept = errors.Wrap(errors.ErrUnimplemented, "the history of the aggregate is not kept")
+
+type (
+	// HistoryEvent is a stored event decoded for reading; Version is the
+	// version the event brought the aggregate to
+	HistoryEvent struct {
+		ID         string
+		Name       string
+		Version    int
+		OccurredAt time.Time
+		Payload    json.RawMessage
+		Metadata   ddd.Metadata
+	}
+
+	// HistoryReader returns the events of an aggregate in the order they
+	// were stored
+	HistoryReader interface {
+		History(ctx context.Context, aggregateName, aggregateID string) ([]HistoryEvent, error)
+	}
+)
+
+// NoHistory is a HistoryReader for aggregates whose events are not kept
+// anywhere they can be read back from
+type NoHistory struct{}
+
+var _ HistoryReader = NoHistory{}
+
+func (NoHistory) History(context.Context, string, string) ([]HistoryEvent, error) {
+	return nil, ErrHistoryNotKept
+}
+
+// RenderPayload renders a deserialized event payload as JSON
+func RenderPayload(payload ddd.EventPayload) (json.RawMessage, error) {
+	if msg, ok := payload.(proto.Message); ok {
+		return protojson.Marshal(msg)
+	}
+
+	return json.Marshal(payload)
+}
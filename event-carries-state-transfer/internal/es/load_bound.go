@@ -0,0 +1,30 @@
+package es
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// LoadBound limits the events applied to an aggregate as it is loaded.
+	// Aggregate stores skip the events that follow the version, or that
+	// occurred after AsOf, and ignore snapshots they cannot place before the
+	// bound; zero values leave that side of the bound open.
+	LoadBound struct {
+		Version int
+		AsOf    time.Time
+	}
+
+	loadBoundKey struct{}
+)
+
+// WithLoadBound returns a context that loads aggregates up to the bound
+func WithLoadBound(ctx context.Context, bound LoadBound) context.Context {
+	return context.WithValue(ctx, loadBoundKey{}, bound)
+}
+
+// LoadBoundFrom returns the bound set on the context, if any
+func LoadBoundFrom(ctx context.Context) (LoadBound, bool) {
+	bound, ok := ctx.Value(loadBoundKey{}).(LoadBound)
+	return bound, ok && (bound.Version > 0 || !bound.AsOf.IsZero())
+}
@@ -0,0 +1,190 @@
+package es
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stackus/errors"
+
+	"eda-in-golang/internal/ddd"
+)
+
+const defaultProjectorBatchSize = 100
+const defaultProjectorPollingInterval = 500 * time.Millisecond
+
+// ErrCheckpointMoved is returned by a Projection when its checkpoint is no
+// longer where the projector expected; another projector got there first
+var ErrCheckpointMoved = errors.Wrap(errors.ErrConflict, "the projection checkpoint has moved")
+
+type (
+	// StoredEvent is an event read from the global feed of an event store
+	StoredEvent interface {
+		ddd.AggregateEvent
+		Position() int64
+	}
+
+	// EventFeed reads the events of every stream in the order they were stored
+	EventFeed interface {
+		// ReadAll returns up to limit events starting at fromPosition; an
+		// event is not returned before every lower position has either been
+		// stored or abandoned, so a projector never has to wait on a gap
+		ReadAll(ctx context.Context, fromPosition int64, limit int) ([]StoredEvent, error)
+	}
+
+	// Projection is a read model built from the event feed
+	Projection interface {
+		ProjectionName() string
+		// Checkpoint returns the position of the last event projected
+		Checkpoint(ctx context.Context) (int64, error)
+		// Project applies the events and moves the checkpoint to the position
+		// of the last one as a single unit of work; it returns
+		// ErrCheckpointMoved when the checkpoint is not at the expected position
+		Project(ctx context.Context, checkpoint int64, events []StoredEvent) error
+	}
+
+	// Projector feeds the events that follow the checkpoint of a projection
+	// into it; first catching up with the history and then following new
+	// events as they are stored
+	Projector struct {
+		feed            EventFeed
+		projection      Projection
+		batchSize       int
+		pollingInterval time.Duration
+		logger          zerolog.Logger
+	}
+
+	ProjectorOption func(p *Projector)
+
+	// ProjectorProgress reports how far a projector has read
+	ProjectorProgress func(checkpoint int64, projected int)
+)
+
+var _ ddd.EventHandler[ddd.AggregateEvent] = (*Projector)(nil)
+
+func NewProjector(feed EventFeed, projection Projection, logger zerolog.Logger, options ...ProjectorOption) *Projector {
+	p := &Projector{
+		feed:            feed,
+		projection:      projection,
+		batchSize:       defaultProjectorBatchSize,
+		pollingInterval: defaultProjectorPollingInterval,
+		logger:          logger,
+	}
+
+	for _, option := range options {
+		option(p)
+	}
+
+	return p
+}
+
+func ProjectorBatchSize(size int) ProjectorOption {
+	return func(p *Projector) {
+		if size > 0 {
+			p.batchSize = size
+		}
+	}
+}
+
+func ProjectorPollingInterval(interval time.Duration) ProjectorOption {
+	return func(p *Projector) {
+		if interval > 0 {
+			p.pollingInterval = interval
+		}
+	}
+}
+
+// Run catches up and then follows new events until the context is done
+func (p *Projector) Run(ctx context.Context) error {
+	for {
+		projected, caughtUp, err := p.step(ctx)
+		if err != nil && !errors.Is(err, ErrCheckpointMoved) {
+			if ctx.Err() != nil {
+				return nil
+			}
+			p.logger.Error().Err(err).Str("Projection", p.projection.ProjectionName()).Msg("projection failed; retrying")
+		}
+
+		if caughtUp || projected == 0 || err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(p.pollingInterval):
+			}
+		}
+	}
+}
+
+// CatchUp projects every event that has been stored so far and then returns
+func (p *Projector) CatchUp(ctx context.Context, progress ProjectorProgress) error {
+	total := 0
+
+	for {
+		projected, caughtUp, err := p.step(ctx)
+		if err != nil {
+			return err
+		}
+		total += projected
+
+		if progress != nil && projected > 0 {
+			checkpoint, err := p.projection.Checkpoint(ctx)
+			if err != nil {
+				return err
+			}
+			progress(checkpoint, total)
+		}
+
+		if caughtUp || projected == 0 {
+			return nil
+		}
+	}
+}
+
+// Sync projects the events that are available now and returns; when the
+// projection is busy elsewhere it is left to that projector
+func (p *Projector) Sync(ctx context.Context) error {
+	for {
+		projected, caughtUp, err := p.step(ctx)
+		if err != nil {
+			if errors.Is(err, ErrCheckpointMoved) {
+				return nil
+			}
+			return err
+		}
+
+		if caughtUp || projected == 0 {
+			return nil
+		}
+	}
+}
+
+// HandleEvent syncs the projection; subscribing the projector to the events of
+// an aggregate store keeps the projection current as they are saved
+func (p *Projector) HandleEvent(ctx context.Context, _ ddd.AggregateEvent) error {
+	return p.Sync(ctx)
+}
+
+// step projects the next batch; it reports how many events were projected and
+// whether the projection has caught up with the feed. Gaps in the positions are
+// saves that were rolled back and are skipped.
+func (p *Projector) step(ctx context.Context) (int, bool, error) {
+	checkpoint, err := p.projection.Checkpoint(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	events, err := p.feed.ReadAll(ctx, checkpoint+1, p.batchSize)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(events) == 0 {
+		return 0, true, nil
+	}
+
+	if err = p.projection.Project(ctx, checkpoint, events); err != nil {
+		return 0, false, err
+	}
+
+	return len(events), len(events) < p.batchSize, nil
+}
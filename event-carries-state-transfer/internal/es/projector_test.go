@@ -0,0 +1,86 @@
+package es
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"eda-in-golang/internal/ddd"
+)
+
+type testStoredEvent struct {
+	ddd.AggregateEvent
+	position int64
+}
+
+func (e testStoredEvent) Position() int64 { return e.position }
+
+type testFeed []StoredEvent
+
+func (f testFeed) ReadAll(_ context.Context, fromPosition int64, limit int) ([]StoredEvent, error) {
+	var events []StoredEvent
+	for _, event := range f {
+		if event.Position() >= fromPosition && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+type testProjection struct {
+	checkpoint int64
+	projected  []int64
+}
+
+func (p *testProjection) ProjectionName() string { return "test" }
+
+func (p *testProjection) Checkpoint(context.Context) (int64, error) { return p.checkpoint, nil }
+
+func (p *testProjection) Project(_ context.Context, checkpoint int64, events []StoredEvent) error {
+	if checkpoint != p.checkpoint {
+		return ErrCheckpointMoved
+	}
+	for _, event := range events {
+		p.projected = append(p.projected, event.Position())
+	}
+	p.checkpoint = events[len(events)-1].Position()
+	return nil
+}
+
+func TestProjector_CatchUp(t *testing.T) {
+	type testCase struct {
+		positions []int64
+		expected  []int64
+	}
+
+	tests := map[string]testCase{
+		"empty": {
+			positions: nil,
+			expected:  nil,
+		},
+		"several_batches": {
+			positions: []int64{1, 2, 3, 4, 5},
+			expected:  []int64{1, 2, 3, 4, 5},
+		},
+		"gaps_are_skipped": {
+			positions: []int64{1, 3, 4, 7},
+			expected:  []int64{1, 3, 4, 7},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var feed testFeed
+			for _, position := range tc.positions {
+				feed = append(feed, testStoredEvent{position: position})
+			}
+			projection := &testProjection{}
+			projector := NewProjector(feed, projection, zerolog.Nop(), ProjectorBatchSize(2))
+
+			assert.NoError(t, projector.CatchUp(context.Background(), nil))
+			assert.Equal(t, tc.expected, projection.projected)
+		})
+	}
+}
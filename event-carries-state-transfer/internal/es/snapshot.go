@@ -15,6 +15,10 @@ type Snapshotter interface {
 	ToSnapshot() Snapshot
 }
 
+// SnapshotMigration turns an older snapshot into one the aggregate can apply;
+// snapshots it cannot migrate should be returned as they are
+type SnapshotMigration func(snapshot Snapshot) (Snapshot, error)
+
 func LoadSnapshot(v interface{}, snapshot Snapshot, version int) error {
 	type loader interface {
 		SnapshotApplier
@@ -0,0 +1,247 @@
+package es
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eda-in-golang/internal/registry"
+)
+
+type (
+	// SnapshotRow is a snapshot as it is kept by the storage, serialized by the
+	// registry under its snapshot name
+	SnapshotRow struct {
+		Version int
+		Name    string
+		Data    []byte
+		TakenAt time.Time
+	}
+
+	// SnapshotRows gets and puts the stored snapshots; each aggregate has at
+	// most one
+	SnapshotRows interface {
+		// Get returns the snapshot of the aggregate and whether there is one
+		Get(ctx context.Context, aggregateName, aggregateID string) (SnapshotRow, bool, error)
+		// Put replaces the snapshot of the aggregate
+		Put(ctx context.Context, aggregateName, aggregateID string, row SnapshotRow) error
+	}
+
+	// SnapshotStore loads aggregates from their snapshots and takes new ones as
+	// the aggregates are saved; where the snapshots are kept is left to the
+	// SnapshotRows
+	SnapshotStore struct {
+		AggregateStore
+		rows            SnapshotRows
+		registry        registry.Registry
+		defaultStrategy SnapshotStrategy
+		strategies      map[string]SnapshotStrategy
+		migrations      map[string]SnapshotMigration
+		metrics         SnapshotMetrics
+	}
+
+	SnapshotStoreOption func(s *SnapshotStore)
+)
+
+var _ AggregateStore = (*SnapshotStore)(nil)
+
+// low for demonstration; production envs should use higher values 50, 75, 100...
+const defaultSnapshotEvery = 3
+
+func NewSnapshotStore(rows SnapshotRows, registry registry.Registry, options ...SnapshotStoreOption) AggregateStoreMiddleware {
+	snapshots := SnapshotStore{
+		rows:            rows,
+		registry:        registry,
+		defaultStrategy: EveryNEvents(defaultSnapshotEvery),
+		strategies:      make(map[string]SnapshotStrategy),
+		migrations:      make(map[string]SnapshotMigration),
+	}
+
+	for _, option := range options {
+		option(&snapshots)
+	}
+
+	return func(store AggregateStore) AggregateStore {
+		snapshots.AggregateStore = store
+		return snapshots
+	}
+}
+
+// WithSnapshotStrategy sets the strategy used for one kind of aggregate
+func WithSnapshotStrategy(aggregateName string, strategy SnapshotStrategy) SnapshotStoreOption {
+	return func(s *SnapshotStore) {
+		s.strategies[aggregateName] = strategy
+	}
+}
+
+// WithDefaultSnapshotStrategy sets the strategy used for aggregates without
+// one of their own
+func WithDefaultSnapshotStrategy(strategy SnapshotStrategy) SnapshotStoreOption {
+	return func(s *SnapshotStore) {
+		s.defaultStrategy = strategy
+	}
+}
+
+// WithSnapshotMigration sets the migration run on stored snapshots of one kind
+// of aggregate before they are applied
+func WithSnapshotMigration(aggregateName string, migration SnapshotMigration) SnapshotStoreOption {
+	return func(s *SnapshotStore) {
+		s.migrations[aggregateName] = migration
+	}
+}
+
+func WithSnapshotMetrics(metrics SnapshotMetrics) SnapshotStoreOption {
+	return func(s *SnapshotStore) {
+		s.metrics = metrics
+	}
+}
+
+// Load applies the stored snapshot before loading the events that followed
+// it. A snapshot that cannot be deserialized, migrated or applied is ignored;
+// the full stream is replayed instead and a current snapshot is written.
+// Loads limited by a LoadBound only use a snapshot taken at or before the
+// bound on the version, and never write snapshots.
+func (s SnapshotStore) Load(ctx context.Context, aggregate EventSourcedAggregate) error {
+	row, exists, err := s.rows.Get(ctx, aggregate.AggregateName(), aggregate.ID())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return s.fullReplay(ctx, aggregate)
+	}
+
+	// the time a snapshot stands for is not kept; it can only be placed before
+	// a bound on the version
+	bound, bounded := LoadBoundFrom(ctx)
+	if bounded && (!bound.AsOf.IsZero() || row.Version > bound.Version) {
+		return s.fullReplay(ctx, aggregate)
+	}
+
+	snapshot, migrated, ok := s.loadSnapshot(aggregate, row)
+	if !ok {
+		if err = s.fullReplay(ctx, aggregate); err != nil {
+			return err
+		}
+		if aggregate.Version() == 0 || bounded {
+			return nil
+		}
+		return s.saveSnapshot(ctx, aggregate)
+	}
+
+	if s.metrics != nil {
+		s.metrics.SnapshotHit(aggregate.AggregateName())
+	}
+
+	if err = s.AggregateStore.Load(ctx, aggregate); err != nil {
+		return err
+	}
+
+	// replace the old snapshot so the migration is not repeated on every load
+	if migrated && snapshot.SnapshotName() != row.Name && !bounded {
+		return s.saveSnapshot(ctx, aggregate)
+	}
+
+	return nil
+}
+
+// loadSnapshot applies the snapshot to the aggregate, migrating it first when a
+// migration exists; it reports whether a snapshot was applied
+func (s SnapshotStore) loadSnapshot(aggregate EventSourcedAggregate, row SnapshotRow) (Snapshot, bool, bool) {
+	v, err := s.registry.Deserialize(row.Name, row.Data, registry.ValidateImplements((*Snapshot)(nil)))
+	if err != nil {
+		return nil, false, false
+	}
+	snapshot := v.(Snapshot)
+
+	migrated := false
+	if migrate, exists := s.migrations[aggregate.AggregateName()]; exists {
+		if snapshot, err = migrate(snapshot); err != nil {
+			return nil, false, false
+		}
+		migrated = true
+	}
+
+	// ApplySnapshot rejects the snapshot types it does not know before making
+	// any changes to the aggregate
+	if err = LoadSnapshot(aggregate, snapshot, row.Version); err != nil {
+		return nil, false, false
+	}
+
+	return snapshot, migrated, true
+}
+
+func (s SnapshotStore) Save(ctx context.Context, aggregate EventSourcedAggregate) error {
+	if err := s.AggregateStore.Save(ctx, aggregate); err != nil {
+		return err
+	}
+
+	strategy := s.strategyFor(aggregate)
+
+	// most strategies decide from the aggregate alone; the last snapshot is
+	// only read for the ones that look at it
+	var last SnapshotInfo
+	if needsLastSnapshot(strategy) {
+		row, _, err := s.rows.Get(ctx, aggregate.AggregateName(), aggregate.ID())
+		if err != nil {
+			return err
+		}
+		last = SnapshotInfo{Version: row.Version, TakenAt: row.TakenAt}
+	}
+
+	if !strategy.ShouldSnapshot(aggregate, last) {
+		return nil
+	}
+
+	return s.saveSnapshot(ctx, aggregate)
+}
+
+func (s SnapshotStore) saveSnapshot(ctx context.Context, aggregate EventSourcedAggregate) error {
+	sser, ok := aggregate.(Snapshotter)
+	if !ok {
+		return fmt.Errorf("%T does not implelement es.Snapshotter", aggregate)
+	}
+
+	snapshot := sser.ToSnapshot()
+
+	data, err := s.registry.Serialize(snapshot.SnapshotName(), snapshot)
+	if err != nil {
+		return err
+	}
+
+	if err = s.rows.Put(ctx, aggregate.AggregateName(), aggregate.ID(), SnapshotRow{
+		Version: aggregate.PendingVersion(),
+		Name:    snapshot.SnapshotName(),
+		Data:    data,
+		TakenAt: time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	if s.metrics != nil {
+		s.metrics.SnapshotTaken(aggregate.AggregateName())
+	}
+
+	return nil
+}
+
+func (s SnapshotStore) strategyFor(aggregate EventSourcedAggregate) SnapshotStrategy {
+	if strategy, exists := s.strategies[aggregate.AggregateName()]; exists {
+		return strategy
+	}
+
+	return s.defaultStrategy
+}
+
+// fullReplay loads every event of the aggregate; loading an aggregate that has
+// no events yet is not counted as a replay
+func (s SnapshotStore) fullReplay(ctx context.Context, aggregate EventSourcedAggregate) error {
+	if err := s.AggregateStore.Load(ctx, aggregate); err != nil {
+		return err
+	}
+
+	if s.metrics != nil && aggregate.Version() > 0 {
+		s.metrics.FullReplay(aggregate.AggregateName())
+	}
+
+	return nil
+}
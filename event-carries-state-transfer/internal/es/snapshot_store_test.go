@@ -0,0 +1,90 @@
+package es
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/registry"
+)
+
+// testSnapshotRows has at most the one snapshot and counts how often it is read
+type testSnapshotRows struct {
+	row  *SnapshotRow
+	gets int
+}
+
+func (r *testSnapshotRows) Get(context.Context, string, string) (SnapshotRow, bool, error) {
+	r.gets++
+	if r.row == nil {
+		return SnapshotRow{}, false, nil
+	}
+	return *r.row, true, nil
+}
+
+func (r *testSnapshotRows) Put(_ context.Context, _, _ string, row SnapshotRow) error {
+	r.row = &row
+	return nil
+}
+
+// testAggregateStore loads every aggregate at the same version
+type testAggregateStore struct {
+	version int
+}
+
+func (s testAggregateStore) Load(_ context.Context, aggregate EventSourcedAggregate) error {
+	aggregate.(*testAggregate).setVersion(s.version)
+	return nil
+}
+
+func (testAggregateStore) Save(context.Context, EventSourcedAggregate) error { return nil }
+
+func TestSnapshotStore_Save(t *testing.T) {
+	never := SnapshotStrategyFunc(func(EventSourcedAggregate, SnapshotInfo) bool { return false })
+
+	tests := map[string]struct {
+		strategy SnapshotStrategy
+		reads    int
+	}{
+		"EveryNEvents":          {strategy: EveryNEvents(3), reads: 0},
+		"OnEvents":              {strategy: OnEvents("test.Closed"), reads: 0},
+		"AnyOfTheAggregate":     {strategy: AnyOf(EveryNEvents(3), OnEvents("test.Closed")), reads: 0},
+		"TimeSinceLastSnapshot": {strategy: TimeSinceLastSnapshot(time.Hour), reads: 1},
+		"AnyOfTheLastSnapshot":  {strategy: AnyOf(EveryNEvents(3), TimeSinceLastSnapshot(time.Hour)), reads: 1},
+		"StrategyFunc":          {strategy: never, reads: 1},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// a recent snapshot that none of the strategies would replace
+			rows := &testSnapshotRows{row: &SnapshotRow{Version: 3, TakenAt: time.Now()}}
+			store := AggregateStoreWithMiddleware(testAggregateStore{}, NewSnapshotStore(rows, registry.New(), WithDefaultSnapshotStrategy(tc.strategy)))
+
+			require.NoError(t, store.Save(context.Background(), newTestAggregate(3, pending(1)...)))
+			assert.Equal(t, tc.reads, rows.gets)
+		})
+	}
+}
+
+func TestSnapshotStore_Load(t *testing.T) {
+	tests := map[string]struct {
+		version     int
+		fullReplays int64
+	}{
+		"NoEventsYet": {version: 0, fullReplays: 0},
+		"NoSnapshot":  {version: 2, fullReplays: 1},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			metrics := NewSnapshotCounters()
+			store := AggregateStoreWithMiddleware(testAggregateStore{version: tc.version}, NewSnapshotStore(&testSnapshotRows{}, registry.New(), WithSnapshotMetrics(metrics)))
+
+			aggregate := newTestAggregate(0)
+			require.NoError(t, store.Load(context.Background(), aggregate))
+			assert.Equal(t, tc.version, aggregate.Version())
+			assert.Equal(t, tc.fullReplays, metrics.Counts("test.Aggregate").FullReplays)
+		})
+	}
+}
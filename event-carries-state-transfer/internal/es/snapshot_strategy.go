@@ -0,0 +1,166 @@
+package es
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// SnapshotStrategy decides whether a snapshot should be taken as an
+	// aggregate is saved; last is the zero value when no snapshot exists yet
+	SnapshotStrategy interface {
+		ShouldSnapshot(aggregate EventSourcedAggregate, last SnapshotInfo) bool
+	}
+
+	SnapshotStrategyFunc func(aggregate EventSourcedAggregate, last SnapshotInfo) bool
+
+	// aggregateStrategy decides from the aggregate alone; the last snapshot
+	// does not need to be read for it
+	aggregateStrategy func(aggregate EventSourcedAggregate) bool
+
+	anyOf []SnapshotStrategy
+
+	// SnapshotInfo describes the most recent snapshot of an aggregate
+	SnapshotInfo struct {
+		Version int
+		TakenAt time.Time
+	}
+
+	// SnapshotMetrics is told how each aggregate was loaded and when snapshots
+	// were taken
+	SnapshotMetrics interface {
+		SnapshotHit(aggregateName string)
+		FullReplay(aggregateName string)
+		SnapshotTaken(aggregateName string)
+	}
+
+	SnapshotCounts struct {
+		Hits        int64
+		FullReplays int64
+		Taken       int64
+	}
+
+	// SnapshotCounters is an in-memory SnapshotMetrics
+	SnapshotCounters struct {
+		counts map[string]SnapshotCounts
+		mu     sync.Mutex
+	}
+)
+
+var _ SnapshotMetrics = (*SnapshotCounters)(nil)
+
+func (f SnapshotStrategyFunc) ShouldSnapshot(aggregate EventSourcedAggregate, last SnapshotInfo) bool {
+	return f(aggregate, last)
+}
+
+func (f aggregateStrategy) ShouldSnapshot(aggregate EventSourcedAggregate, _ SnapshotInfo) bool {
+	return f(aggregate)
+}
+
+func (s anyOf) ShouldSnapshot(aggregate EventSourcedAggregate, last SnapshotInfo) bool {
+	for _, strategy := range s {
+		if strategy.ShouldSnapshot(aggregate, last) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsLastSnapshot reports whether the strategy may look at the last
+// snapshot; strategies from outside this package are assumed to
+func needsLastSnapshot(strategy SnapshotStrategy) bool {
+	switch s := strategy.(type) {
+	case aggregateStrategy:
+		return false
+	case anyOf:
+		for _, strategy := range s {
+			if needsLastSnapshot(strategy) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// EveryNEvents takes a snapshot each time the aggregate version reaches or
+// passes a multiple of n
+func EveryNEvents(n int) SnapshotStrategy {
+	return aggregateStrategy(func(aggregate EventSourcedAggregate) bool {
+		if n <= 0 {
+			return false
+		}
+
+		pendingVersion := aggregate.PendingVersion()
+		pendingChanges := len(aggregate.Events())
+
+		return pendingVersion >= n && ((pendingChanges >= n) ||
+			(pendingVersion%n < pendingChanges) ||
+			(pendingVersion%n == 0))
+	})
+}
+
+// TimeSinceLastSnapshot takes a snapshot when the last one is older than the
+// given duration, or when there is no snapshot yet
+func TimeSinceLastSnapshot(d time.Duration) SnapshotStrategy {
+	return SnapshotStrategyFunc(func(_ EventSourcedAggregate, last SnapshotInfo) bool {
+		return last.TakenAt.IsZero() || time.Since(last.TakenAt) >= d
+	})
+}
+
+// OnEvents takes a snapshot when any of the named events is being saved
+func OnEvents(eventNames ...string) SnapshotStrategy {
+	names := make(map[string]struct{}, len(eventNames))
+	for _, name := range eventNames {
+		names[name] = struct{}{}
+	}
+
+	return aggregateStrategy(func(aggregate EventSourcedAggregate) bool {
+		for _, event := range aggregate.Events() {
+			if _, exists := names[event.EventName()]; exists {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// AnyOf takes a snapshot when any one of the strategies would
+func AnyOf(strategies ...SnapshotStrategy) SnapshotStrategy {
+	return anyOf(strategies)
+}
+
+func NewSnapshotCounters() *SnapshotCounters {
+	return &SnapshotCounters{
+		counts: make(map[string]SnapshotCounts),
+	}
+}
+
+func (c *SnapshotCounters) SnapshotHit(aggregateName string) {
+	c.update(aggregateName, func(counts *SnapshotCounts) { counts.Hits++ })
+}
+
+func (c *SnapshotCounters) FullReplay(aggregateName string) {
+	c.update(aggregateName, func(counts *SnapshotCounts) { counts.FullReplays++ })
+}
+
+func (c *SnapshotCounters) SnapshotTaken(aggregateName string) {
+	c.update(aggregateName, func(counts *SnapshotCounts) { counts.Taken++ })
+}
+
+func (c *SnapshotCounters) Counts(aggregateName string) SnapshotCounts {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.counts[aggregateName]
+}
+
+func (c *SnapshotCounters) update(aggregateName string, fn func(counts *SnapshotCounts)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := c.counts[aggregateName]
+	fn(&counts)
+	c.counts[aggregateName] = counts
+}
@@ -0,0 +1,119 @@
+package es
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"eda-in-golang/internal/ddd"
+)
+
+type testAggregate struct {
+	Aggregate
+}
+
+func (*testAggregate) ApplyEvent(ddd.Event) error { return nil }
+
+type testPayload struct{}
+
+// newTestAggregate returns an aggregate at the version with the named events
+// waiting to be saved
+func newTestAggregate(version int, eventNames ...string) *testAggregate {
+	aggregate := &testAggregate{Aggregate: NewAggregate("aggregate-id", "test.Aggregate")}
+	aggregate.setVersion(version)
+	for _, name := range eventNames {
+		aggregate.AddEvent(name, testPayload{})
+	}
+	return aggregate
+}
+
+func pending(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = "test.Changed"
+	}
+	return names
+}
+
+func TestEveryNEvents(t *testing.T) {
+	tests := map[string]struct {
+		n       int
+		version int
+		pending int
+		want    bool
+	}{
+		"below n":              {n: 5, version: 0, pending: 4, want: false},
+		"reaches n":            {n: 5, version: 0, pending: 5, want: true},
+		"reaches a multiple":   {n: 5, version: 8, pending: 2, want: true},
+		"passes a multiple":    {n: 5, version: 9, pending: 2, want: true},
+		"between multiples":    {n: 5, version: 11, pending: 2, want: false},
+		"more than n pending":  {n: 5, version: 3, pending: 7, want: true},
+		"n is zero":            {n: 0, version: 0, pending: 10, want: false},
+		"n is negative":        {n: -1, version: 0, pending: 10, want: false},
+		"multiple of one":      {n: 1, version: 4, pending: 1, want: true},
+		"just after multiple":  {n: 5, version: 10, pending: 1, want: false},
+		"lands past two of n":  {n: 2, version: 1, pending: 4, want: true},
+		"first event of batch": {n: 3, version: 0, pending: 1, want: false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			aggregate := newTestAggregate(tc.version, pending(tc.pending)...)
+			assert.Equal(t, tc.want, EveryNEvents(tc.n).ShouldSnapshot(aggregate, SnapshotInfo{}))
+		})
+	}
+}
+
+func TestTimeSinceLastSnapshot(t *testing.T) {
+	strategy := TimeSinceLastSnapshot(time.Hour)
+	aggregate := newTestAggregate(1, pending(1)...)
+
+	assert.True(t, strategy.ShouldSnapshot(aggregate, SnapshotInfo{}), "no snapshot yet")
+	assert.True(t, strategy.ShouldSnapshot(aggregate, SnapshotInfo{Version: 1, TakenAt: time.Now().Add(-2 * time.Hour)}))
+	assert.False(t, strategy.ShouldSnapshot(aggregate, SnapshotInfo{Version: 1, TakenAt: time.Now().Add(-time.Minute)}))
+}
+
+func TestOnEvents(t *testing.T) {
+	strategy := OnEvents("test.Closed", "test.Archived")
+
+	assert.True(t, strategy.ShouldSnapshot(newTestAggregate(3, "test.Changed", "test.Closed"), SnapshotInfo{}))
+	assert.True(t, strategy.ShouldSnapshot(newTestAggregate(3, "test.Archived"), SnapshotInfo{}))
+	assert.False(t, strategy.ShouldSnapshot(newTestAggregate(3, "test.Changed"), SnapshotInfo{}))
+	assert.False(t, strategy.ShouldSnapshot(newTestAggregate(3), SnapshotInfo{}))
+	assert.False(t, OnEvents().ShouldSnapshot(newTestAggregate(3, "test.Closed"), SnapshotInfo{}))
+}
+
+func TestAnyOf(t *testing.T) {
+	never := SnapshotStrategyFunc(func(EventSourcedAggregate, SnapshotInfo) bool { return false })
+	always := SnapshotStrategyFunc(func(EventSourcedAggregate, SnapshotInfo) bool { return true })
+	aggregate := newTestAggregate(1, pending(1)...)
+
+	tests := map[string]struct {
+		strategies []SnapshotStrategy
+		want       bool
+	}{
+		"none":       {strategies: nil, want: false},
+		"never":      {strategies: []SnapshotStrategy{never, never}, want: false},
+		"one agrees": {strategies: []SnapshotStrategy{never, always}, want: true},
+		"all agree":  {strategies: []SnapshotStrategy{always, always}, want: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, AnyOf(tc.strategies...).ShouldSnapshot(aggregate, SnapshotInfo{}))
+		})
+	}
+
+	t.Run("passes the last snapshot along", func(t *testing.T) {
+		last := SnapshotInfo{Version: 4, TakenAt: time.Now()}
+		var seen []string
+		record := func(name string) SnapshotStrategy {
+			return SnapshotStrategyFunc(func(_ EventSourcedAggregate, info SnapshotInfo) bool {
+				seen = append(seen, fmt.Sprintf("%s:%d", name, info.Version))
+				return false
+			})
+		}
+		AnyOf(record("a"), record("b")).ShouldSnapshot(aggregate, last)
+		assert.Equal(t, []string{"a:4", "b:4"}, seen)
+	})
+}
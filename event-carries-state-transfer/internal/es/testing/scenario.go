@@ -0,0 +1,180 @@
+// Package testing runs Given/When/Then scenarios against event sourced
+// aggregates:
+//
+//	estesting.Given(func() *domain.Order { return domain.NewOrder("order-id") },
+//		&domain.OrderCreated{CustomerID: "customer-id", PaymentID: "payment-id", Items: items},
+//	).When(func(order *domain.Order) error {
+//		_, err := order.Cancel()
+//		return err
+//	}).Then(t, &domain.OrderCanceled{CustomerID: "customer-id", PaymentID: "payment-id"})
+package testing
+
+import (
+	"fmt"
+
+	"github.com/stretchr/testify/assert"
+
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/es"
+)
+
+type (
+	// T is the part of testing.TB that scenarios report to
+	T interface {
+		Helper()
+		Errorf(format string, args ...any)
+		FailNow()
+	}
+
+	Scenario[A es.EventSourcedAggregate] struct {
+		newAggregate func() A
+		aggregate    A
+		givenErr     error
+		whenErr      error
+	}
+
+	// emittedEvent is compared in place of the events so that differences are
+	// shown by name and payload only
+	emittedEvent struct {
+		Name    string
+		Payload ddd.EventPayload
+	}
+)
+
+// Given builds the aggregate and loads the events that happened to it before
+// the scenario; the event names are taken from the Key of each payload
+func Given[A es.EventSourcedAggregate](newAggregate func() A, events ...ddd.EventPayload) *Scenario[A] {
+	s := &Scenario[A]{
+		newAggregate: newAggregate,
+		aggregate:    newAggregate(),
+	}
+
+	for _, payload := range events {
+		name, err := eventName(payload)
+		if err != nil {
+			s.givenErr = err
+			return s
+		}
+		s.aggregate.AddEvent(name, payload)
+	}
+
+	given := s.aggregate.Events()
+	s.aggregate.ClearEvents()
+	for _, event := range given {
+		if err := es.LoadEvent(s.aggregate, event); err != nil {
+			s.givenErr = fmt.Errorf("loading the given event %s: %w", event.EventName(), err)
+			return s
+		}
+	}
+
+	return s
+}
+
+// When runs the command against the aggregate
+func (s *Scenario[A]) When(command func(aggregate A) error) *Scenario[A] {
+	if s.givenErr == nil {
+		s.whenErr = command(s.aggregate)
+	}
+
+	return s
+}
+
+// Then expects the command to succeed and emit exactly the events given. The
+// emitted events are then applied and, for aggregates that take snapshots,
+// the snapshot of the result must survive a round-trip.
+func (s *Scenario[A]) Then(t T, events ...ddd.EventPayload) {
+	t.Helper()
+
+	s.requireGiven(t)
+
+	if !assert.NoError(t, s.whenErr, "the command failed") {
+		t.FailNow()
+	}
+
+	expected := make([]emittedEvent, len(events))
+	for i, payload := range events {
+		name, err := eventName(payload)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		expected[i] = emittedEvent{Name: name, Payload: payload}
+	}
+
+	if !assert.Equal(t, expected, s.emitted(), "the command emitted other events") {
+		t.FailNow()
+	}
+
+	for _, event := range s.aggregate.Events() {
+		if !assert.NoError(t, s.aggregate.ApplyEvent(event), "applying the emitted event %s", event.EventName()) {
+			t.FailNow()
+		}
+	}
+	s.aggregate.CommitEvents()
+
+	s.checkSnapshot(t)
+}
+
+// ThenError expects the command to fail with the error, or one that wraps it,
+// without emitting any events
+func (s *Scenario[A]) ThenError(t T, err error) {
+	t.Helper()
+
+	s.requireGiven(t)
+
+	if !assert.ErrorIs(t, s.whenErr, err) {
+		t.FailNow()
+	}
+
+	if !assert.Empty(t, s.emitted(), "the failed command emitted events") {
+		t.FailNow()
+	}
+}
+
+func (s *Scenario[A]) requireGiven(t T) {
+	t.Helper()
+
+	if !assert.NoError(t, s.givenErr, "the given events could not be loaded") {
+		t.FailNow()
+	}
+}
+
+func (s *Scenario[A]) emitted() []emittedEvent {
+	events := make([]emittedEvent, len(s.aggregate.Events()))
+	for i, event := range s.aggregate.Events() {
+		events[i] = emittedEvent{Name: event.EventName(), Payload: event.Payload()}
+	}
+
+	return events
+}
+
+// checkSnapshot applies the snapshot of the aggregate to a new one and
+// expects it to produce the same snapshot
+func (s *Scenario[A]) checkSnapshot(t T) {
+	t.Helper()
+
+	snapshotter, ok := any(s.aggregate).(es.Snapshotter)
+	if !ok {
+		return
+	}
+
+	snapshot := snapshotter.ToSnapshot()
+	restored := s.newAggregate()
+	if !assert.NoError(t, es.LoadSnapshot(restored, snapshot, s.aggregate.Version()), "applying the snapshot") {
+		t.FailNow()
+	}
+
+	roundTrip := any(restored).(es.Snapshotter).ToSnapshot()
+	if !assert.Equal(t, snapshot, roundTrip, "the snapshot did not survive a round-trip") {
+		t.FailNow()
+	}
+	assert.Equal(t, s.aggregate.Version(), restored.Version(), "the snapshot version was not restored")
+}
+
+func eventName(payload ddd.EventPayload) (string, error) {
+	keyer, ok := payload.(interface{ Key() string })
+	if !ok {
+		return "", fmt.Errorf("the event payload %T has no Key to name it with", payload)
+	}
+
+	return keyer.Key(), nil
+}
@@ -0,0 +1,94 @@
+package es
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+type (
+	// UpcastFunc transforms a serialized event payload from one schema version
+	// into the next
+	UpcastFunc func(data []byte) ([]byte, error)
+
+	// Upcasters hold the chains of upcasts for each event. An event with no
+	// upcasts is at version 1; every registered upcast adds one version.
+	Upcasters struct {
+		steps map[string][]UpcastFunc
+		mu    sync.RWMutex
+	}
+)
+
+func NewUpcasters() *Upcasters {
+	return &Upcasters{
+		steps: make(map[string][]UpcastFunc),
+	}
+}
+
+// Register adds the upcast of an event payload from version fromVersion to
+// fromVersion+1; upcasts must be registered in order starting with version 1
+func (u *Upcasters) Register(eventName string, fromVersion int, fn UpcastFunc) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if expected := len(u.steps[eventName]) + 1; fromVersion != expected {
+		return fmt.Errorf("upcast for %s from version %d registered out of order; expected version %d", eventName, fromVersion, expected)
+	}
+
+	u.steps[eventName] = append(u.steps[eventName], fn)
+
+	return nil
+}
+
+// Version returns the current schema version of an event
+func (u *Upcasters) Version(eventName string) int {
+	if u == nil {
+		return 1
+	}
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	return len(u.steps[eventName]) + 1
+}
+
+// Upcast brings a payload stored at the given version up to the current version
+func (u *Upcasters) Upcast(eventName string, version int, data []byte) ([]byte, error) {
+	if u == nil {
+		return data, nil
+	}
+
+	u.mu.RLock()
+	steps := u.steps[eventName]
+	u.mu.RUnlock()
+
+	if version < 1 || version > len(steps)+1 {
+		return nil, fmt.Errorf("%s has no upcast from version %d", eventName, version)
+	}
+
+	var err error
+	for _, step := range steps[version-1:] {
+		if data, err = step(data); err != nil {
+			return nil, fmt.Errorf("upcasting %s: %w", eventName, err)
+		}
+	}
+
+	return data, nil
+}
+
+// UpcastJSON adapts a function that edits a JSON payload as a generic map,
+// e.g. to rename or move fields, into an UpcastFunc
+func UpcastJSON(fn func(payload map[string]any) error) UpcastFunc {
+	return func(data []byte) ([]byte, error) {
+		payload := make(map[string]any)
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, err
+		}
+
+		if err := fn(payload); err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(payload)
+	}
+}
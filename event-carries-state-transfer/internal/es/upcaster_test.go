@@ -0,0 +1,70 @@
+package es
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpcasters_Upcast(t *testing.T) {
+	upcasters := NewUpcasters()
+	assert.NoError(t, upcasters.Register("OrderCreated", 1, UpcastJSON(func(payload map[string]any) error {
+		payload["CustomerID"] = payload["Customer"]
+		delete(payload, "Customer")
+		return nil
+	})))
+	assert.NoError(t, upcasters.Register("OrderCreated", 2, UpcastJSON(func(payload map[string]any) error {
+		payload["Currency"] = "USD"
+		return nil
+	})))
+
+	type args struct {
+		name    string
+		version int
+		data    string
+	}
+	type testCase struct {
+		args     args
+		expected string
+		wantErr  bool
+	}
+
+	tests := map[string]testCase{
+		"from_version_1": {
+			args:     args{name: "OrderCreated", version: 1, data: `{"Customer":"c-1"}`},
+			expected: `{"Currency":"USD","CustomerID":"c-1"}`,
+		},
+		"from_version_2": {
+			args:     args{name: "OrderCreated", version: 2, data: `{"CustomerID":"c-1"}`},
+			expected: `{"Currency":"USD","CustomerID":"c-1"}`,
+		},
+		"current_version": {
+			args:     args{name: "OrderCreated", version: 3, data: `{"CustomerID":"c-1","Currency":"EUR"}`},
+			expected: `{"CustomerID":"c-1","Currency":"EUR"}`,
+		},
+		"no_upcasts": {
+			args:     args{name: "OrderCompleted", version: 1, data: `{"InvoiceID":"i-1"}`},
+			expected: `{"InvoiceID":"i-1"}`,
+		},
+		"future_version": {
+			args:    args{name: "OrderCreated", version: 4, data: `{}`},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := upcasters.Upcast(tc.args.name, tc.args.version, []byte(tc.args.data))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(data))
+		})
+	}
+
+	assert.Equal(t, 3, upcasters.Version("OrderCreated"))
+	assert.Equal(t, 1, upcasters.Version("OrderCompleted"))
+	assert.Error(t, upcasters.Register("OrderCreated", 1, UpcastJSON(func(map[string]any) error { return nil })))
+}
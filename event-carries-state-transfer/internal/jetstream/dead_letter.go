@@ -0,0 +1,219 @@
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+
+	"eda-in-golang/internal/am"
+)
+
+const deadLetterFetchWait = time.Second
+
+type deadLetterMessage struct {
+	id         string
+	name       string
+	subject    string
+	data       []byte
+	raw        []byte
+	reason     string
+	deliveries int
+	groupName  string
+	deadAt     time.Time
+	sequence   uint64
+}
+
+var _ am.DeadLetterMessage = (*deadLetterMessage)(nil)
+
+var _ am.DeadLetterQueue = (*Stream)(nil)
+
+func (m deadLetterMessage) ID() string          { return m.id }
+func (m deadLetterMessage) MessageName() string { return m.name }
+func (m deadLetterMessage) Subject() string     { return m.subject }
+func (m deadLetterMessage) Data() []byte        { return m.data }
+func (m deadLetterMessage) Reason() string      { return m.reason }
+func (m deadLetterMessage) Deliveries() int     { return m.deliveries }
+func (m deadLetterMessage) GroupName() string   { return m.groupName }
+func (m deadLetterMessage) DeadAt() time.Time   { return m.deadAt }
+
+// DeadLetters returns up to limit messages waiting on the dead-letter subject;
+// a limit of zero returns them all
+func (s *Stream) DeadLetters(ctx context.Context, subject string, limit int) ([]am.DeadLetterMessage, error) {
+	var msgs []am.DeadLetterMessage
+
+	err := s.scanDeadLetters(ctx, subject, func(msg deadLetterMessage) bool {
+		msgs = append(msgs, msg)
+		return limit <= 0 || len(msgs) < limit
+	})
+
+	return msgs, err
+}
+
+// Replay republishes the dead-lettered messages onto their original subjects
+// and removes them from the dead-letter subject; with no ids every message is
+// replayed. A replayed message is handled only by the group it failed in.
+func (s *Stream) Replay(ctx context.Context, subject string, ids ...string) error {
+	var filter map[string]struct{}
+	if len(ids) > 0 {
+		filter = make(map[string]struct{}, len(ids))
+		for _, id := range ids {
+			filter[id] = struct{}{}
+		}
+	}
+
+	var replays []deadLetterMessage
+	err := s.scanDeadLetters(ctx, subject, func(msg deadLetterMessage) bool {
+		if filter != nil {
+			if _, exists := filter[msg.id]; !exists {
+				return true
+			}
+		}
+		replays = append(replays, msg)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range replays {
+		// the original message id is not used; the duplicate window could
+		// otherwise swallow the replay
+		natsMsg := nats.NewMsg(msg.subject)
+		natsMsg.Data = msg.raw
+		if msg.groupName != "" {
+			natsMsg.Header.Set(am.ReplayGroupHeader, msg.groupName)
+		}
+		if _, err = s.js.PublishMsg(natsMsg, nats.Context(ctx)); err != nil {
+			return err
+		}
+
+		if err = s.js.DeleteMsg(s.streamName, msg.sequence, nats.Context(ctx)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deadLetter republishes the original stream message to the dead-letter
+// subject along with details about why it failed
+func (s *Stream) deadLetter(cfg am.SubscriberConfig, natsMsg *nats.Msg, reason error, deliveries uint64) error {
+	msg := nats.NewMsg(cfg.DeadLetter())
+	msg.Data = natsMsg.Data
+	msg.Header.Set(am.DeadLetterSubjectHeader, natsMsg.Subject)
+	msg.Header.Set(am.DeadLetterErrorHeader, reason.Error())
+	msg.Header.Set(am.DeadLetterDeliveriesHeader, strconv.FormatUint(deliveries, 10))
+	msg.Header.Set(am.DeadLetterGroupHeader, cfg.GroupName())
+
+	_, err := s.js.PublishMsg(msg)
+
+	return err
+}
+
+// replayedForOthers reports whether the message is a dead letter replayed for
+// another group
+func replayedForOthers(cfg am.SubscriberConfig, natsMsg *nats.Msg) bool {
+	groupName := natsMsg.Header.Get(am.ReplayGroupHeader)
+
+	return groupName != "" && groupName != cfg.GroupName()
+}
+
+// checkDeadLetter makes sure that the messages published to the dead-letter
+// subject are kept by the stream; they would otherwise be lost
+func (s *Stream) checkDeadLetter(subject string) error {
+	if strings.ContainsAny(subject, "*>") {
+		return fmt.Errorf("the dead-letter subject %q cannot contain wildcards", subject)
+	}
+
+	info, err := s.js.StreamInfo(s.streamName)
+	if err != nil {
+		return err
+	}
+
+	for _, pattern := range info.Config.Subjects {
+		if subjectMatches(pattern, subject) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the dead-letter subject %q is not one of the subjects of the stream %q", subject, s.streamName)
+}
+
+// subjectMatches reports whether a subject matches a NATS subject pattern; "*"
+// matches a single token and ">" matches one or more trailing tokens
+func subjectMatches(pattern, subject string) bool {
+	pTokens := strings.Split(pattern, ".")
+	sTokens := strings.Split(subject, ".")
+
+	for i, pToken := range pTokens {
+		if pToken == ">" {
+			return len(sTokens) > i
+		}
+		if i >= len(sTokens) {
+			return false
+		}
+		if pToken != "*" && pToken != sTokens[i] {
+			return false
+		}
+	}
+
+	return len(pTokens) == len(sTokens)
+}
+
+func (s *Stream) scanDeadLetters(ctx context.Context, subject string, fn func(msg deadLetterMessage) bool) error {
+	sub, err := s.js.SubscribeSync(subject, nats.OrderedConsumer(), nats.BindStream(s.streamName))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = sub.Unsubscribe()
+	}()
+
+	for {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		var natsMsg *nats.Msg
+		natsMsg, err = sub.NextMsg(deadLetterFetchWait)
+		if err != nil {
+			if err == nats.ErrTimeout {
+				return nil
+			}
+			return err
+		}
+
+		var md *nats.MsgMetadata
+		md, err = natsMsg.Metadata()
+		if err != nil {
+			return err
+		}
+
+		m := &StreamMessage{}
+		if err = proto.Unmarshal(natsMsg.Data, m); err != nil {
+			return err
+		}
+
+		deliveries, _ := strconv.Atoi(natsMsg.Header.Get(am.DeadLetterDeliveriesHeader))
+
+		if !fn(deadLetterMessage{
+			id:         m.GetId(),
+			name:       m.GetName(),
+			subject:    natsMsg.Header.Get(am.DeadLetterSubjectHeader),
+			data:       m.GetData(),
+			raw:        natsMsg.Data,
+			reason:     natsMsg.Header.Get(am.DeadLetterErrorHeader),
+			deliveries: deliveries,
+			groupName:  natsMsg.Header.Get(am.DeadLetterGroupHeader),
+			deadAt:     md.Timestamp,
+			sequence:   md.Sequence.Stream,
+		}) || md.NumPending == 0 {
+			return nil
+		}
+	}
+}
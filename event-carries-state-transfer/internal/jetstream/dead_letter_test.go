@@ -0,0 +1,169 @@
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/am"
+)
+
+const testDeadLetter = "mallbots.dead-letters"
+
+func TestStream_DeadLetters(t *testing.T) {
+	stream, _ := newTestStream(t)
+
+	var mu sync.Mutex
+	failing := true
+	handled := make(chan string, 10)
+	err := stream.Subscribe("mallbots.orders", am.RawMessageHandlerFunc(func(ctx context.Context, msg am.IncomingRawMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if failing {
+			return errors.New("handler failed")
+		}
+		handled <- msg.ID()
+		return nil
+	}),
+		am.GroupName("test-orders"),
+		am.AckWait(time.Second),
+		am.MaxRedeliver(2),
+		am.DeadLetter(testDeadLetter),
+	)
+	require.NoError(t, err)
+
+	// another group on the subject that handled the messages the first time
+	var audited []string
+	err = stream.Subscribe("mallbots.orders", am.RawMessageHandlerFunc(func(ctx context.Context, msg am.IncomingRawMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+		audited = append(audited, msg.ID())
+		return nil
+	}), am.GroupName("test-audit"), am.AckWait(time.Second))
+	require.NoError(t, err)
+
+	for _, id := range []string{"message-1", "message-2", "message-3"} {
+		require.NoError(t, stream.Publish(context.Background(), "mallbots.orders", testMessage{id: id, name: "test.Message"}))
+	}
+
+	var dead []am.DeadLetterMessage
+	require.Eventually(t, func() bool {
+		dead, err = stream.DeadLetters(context.Background(), testDeadLetter, 0)
+		return err == nil && len(dead) == 3
+	}, 10*time.Second, 100*time.Millisecond, "the messages were not dead-lettered")
+
+	t.Run("DeadLetters", func(t *testing.T) {
+		msg := dead[0]
+		assert.Equal(t, "message-1", msg.ID())
+		assert.Equal(t, "test.Message", msg.MessageName())
+		assert.Equal(t, "mallbots.orders", msg.Subject())
+		assert.Equal(t, "handler failed", msg.Reason())
+		assert.Equal(t, 2, msg.Deliveries())
+		assert.Equal(t, "test-orders", msg.GroupName())
+		assert.False(t, msg.DeadAt().IsZero())
+
+		limited, err := stream.DeadLetters(context.Background(), testDeadLetter, 2)
+		require.NoError(t, err)
+		assert.Len(t, limited, 2)
+	})
+	t.Run("Replay", func(t *testing.T) {
+		mu.Lock()
+		failing = false
+		mu.Unlock()
+
+		require.NoError(t, stream.Replay(context.Background(), testDeadLetter, "message-2"))
+
+		select {
+		case id := <-handled:
+			assert.Equal(t, "message-2", id)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the replayed message was not handled")
+		}
+
+		remaining, err := stream.DeadLetters(context.Background(), testDeadLetter, 0)
+		require.NoError(t, err)
+		require.Len(t, remaining, 2)
+		assert.Equal(t, "message-1", remaining[0].ID())
+		assert.Equal(t, "message-3", remaining[1].ID())
+
+		// only the group the message failed in is sent the replay
+		time.Sleep(500 * time.Millisecond)
+		mu.Lock()
+		assert.Equal(t, []string{"message-1", "message-2", "message-3"}, audited)
+		mu.Unlock()
+	})
+	t.Run("History", func(t *testing.T) {
+		var history []string
+		err := stream.ReplayHistory(context.Background(), "mallbots.orders", am.RawMessageHandlerFunc(func(ctx context.Context, msg am.IncomingRawMessage) error {
+			history = append(history, msg.ID())
+			return nil
+		}), nil)
+		require.NoError(t, err)
+
+		// the replayed copy is left out
+		assert.Equal(t, []string{"message-1", "message-2", "message-3"}, history)
+	})
+}
+
+// addConsumerFails stands in for a server that refuses to change an existing
+// durable when it is added again
+type addConsumerFails struct {
+	nats.JetStreamContext
+}
+
+func (addConsumerFails) AddConsumer(string, *nats.ConsumerConfig, ...nats.JSOpt) (*nats.ConsumerInfo, error) {
+	return nil, nats.ErrConsumerNameAlreadyInUse
+}
+
+func TestStream_Subscribe_ExistingDurable(t *testing.T) {
+	_, js := newTestStream(t)
+	handler := am.RawMessageHandlerFunc(func(context.Context, am.IncomingRawMessage) error { return nil })
+
+	// a durable from before the consumers were given a filter subject
+	_, err := js.AddConsumer(testStreamName, &nats.ConsumerConfig{
+		Durable:        "test-orders",
+		DeliverSubject: "test-orders",
+		DeliverGroup:   "test-orders",
+		AckPolicy:      nats.AckExplicitPolicy,
+	})
+	require.NoError(t, err)
+
+	stream := NewStream(testStreamName, addConsumerFails{js}, zerolog.Nop())
+	require.NoError(t, stream.Subscribe("mallbots.orders", handler, am.GroupName("test-orders")))
+
+	info, err := js.ConsumerInfo(testStreamName, "test-orders")
+	require.NoError(t, err)
+	assert.Equal(t, "mallbots.orders", info.Config.FilterSubject)
+}
+
+func TestStream_Subscribe_DeadLetterSubject(t *testing.T) {
+	stream, _ := newTestStream(t)
+	handler := am.RawMessageHandlerFunc(func(context.Context, am.IncomingRawMessage) error { return nil })
+
+	tests := map[string]struct {
+		subject string
+		wantErr bool
+	}{
+		"InTheStream":   {subject: testDeadLetter},
+		"OutsideStream": {subject: "elsewhere.dead-letters", wantErr: true},
+		"WithAWildcard": {subject: "mallbots.dead.*", wantErr: true},
+		"StreamName":    {subject: "mallbots", wantErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := stream.Subscribe("mallbots.orders", handler, am.GroupName("test-"+name), am.MaxRedeliver(2), am.DeadLetter(tc.subject))
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
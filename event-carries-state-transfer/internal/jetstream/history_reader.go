@@ -0,0 +1,103 @@
+package jetstream
+
+import (
+	"context"
+	"time"
+
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/es"
+	"eda-in-golang/internal/registry"
+)
+
+// defaultHistoryWindow is how far back a HistoryReader looks by default
+const defaultHistoryWindow = 90 * 24 * time.Hour
+
+// HistoryReader is an es.HistoryReader for aggregates that are not event
+// sourced; it reads the integration events published for the aggregate from
+// the stream. An event belongs to the aggregate when its metadata names it,
+// or otherwise when its payload has the aggregate ID.
+//
+// The events of every aggregate share the topic, so each call reads all of
+// the messages published to the topic within the window and keeps the ones of
+// the aggregate; the cost grows with the traffic on the topic, not with the
+// history of the aggregate. Events older than the window are left out.
+type HistoryReader struct {
+	stream    *Stream
+	topicName string
+	reg       registry.Registry
+	window    time.Duration
+}
+
+type HistoryReaderOption func(r *HistoryReader)
+
+var _ es.HistoryReader = (*HistoryReader)(nil)
+
+func NewHistoryReader(stream *Stream, topicName string, reg registry.Registry, options ...HistoryReaderOption) HistoryReader {
+	r := HistoryReader{
+		stream:    stream,
+		topicName: topicName,
+		reg:       reg,
+		window:    defaultHistoryWindow,
+	}
+
+	for _, option := range options {
+		option(&r)
+	}
+
+	return r
+}
+
+// HistoryWindow sets how far back the history of an aggregate is read
+func HistoryWindow(window time.Duration) HistoryReaderOption {
+	return func(r *HistoryReader) {
+		if window > 0 {
+			r.window = window
+		}
+	}
+}
+
+// History reads the messages on the topic published within the window; the
+// versions are the positions of the events within the history that was read
+func (r HistoryReader) History(ctx context.Context, _, aggregateID string) ([]es.HistoryEvent, error) {
+	var history []es.HistoryEvent
+
+	handler := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+		if !r.belongsTo(event, aggregateID) {
+			return nil
+		}
+
+		payload, err := es.RenderPayload(event.Payload())
+		if err != nil {
+			return err
+		}
+
+		history = append(history, es.HistoryEvent{
+			ID:         event.ID(),
+			Name:       event.EventName(),
+			Version:    len(history) + 1,
+			OccurredAt: event.OccurredAt(),
+			Payload:    payload,
+			Metadata:   event.Metadata(),
+		})
+
+		return nil
+	})
+
+	since := time.Now().Add(-r.window)
+	err := r.stream.ReplayHistorySince(ctx, r.topicName, since, am.NewEventMessageHandler(r.reg, handler), nil)
+
+	return history, err
+}
+
+func (r HistoryReader) belongsTo(event ddd.Event, aggregateID string) bool {
+	if id, ok := event.Metadata().Get(ddd.AggregateIDKey).(string); ok {
+		return id == aggregateID
+	}
+
+	if payload, ok := event.Payload().(interface{ GetId() string }); ok {
+		return payload.GetId() == aggregateID
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/ddd"
+)
+
+func TestHistoryReader_History(t *testing.T) {
+	stream, _ := newTestStream(t)
+	reg := newTestRegistry(t)
+	events := am.NewEventStream(reg, stream)
+
+	publish := func(productID string, sequence int) {
+		require.NoError(t, events.Publish(context.Background(), "mallbots.products", ddd.NewEvent(
+			productPriceChanged{}.Key(),
+			&productPriceChanged{ProductID: productID, Sequence: sequence},
+			ddd.Metadata{ddd.AggregateIDKey: productID},
+		)))
+	}
+	publish("product-1", 1)
+	publish("product-2", 1)
+	time.Sleep(500 * time.Millisecond)
+	publish("product-1", 2)
+
+	t.Run("OnlyTheAggregate", func(t *testing.T) {
+		history, err := NewHistoryReader(stream, "mallbots.products", reg).History(context.Background(), "", "product-1")
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		for i, event := range history {
+			assert.Equal(t, i+1, event.Version)
+			assert.Equal(t, "product-1", event.Metadata.Get(ddd.AggregateIDKey))
+			assert.JSONEq(t, fmt.Sprintf(`{"ProductID":"product-1","Sequence":%d}`, i+1), string(event.Payload))
+		}
+	})
+	t.Run("WithinTheWindow", func(t *testing.T) {
+		reader := NewHistoryReader(stream, "mallbots.products", reg, HistoryWindow(250*time.Millisecond))
+
+		history, err := reader.History(context.Background(), "", "product-1")
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, 1, history[0].Version)
+
+		history, err = reader.History(context.Background(), "", "product-2")
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+	t.Run("NothingPublished", func(t *testing.T) {
+		history, err := NewHistoryReader(stream, "mallbots.stores", reg).History(context.Background(), "", "store-1")
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+}
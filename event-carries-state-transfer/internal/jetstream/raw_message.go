@@ -1,6 +1,8 @@
 package jetstream
 
 import (
+	"time"
+
 	"eda-in-golang/internal/am"
 )
 
@@ -12,6 +14,7 @@ type rawMessage struct {
 	acked    bool
 	ackFn    func() error
 	nackFn   func() error
+	delayFn  func(delay time.Duration) error
 	extendFn func() error
 	killFn   func() error
 }
@@ -39,6 +42,14 @@ func (m *rawMessage) NAck() error {
 	return m.nackFn()
 }
 
+func (m *rawMessage) nackWithDelay(delay time.Duration) error {
+	if m.acked {
+		return nil
+	}
+	m.acked = true
+	return m.delayFn(delay)
+}
+
 func (m rawMessage) Extend() error {
 	return m.extendFn()
 }
@@ -0,0 +1,130 @@
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+
+	"eda-in-golang/internal/am"
+)
+
+// ReplayProgress reports the stream sequence of the last message replayed and
+// how many messages have been replayed so far
+type ReplayProgress func(sequence uint64, replayed int)
+
+// ReplayHistory hands the messages stored for the topic to the handler in
+// order, from the first up to the last one stored when the replay started. It
+// uses its own ephemeral consumer and leaves the consumers of the subscribers
+// alone; the messages do not need to be acknowledged.
+func (s *Stream) ReplayHistory(ctx context.Context, topicName string, handler am.MessageHandler[am.IncomingRawMessage], progress ReplayProgress) error {
+	return s.ReplayHistoryFrom(ctx, topicName, 0, handler, progress)
+}
+
+// ReplayHistoryFrom is ReplayHistory starting with the first message for the
+// topic at or after the stream sequence; a sequence of zero replays them all
+func (s *Stream) ReplayHistoryFrom(ctx context.Context, topicName string, sequence uint64, handler am.MessageHandler[am.IncomingRawMessage], progress ReplayProgress) error {
+	// the replay ends with the last message stored for the topic now; the
+	// pending count of the consumer would miss the messages already pushed
+	last, err := s.lastMsg(ctx, topicName)
+	if err != nil || last == nil || last.Sequence < sequence {
+		return err
+	}
+
+	deliver := nats.DeliverAll()
+	if sequence > 0 {
+		deliver = nats.StartSequence(sequence)
+	}
+
+	return s.replay(ctx, topicName, last, deliver, handler, progress)
+}
+
+// ReplayHistorySince is ReplayHistory starting with the first message for the
+// topic stored at or after the time
+func (s *Stream) ReplayHistorySince(ctx context.Context, topicName string, since time.Time, handler am.MessageHandler[am.IncomingRawMessage], progress ReplayProgress) error {
+	last, err := s.lastMsg(ctx, topicName)
+	if err != nil || last == nil || last.Time.Before(since) {
+		return err
+	}
+
+	return s.replay(ctx, topicName, last, nats.StartTime(since), handler, progress)
+}
+
+// lastMsg returns the last message stored for the topic, or nil when there is
+// none
+func (s *Stream) lastMsg(ctx context.Context, topicName string) (*nats.RawStreamMsg, error) {
+	last, err := s.js.GetLastMsg(s.streamName, topicName, nats.Context(ctx))
+	if err != nil {
+		if errors.Is(err, nats.ErrMsgNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return last, nil
+}
+
+func (s *Stream) replay(ctx context.Context, topicName string, last *nats.RawStreamMsg, deliver nats.SubOpt, handler am.MessageHandler[am.IncomingRawMessage], progress ReplayProgress) error {
+	sub, err := s.js.SubscribeSync(topicName, nats.OrderedConsumer(), deliver, nats.BindStream(s.streamName))
+	if err != nil {
+		return err
+	}
+	defer func(sub *nats.Subscription) {
+		_ = sub.Unsubscribe()
+	}(sub)
+
+	replayed := 0
+
+	for {
+		natsMsg, err := sub.NextMsgWithContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		md, err := natsMsg.Metadata()
+		if err != nil {
+			return err
+		}
+
+		// a replayed dead letter is a copy of a message already in the history
+		if natsMsg.Header.Get(am.ReplayGroupHeader) != "" {
+			if md.Sequence.Stream >= last.Sequence {
+				return nil
+			}
+			continue
+		}
+
+		m := &StreamMessage{}
+		if err = proto.Unmarshal(natsMsg.Data, m); err != nil {
+			return err
+		}
+
+		noop := func() error { return nil }
+		msg := &rawMessage{
+			id:       m.GetId(),
+			name:     m.GetName(),
+			subject:  natsMsg.Subject,
+			data:     m.GetData(),
+			ackFn:    noop,
+			nackFn:   noop,
+			delayFn:  func(time.Duration) error { return nil },
+			extendFn: noop,
+			killFn:   noop,
+		}
+
+		if err = handler.HandleMessage(ctx, msg); err != nil {
+			return err
+		}
+
+		replayed++
+		if progress != nil {
+			progress(md.Sequence.Stream, replayed)
+		}
+
+		if md.Sequence.Stream >= last.Sequence {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package jetstream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/ddd"
+)
+
+func TestStream_ReplayHistory(t *testing.T) {
+	stream, _ := newTestStream(t)
+	reg := newTestRegistry(t)
+	events := am.NewEventStream(reg, stream)
+
+	publish := func(topicName, productID string, sequence int) {
+		require.NoError(t, events.Publish(context.Background(), topicName, ddd.NewEvent(
+			productPriceChanged{}.Key(), &productPriceChanged{ProductID: productID, Sequence: sequence},
+		)))
+	}
+	publish("mallbots.products", "product-1", 1)
+	publish("mallbots.stores", "store-1", 1)
+	publish("mallbots.products", "product-1", 2)
+	publish("mallbots.products", "product-2", 1)
+
+	replay := func(sequence uint64) (replayed []productPriceChanged, sequences []uint64) {
+		handler := ddd.EventHandlerFunc[ddd.Event](func(ctx context.Context, event ddd.Event) error {
+			replayed = append(replayed, *event.Payload().(*productPriceChanged))
+			return nil
+		})
+		err := stream.ReplayHistoryFrom(context.Background(), "mallbots.products", sequence, am.NewEventMessageHandler(reg, handler),
+			func(sequence uint64, count int) {
+				sequences = append(sequences, sequence)
+				assert.Equal(t, len(sequences), count)
+			},
+		)
+		require.NoError(t, err)
+		return replayed, sequences
+	}
+
+	t.Run("FromTheStart", func(t *testing.T) {
+		replayed, sequences := replay(0)
+		assert.Equal(t, []productPriceChanged{
+			{ProductID: "product-1", Sequence: 1},
+			{ProductID: "product-1", Sequence: 2},
+			{ProductID: "product-2", Sequence: 1},
+		}, replayed)
+		assert.Equal(t, []uint64{1, 3, 4}, sequences)
+	})
+	t.Run("FromASequence", func(t *testing.T) {
+		replayed, sequences := replay(2)
+		assert.Equal(t, []productPriceChanged{
+			{ProductID: "product-1", Sequence: 2},
+			{ProductID: "product-2", Sequence: 1},
+		}, replayed)
+		assert.Equal(t, []uint64{3, 4}, sequences)
+	})
+	t.Run("NothingToReplay", func(t *testing.T) {
+		replayed, _ := replay(5)
+		assert.Empty(t, replayed)
+	})
+}
@@ -3,6 +3,7 @@ package jetstream
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog"
@@ -13,21 +14,41 @@ import (
 
 const maxRetries = 5
 
+// shardQueueSize is how many messages may be outstanding for each shard worker
+const shardQueueSize = 8
+
 type Stream struct {
 	streamName string
 	js         nats.JetStreamContext
+	confirm    bool
 	mu         sync.Mutex
 	logger     zerolog.Logger
 }
 
+type StreamOption func(s *Stream)
+
 var _ am.RawMessageStream = (*Stream)(nil)
 
-func NewStream(streamName string, js nats.JetStreamContext, logger zerolog.Logger) *Stream {
-	return &Stream{
+func NewStream(streamName string, js nats.JetStreamContext, logger zerolog.Logger, options ...StreamOption) *Stream {
+	s := &Stream{
 		streamName: streamName,
 		js:         js,
 		logger:     logger,
 	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// ConfirmPublish makes Publish wait until JetStream has acknowledged the
+// message; a nil error then means the message was stored by the stream
+func ConfirmPublish() StreamOption {
+	return func(s *Stream) {
+		s.confirm = true
+	}
 }
 
 func (s *Stream) Publish(ctx context.Context, topicName string, rawMsg am.RawMessage) (err error) {
@@ -42,11 +63,18 @@ func (s *Stream) Publish(ctx context.Context, topicName string, rawMsg am.RawMes
 		return err
 	}
 
-	var p nats.PubAckFuture
-	p, err = s.js.PublishMsgAsync(&nats.Msg{
+	msg := &nats.Msg{
 		Subject: topicName,
 		Data:    data,
-	}, nats.MsgId(rawMsg.ID()))
+	}
+
+	if s.confirm {
+		_, err = s.js.PublishMsg(msg, nats.MsgId(rawMsg.ID()), nats.Context(ctx))
+		return err
+	}
+
+	var p nats.PubAckFuture
+	p, err = s.js.PublishMsgAsync(msg, nats.MsgId(rawMsg.ID()))
 	if err != nil {
 		return err
 	}
@@ -59,16 +87,22 @@ func (s *Stream) Publish(ctx context.Context, topicName string, rawMsg am.RawMes
 			select {
 			case <-future.Ok(): // publish acknowledged
 				return
-			case <-future.Err(): // error ignored; try again
+			case err = <-future.Err():
 				// TODO add some variable delay between tries
 				tries = tries - 1
 				if tries <= 0 {
-					// TODO do more than give up
+					s.logger.Error().Err(err).
+						Str("MessageID", rawMsg.ID()).
+						Str("Subject", topicName).
+						Msg("giving up on publishing message")
 					return
 				}
 				future, err = s.js.PublishMsgAsync(future.Msg())
 				if err != nil {
-					// TODO do more than give up
+					s.logger.Error().Err(err).
+						Str("MessageID", rawMsg.ID()).
+						Str("Subject", topicName).
+						Msg("failed to republish message")
 					return
 				}
 			}
@@ -83,6 +117,12 @@ func (s *Stream) Subscribe(topicName string, handler am.MessageHandler[am.Incomi
 
 	subCfg := am.NewSubscriberConfig(options)
 
+	if deadLetter := subCfg.DeadLetter(); deadLetter != "" {
+		if err = s.checkDeadLetter(deadLetter); err != nil {
+			return err
+		}
+	}
+
 	opts := []nats.SubOpt{
 		nats.MaxDeliver(subCfg.MaxRedeliver()),
 	}
@@ -93,6 +133,9 @@ func (s *Stream) Subscribe(topicName string, handler am.MessageHandler[am.Incomi
 		cfg.DeliverSubject = groupName
 		cfg.DeliverGroup = groupName
 		cfg.Durable = groupName
+		// without a filter the consumer would be sent every message in the
+		// stream, dead-lettered messages included
+		cfg.FilterSubject = topicName
 
 		opts = append(opts, nats.Bind(s.streamName, groupName), nats.Durable(groupName))
 	}
@@ -104,20 +147,39 @@ func (s *Stream) Subscribe(topicName string, handler am.MessageHandler[am.Incomi
 		cfg.AckWait = ackWait
 
 		opts = append(opts, nats.AckExplicit(), nats.AckWait(ackWait))
+
+		// no more messages are delivered than the shard workers can queue
+		if workers := subCfg.ShardWorkers(); workers > 1 {
+			cfg.MaxAckPending = workers * shardQueueSize
+			opts = append(opts, nats.MaxAckPending(cfg.MaxAckPending))
+		}
 	} else {
 		cfg.AckPolicy = nats.AckNonePolicy
 		opts = append(opts, nats.AckNone())
 	}
 
-	_, err = s.js.AddConsumer(s.streamName, cfg)
-	if err != nil {
-		return err
+	if _, err = s.js.AddConsumer(s.streamName, cfg); err != nil {
+		if cfg.Durable == "" {
+			return err
+		}
+		// the durable exists with another config, for instance one created
+		// before it was given a filter subject; settings that JetStream
+		// cannot update, such as the ack policy, require the durable to be
+		// deleted first
+		if _, err = s.js.UpdateConsumer(s.streamName, cfg); err != nil {
+			return err
+		}
+	}
+
+	handle := s.handleMsg(subCfg, handler)
+	if subCfg.ShardWorkers() > 1 {
+		handle = s.shardMsgs(subCfg, handler)
 	}
 
 	if groupName := subCfg.GroupName(); groupName == "" {
-		_, err = s.js.Subscribe(topicName, s.handleMsg(subCfg, handler), opts...)
+		_, err = s.js.Subscribe(topicName, handle, opts...)
 	} else {
-		_, err = s.js.QueueSubscribe(topicName, groupName, s.handleMsg(subCfg, handler), opts...)
+		_, err = s.js.QueueSubscribe(topicName, groupName, handle, opts...)
 	}
 
 	return nil
@@ -125,55 +187,199 @@ func (s *Stream) Subscribe(topicName string, handler am.MessageHandler[am.Incomi
 
 func (s *Stream) handleMsg(cfg am.SubscriberConfig, handler am.MessageHandler[am.IncomingRawMessage]) func(*nats.Msg) {
 	return func(natsMsg *nats.Msg) {
-		var err error
+		if replayedForOthers(cfg, natsMsg) {
+			_ = natsMsg.Ack()
+			return
+		}
 
-		m := &StreamMessage{}
-		err = proto.Unmarshal(natsMsg.Data, m)
+		msg, err := s.decodeMsg(natsMsg)
 		if err != nil {
 			// TODO Nak? ... logging?
 			return
 		}
 
-		msg := &rawMessage{
-			id:       m.GetId(),
-			name:     m.GetName(),
-			data:     m.GetData(),
-			acked:    false,
-			ackFn:    func() error { return natsMsg.Ack() },
-			nackFn:   func() error { return natsMsg.Nak() },
-			extendFn: func() error { return natsMsg.InProgress() },
-			killFn:   func() error { return natsMsg.Term() },
-		}
-
-		wCtx, cancel := context.WithTimeout(context.Background(), cfg.AckWait())
-		defer cancel()
+		s.processMsg(cfg, handler, natsMsg, msg)
+	}
+}
 
-		errc := make(chan error)
-		go func() {
-			errc <- handler.HandleMessage(wCtx, msg)
-		}()
+// shardMsgs passes each message to the worker picked by its shard key so that
+// each worker receives its messages in the order they were delivered. The ack
+// wait of a message starts when it is delivered; messages waiting behind
+// others for their worker are kept in progress so that they are not
+// redelivered, out of order, while they wait.
+func (s *Stream) shardMsgs(cfg am.SubscriberConfig, handler am.MessageHandler[am.IncomingRawMessage]) func(*nats.Msg) {
+	type delivery struct {
+		natsMsg  *nats.Msg
+		msg      *rawMessage
+		queuedAt time.Time
+		dequeued chan struct{}
+	}
 
-		if cfg.AckType() == am.AckTypeAuto {
-			err = msg.Ack()
-			if err != nil {
-				// TODO logging?
+	// with MaxAckPending bounded the queues have room for every message that
+	// can be outstanding, so delivering a message never waits for a worker
+	workers := make([]chan delivery, cfg.ShardWorkers())
+	for i := range workers {
+		workers[i] = make(chan delivery, len(workers)*shardQueueSize)
+		go func(deliveries <-chan delivery) {
+			for d := range deliveries {
+				close(d.dequeued)
+				// a message that waited gets the whole ack wait for its handler
+				if cfg.AckType() != am.AckTypeAuto && time.Since(d.queuedAt) > cfg.AckWait()/10 {
+					_ = d.natsMsg.InProgress()
+				}
+				s.processMsg(cfg, handler, d.natsMsg, d.msg)
 			}
+		}(workers[i])
+	}
+
+	return func(natsMsg *nats.Msg) {
+		if replayedForOthers(cfg, natsMsg) {
+			_ = natsMsg.Ack()
+			return
+		}
+
+		msg, err := s.decodeMsg(natsMsg)
+		if err != nil {
+			// TODO Nak? ... logging?
+			return
+		}
+
+		d := delivery{natsMsg: natsMsg, msg: msg, queuedAt: time.Now(), dequeued: make(chan struct{})}
+		if cfg.AckType() != am.AckTypeAuto {
+			go keepInProgress(natsMsg, cfg.AckWait(), d.dequeued)
 		}
 
+		workers[am.Shard(msg, cfg.ShardKey(), len(workers))] <- d
+	}
+}
+
+// keepInProgress resets the ack wait of a queued message until it is dequeued
+func keepInProgress(natsMsg *nats.Msg, ackWait time.Duration, dequeued <-chan struct{}) {
+	ticker := time.NewTicker(ackWait / 2)
+	defer ticker.Stop()
+
+	for {
 		select {
-		case err = <-errc:
-			if err == nil {
-				if ackErr := msg.Ack(); ackErr != nil {
-					// TODO logging?
-				}
-				return
-			}
-			if nakErr := msg.NAck(); nakErr != nil {
+		case <-dequeued:
+			return
+		case <-ticker.C:
+			_ = natsMsg.InProgress()
+		}
+	}
+}
+
+func (s *Stream) decodeMsg(natsMsg *nats.Msg) (*rawMessage, error) {
+	m := &StreamMessage{}
+	if err := proto.Unmarshal(natsMsg.Data, m); err != nil {
+		return nil, err
+	}
+
+	return &rawMessage{
+		id:       m.GetId(),
+		name:     m.GetName(),
+		subject:  natsMsg.Subject,
+		data:     m.GetData(),
+		acked:    false,
+		ackFn:    func() error { return natsMsg.Ack() },
+		nackFn:   func() error { return natsMsg.Nak() },
+		delayFn:  func(delay time.Duration) error { return natsMsg.NakWithDelay(delay) },
+		extendFn: func() error { return natsMsg.InProgress() },
+		killFn:   func() error { return natsMsg.Term() },
+	}, nil
+}
+
+func (s *Stream) processMsg(cfg am.SubscriberConfig, handler am.MessageHandler[am.IncomingRawMessage], natsMsg *nats.Msg, msg *rawMessage) {
+	var err error
+
+	wCtx, cancel := context.WithTimeout(context.Background(), cfg.AckWait())
+	defer cancel()
+
+	errc := make(chan error)
+	go func() {
+		errc <- handler.HandleMessage(wCtx, msg)
+	}()
+
+	if cfg.AckType() == am.AckTypeAuto {
+		err = msg.Ack()
+		if err != nil {
+			// TODO logging?
+		}
+	}
+
+	select {
+	case err = <-errc:
+		if err == nil {
+			if ackErr := msg.Ack(); ackErr != nil {
 				// TODO logging?
 			}
-		case <-wCtx.Done():
-			// TODO logging?
 			return
 		}
+		s.handleFailure(cfg, natsMsg, msg, err)
+	case <-wCtx.Done():
+		s.handleFinalDelivery(cfg, natsMsg, msg, wCtx.Err())
+		return
 	}
 }
+
+func (s *Stream) handleFailure(cfg am.SubscriberConfig, natsMsg *nats.Msg, msg *rawMessage, reason error) {
+	policy := cfg.RetryPolicy()
+	if policy == nil {
+		if !s.handleFinalDelivery(cfg, natsMsg, msg, reason) {
+			_ = msg.NAck()
+		}
+		return
+	}
+
+	md, err := natsMsg.Metadata()
+	if err != nil {
+		_ = msg.NAck()
+		return
+	}
+
+	if policy.IsPermanent(reason) {
+		// nothing is gained by trying again
+		if cfg.DeadLetter() == "" || !s.moveToDeadLetter(cfg, natsMsg, msg, reason, md.NumDelivered) {
+			_ = msg.Kill()
+		}
+		return
+	}
+
+	if s.handleFinalDelivery(cfg, natsMsg, msg, reason) {
+		return
+	}
+
+	if err = msg.nackWithDelay(policy.Delay(int(md.NumDelivered))); err != nil {
+		s.logger.Error().Err(err).Str("MessageID", msg.ID()).Msg("failed to nak message with delay")
+	}
+}
+
+// handleFinalDelivery moves the message to the dead-letter subject when this
+// was the last delivery the consumer would attempt
+func (s *Stream) handleFinalDelivery(cfg am.SubscriberConfig, natsMsg *nats.Msg, msg *rawMessage, reason error) bool {
+	if cfg.DeadLetter() == "" || cfg.AckType() == am.AckTypeAuto || cfg.MaxRedeliver() < 1 {
+		return false
+	}
+
+	md, err := natsMsg.Metadata()
+	if err != nil || md.NumDelivered < uint64(cfg.MaxRedeliver()) {
+		return false
+	}
+
+	return s.moveToDeadLetter(cfg, natsMsg, msg, reason, md.NumDelivered)
+}
+
+func (s *Stream) moveToDeadLetter(cfg am.SubscriberConfig, natsMsg *nats.Msg, msg *rawMessage, reason error, deliveries uint64) bool {
+	if err := s.deadLetter(cfg, natsMsg, reason, deliveries); err != nil {
+		s.logger.Error().Err(err).
+			Str("MessageID", msg.ID()).
+			Str("DeadLetter", cfg.DeadLetter()).
+			Msg("failed to dead-letter message")
+		return false
+	}
+
+	if err := msg.Ack(); err != nil {
+		s.logger.Error().Err(err).Str("MessageID", msg.ID()).Msg("failed to ack dead-lettered message")
+	}
+
+	return true
+}
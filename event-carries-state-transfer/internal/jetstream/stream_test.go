@@ -0,0 +1,174 @@
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/registry"
+	"eda-in-golang/internal/registry/serdes"
+)
+
+const testStreamName = "mallbots"
+
+type productPriceChanged struct {
+	ProductID string
+	Sequence  int
+}
+
+func (productPriceChanged) Key() string { return "test.ProductPriceChanged" }
+
+// newTestStream starts a JetStream enabled server for the test and returns a
+// stream over a fresh "mallbots" stream
+func newTestStream(t *testing.T) (*Stream, nats.JetStreamContext) {
+	t.Helper()
+
+	ns, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		NoLog:     true,
+		NoSigs:    true,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+	})
+	require.NoError(t, err)
+	ns.Start()
+	t.Cleanup(ns.Shutdown)
+	require.True(t, ns.ReadyForConnections(5*time.Second), "the nats server did not start")
+
+	nc, err := nats.Connect(ns.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+
+	js, err := nc.JetStream()
+	require.NoError(t, err)
+
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:     testStreamName,
+		Subjects: []string{testStreamName + ".>"},
+	})
+	require.NoError(t, err)
+
+	return NewStream(testStreamName, js, zerolog.Nop(), ConfirmPublish()), js
+}
+
+func newTestRegistry(t *testing.T) registry.Registry {
+	t.Helper()
+
+	reg := registry.New()
+	require.NoError(t, serdes.NewJsonSerde(reg).Register(productPriceChanged{}))
+
+	return reg
+}
+
+func TestStream_ShardBy(t *testing.T) {
+	const products = 3
+	const changes = 10
+
+	stream, _ := newTestStream(t)
+	events := am.NewEventStream(newTestRegistry(t), stream)
+
+	var mu sync.Mutex
+	handled := make(map[string][]int)
+	done := make(chan struct{})
+
+	// the messages queued behind a slow handler wait longer than the ack wait
+	err := events.Subscribe("mallbots.products", am.MessageHandlerFunc[am.IncomingEventMessage](
+		func(ctx context.Context, msg am.IncomingEventMessage) error {
+			time.Sleep(time.Duration(20+rand.Intn(20)) * time.Millisecond)
+
+			payload := msg.Payload().(*productPriceChanged)
+			mu.Lock()
+			defer mu.Unlock()
+			handled[payload.ProductID] = append(handled[payload.ProductID], payload.Sequence)
+			if count(handled) == products*changes {
+				close(done)
+			}
+			return nil
+		}),
+		am.GroupName("test-products"),
+		am.AckWait(200*time.Millisecond),
+		am.MaxRedeliver(5),
+		am.ShardBy{Key: ddd.AggregateIDKey, Workers: 2},
+	)
+	require.NoError(t, err)
+
+	for i := range changes {
+		for p := range products {
+			productID := fmt.Sprintf("product-%d", p)
+			require.NoError(t, events.Publish(context.Background(), "mallbots.products", ddd.NewEvent(
+				productPriceChanged{}.Key(),
+				&productPriceChanged{ProductID: productID, Sequence: i},
+				ddd.Metadata{ddd.AggregateIDKey: productID},
+			)))
+		}
+	}
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the messages were not handled")
+	}
+	// give the stream a chance to redeliver anything that timed out
+	time.Sleep(500 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for p := range products {
+		expected := make([]int, changes)
+		for i := range expected {
+			expected[i] = i
+		}
+		assert.Equal(t, expected, handled[fmt.Sprintf("product-%d", p)])
+	}
+}
+
+func count(handled map[string][]int) int {
+	n := 0
+	for _, sequences := range handled {
+		n += len(sequences)
+	}
+	return n
+}
+
+func TestStream_ConfirmPublish(t *testing.T) {
+	confirmed, js := newTestStream(t)
+	unconfirmed := NewStream(testStreamName, js, zerolog.Nop())
+	msg := testMessage{id: "message-id", name: "test.Message"}
+
+	t.Run("Stored", func(t *testing.T) {
+		require.NoError(t, confirmed.Publish(context.Background(), "mallbots.confirmed", msg))
+
+		// the message is in the stream as soon as Publish returns
+		info, err := js.StreamInfo(testStreamName)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), info.State.Msgs)
+	})
+	t.Run("NotStored", func(t *testing.T) {
+		// no stream is listening on the subject
+		assert.Error(t, confirmed.Publish(context.Background(), "elsewhere.confirmed", msg))
+		assert.NoError(t, unconfirmed.Publish(context.Background(), "elsewhere.unconfirmed", msg),
+			"an unconfirmed publish reports its failures asynchronously")
+	})
+}
+
+type testMessage struct {
+	id   string
+	name string
+}
+
+func (m testMessage) ID() string          { return m.id }
+func (m testMessage) MessageName() string { return m.name }
+func (m testMessage) Subject() string     { return "" }
+func (m testMessage) Data() []byte        { return nil }
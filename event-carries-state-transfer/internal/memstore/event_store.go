@@ -0,0 +1,276 @@
+package memstore
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/stackus/errors"
+
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/es"
+	"eda-in-golang/internal/registry"
+)
+
+type (
+	// EventStore keeps the events of every aggregate in memory and behaves
+	// like the postgres EventStore without requiring a database; useful for
+	// tests. Payloads are stored serialized by the registry.
+	EventStore struct {
+		registry  registry.Registry
+		upcasters *es.Upcasters
+		streams   map[streamKey][]*storedEvent
+		events    []*storedEvent
+		mu        sync.RWMutex
+	}
+
+	EventStoreOption func(s *EventStore)
+
+	streamKey struct {
+		name string
+		id   string
+	}
+
+	storedEvent struct {
+		aggregateName string
+		aggregateID   string
+		version       int
+		id            string
+		name          string
+		eventVersion  int
+		data          []byte
+		metadata      []byte
+		occurredAt    time.Time
+		position      int64
+	}
+
+	aggregateEvent struct {
+		id            string
+		name          string
+		payload       ddd.EventPayload
+		metadata      ddd.Metadata
+		occurredAt    time.Time
+		aggregateName string
+		aggregateID   string
+		version       int
+		position      int64
+	}
+)
+
+var _ es.AggregateStore = (*EventStore)(nil)
+
+var _ es.EventFeed = (*EventStore)(nil)
+
+var _ es.HistoryReader = (*EventStore)(nil)
+
+var _ es.StoredEvent = (*aggregateEvent)(nil)
+
+func NewEventStore(registry registry.Registry, options ...EventStoreOption) *EventStore {
+	s := &EventStore{
+		registry: registry,
+		streams:  make(map[streamKey][]*storedEvent),
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// WithUpcasters brings events stored with an older schema version up to date
+// before they are deserialized
+func WithUpcasters(upcasters *es.Upcasters) EventStoreOption {
+	return func(s *EventStore) {
+		s.upcasters = upcasters
+	}
+}
+
+func (s *EventStore) Load(ctx context.Context, aggregate es.EventSourcedAggregate) error {
+	s.mu.RLock()
+	stream := s.streams[streamKey{name: aggregate.AggregateName(), id: aggregate.ID()}]
+	s.mu.RUnlock()
+
+	bound, bounded := es.LoadBoundFrom(ctx)
+
+	for _, stored := range stream {
+		if stored.version <= aggregate.Version() {
+			continue
+		}
+		if bounded && bound.Version > 0 && stored.version > bound.Version {
+			break
+		}
+		if bounded && !bound.AsOf.IsZero() && stored.occurredAt.After(bound.AsOf) {
+			break
+		}
+
+		event, err := s.decode(stored)
+		if err != nil {
+			return err
+		}
+
+		if err = es.LoadEvent(aggregate, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Save appends the pending events; when another writer has already stored an
+// event with one of the versions nothing is saved and es.ErrConcurrencyConflict
+// is returned, as the unique stream version does for the postgres EventStore
+func (s *EventStore) Save(_ context.Context, aggregate es.EventSourcedAggregate) error {
+	aggregateID := aggregate.ID()
+	aggregateName := aggregate.AggregateName()
+
+	events := make([]*storedEvent, len(aggregate.Events()))
+	for i, event := range aggregate.Events() {
+		data, err := s.registry.Serialize(event.EventName(), event.Payload())
+		if err != nil {
+			return err
+		}
+
+		metadata, err := json.Marshal(event.Metadata())
+		if err != nil {
+			return err
+		}
+
+		events[i] = &storedEvent{
+			aggregateName: aggregateName,
+			aggregateID:   aggregateID,
+			version:       event.AggregateVersion(),
+			id:            event.ID(),
+			name:          event.EventName(),
+			eventVersion:  s.upcasters.Version(event.EventName()),
+			data:          data,
+			metadata:      metadata,
+			occurredAt:    event.OccurredAt(),
+		}
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := streamKey{name: aggregateName, id: aggregateID}
+	stream := s.streams[key]
+
+	if len(stream) > 0 && stream[len(stream)-1].version >= events[0].version {
+		return errors.Wrapf(es.ErrConcurrencyConflict, "saving %s %s", aggregateName, aggregateID)
+	}
+
+	for _, event := range events {
+		event.position = int64(len(s.events) + 1)
+		s.events = append(s.events, event)
+	}
+	s.streams[key] = append(stream, events...)
+
+	return nil
+}
+
+// ReadAll returns up to limit events from every stream, starting at
+// fromPosition and in the order they were saved
+func (s *EventStore) ReadAll(_ context.Context, fromPosition int64, limit int) ([]es.StoredEvent, error) {
+	s.mu.RLock()
+	var stored []*storedEvent
+	if fromPosition < 1 {
+		fromPosition = 1
+	}
+	if start := int(fromPosition - 1); start < len(s.events) {
+		end := len(s.events)
+		if start+limit < end {
+			end = start + limit
+		}
+		stored = s.events[start:end]
+	}
+	s.mu.RUnlock()
+
+	events := make([]es.StoredEvent, 0, len(stored))
+	for _, event := range stored {
+		decoded, err := s.decode(event)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, decoded)
+	}
+
+	return events, nil
+}
+
+func (s *EventStore) History(_ context.Context, aggregateName, aggregateID string) ([]es.HistoryEvent, error) {
+	s.mu.RLock()
+	stream := s.streams[streamKey{name: aggregateName, id: aggregateID}]
+	s.mu.RUnlock()
+
+	history := make([]es.HistoryEvent, 0, len(stream))
+	for _, stored := range stream {
+		event, err := s.decode(stored)
+		if err != nil {
+			return nil, err
+		}
+
+		payload, err := es.RenderPayload(event.payload)
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, es.HistoryEvent{
+			ID:         event.id,
+			Name:       event.name,
+			Version:    event.version,
+			OccurredAt: event.occurredAt,
+			Payload:    payload,
+			Metadata:   event.metadata,
+		})
+	}
+
+	return history, nil
+}
+
+func (s *EventStore) decode(stored *storedEvent) (aggregateEvent, error) {
+	event := aggregateEvent{
+		id:            stored.id,
+		name:          stored.name,
+		occurredAt:    stored.occurredAt,
+		aggregateName: stored.aggregateName,
+		aggregateID:   stored.aggregateID,
+		version:       stored.version,
+		position:      stored.position,
+	}
+
+	data, err := s.upcasters.Upcast(stored.name, stored.eventVersion, stored.data)
+	if err != nil {
+		return event, err
+	}
+
+	event.metadata = make(ddd.Metadata)
+	if err = json.Unmarshal(stored.metadata, &event.metadata); err != nil {
+		return event, err
+	}
+	// JSON does not keep the types of the aggregate keys; use the stream values
+	event.metadata.Set(ddd.AggregateNameKey, event.aggregateName)
+	event.metadata.Set(ddd.AggregateIDKey, event.aggregateID)
+	event.metadata.Set(ddd.AggregateVersionKey, event.version)
+
+	event.payload, err = s.registry.Deserialize(stored.name, data)
+	if err != nil {
+		return event, err
+	}
+
+	return event, nil
+}
+
+func (e aggregateEvent) ID() string                { return e.id }
+func (e aggregateEvent) EventName() string         { return e.name }
+func (e aggregateEvent) Payload() ddd.EventPayload { return e.payload }
+func (e aggregateEvent) Metadata() ddd.Metadata    { return e.metadata }
+func (e aggregateEvent) OccurredAt() time.Time     { return e.occurredAt }
+func (e aggregateEvent) AggregateName() string     { return e.aggregateName }
+func (e aggregateEvent) AggregateID() string       { return e.aggregateID }
+func (e aggregateEvent) AggregateVersion() int     { return e.version }
+func (e aggregateEvent) Position() int64           { return e.position }
@@ -0,0 +1,47 @@
+package memstore
+
+import (
+	"context"
+	"sync"
+
+	"eda-in-golang/internal/es"
+	"eda-in-golang/internal/registry"
+)
+
+// SnapshotRows keeps the snapshots of an es.SnapshotStore in memory
+type SnapshotRows struct {
+	rows map[streamKey]es.SnapshotRow
+	mu   sync.RWMutex
+}
+
+var _ es.SnapshotRows = (*SnapshotRows)(nil)
+
+// NewSnapshotStore is the in-memory counterpart of the postgres
+// NewSnapshotStore; snapshots are taken, ignored and migrated in the same way
+func NewSnapshotStore(registry registry.Registry, options ...es.SnapshotStoreOption) es.AggregateStoreMiddleware {
+	return es.NewSnapshotStore(NewSnapshotRows(), registry, options...)
+}
+
+func NewSnapshotRows() *SnapshotRows {
+	return &SnapshotRows{
+		rows: make(map[streamKey]es.SnapshotRow),
+	}
+}
+
+func (r *SnapshotRows) Get(_ context.Context, aggregateName, aggregateID string) (es.SnapshotRow, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	row, exists := r.rows[streamKey{name: aggregateName, id: aggregateID}]
+
+	return row, exists, nil
+}
+
+func (r *SnapshotRows) Put(_ context.Context, aggregateName, aggregateID string, row es.SnapshotRow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rows[streamKey{name: aggregateName, id: aggregateID}] = row
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package memstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stackus/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/es"
+	"eda-in-golang/internal/registry"
+	"eda-in-golang/internal/registry/serdes"
+)
+
+const counterAggregate = "test.Counter"
+
+type (
+	counter struct {
+		es.Aggregate
+		Count int
+	}
+
+	counterIncreased struct {
+		By int
+	}
+
+	counterV1 struct {
+		Count int
+	}
+)
+
+func (counter) Key() string          { return counterAggregate }
+func (counterIncreased) Key() string { return "test.CounterIncreased" }
+func (counterV1) SnapshotName() string {
+	return "test.CounterV1"
+}
+
+func (c *counter) Increase(by int) {
+	c.AddEvent(counterIncreased{}.Key(), &counterIncreased{By: by})
+}
+
+func (c *counter) ApplyEvent(event ddd.Event) error {
+	c.Count += event.Payload().(*counterIncreased).By
+	return nil
+}
+
+func (c *counter) ApplySnapshot(snapshot es.Snapshot) error {
+	c.Count = snapshot.(*counterV1).Count
+	return nil
+}
+
+func (c *counter) ToSnapshot() es.Snapshot {
+	return counterV1{Count: c.Count}
+}
+
+func newTestRegistry(t *testing.T) registry.Registry {
+	reg := registry.New()
+	serde := serdes.NewJsonSerde(reg)
+	require.NoError(t, serde.Register(counter{}))
+	require.NoError(t, serde.Register(counterIncreased{}))
+	require.NoError(t, serde.RegisterKey(counterV1{}.SnapshotName(), counterV1{}))
+
+	return reg
+}
+
+func TestEventStore_SaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	reg := newTestRegistry(t)
+	store := NewEventStore(reg)
+	repo := es.NewAggregateRepository[*counter](counterAggregate, reg, store)
+
+	c, err := repo.Load(ctx, "counter-id")
+	require.NoError(t, err)
+	c.Increase(2)
+	c.Increase(3)
+	require.NoError(t, repo.Save(ctx, c))
+
+	loaded, err := repo.Load(ctx, "counter-id")
+	require.NoError(t, err)
+	assert.Equal(t, 5, loaded.Count)
+	assert.Equal(t, 2, loaded.Version())
+
+	atVersion, err := repo.LoadAtVersion(ctx, "counter-id", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, atVersion.Count)
+
+	events, err := store.ReadAll(ctx, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(2), events[0].Position())
+	assert.Equal(t, 2, events[0].AggregateVersion())
+}
+
+func TestEventStore_ConcurrencyConflict(t *testing.T) {
+	ctx := context.Background()
+	reg := newTestRegistry(t)
+	store := NewEventStore(reg)
+	repo := es.NewAggregateRepository[*counter](counterAggregate, reg, store)
+
+	first, err := repo.Load(ctx, "counter-id")
+	require.NoError(t, err)
+	second, err := repo.Load(ctx, "counter-id")
+	require.NoError(t, err)
+
+	first.Increase(1)
+	require.NoError(t, store.Save(ctx, first))
+
+	second.Increase(10)
+	err = store.Save(ctx, second)
+	assert.ErrorIs(t, err, es.ErrConcurrencyConflict)
+
+	loaded, err := repo.Load(ctx, "counter-id")
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded.Count)
+}
+
+func TestAggregateRepository_Update(t *testing.T) {
+	ctx := context.Background()
+	reg := newTestRegistry(t)
+
+	// increase has another writer save the counter the first conflicts times
+	// the command is run, as if it had been saved while the command ran
+	increase := func(repo es.AggregateRepository[*counter], conflicts int) (func(c *counter) error, *int) {
+		attempts := 0
+		return func(c *counter) error {
+			attempts++
+			if attempts <= conflicts {
+				other, err := repo.Load(ctx, c.ID())
+				require.NoError(t, err)
+				other.Increase(100)
+				require.NoError(t, repo.Save(ctx, other))
+			}
+			c.Increase(1)
+			return nil
+		}, &attempts
+	}
+
+	t.Run("RetriesAConflict", func(t *testing.T) {
+		repo := es.NewAggregateRepository[*counter](counterAggregate, reg, NewEventStore(reg))
+		command, attempts := increase(repo, 2)
+
+		c, err := repo.Update(ctx, "counter-id", command)
+		require.NoError(t, err)
+		assert.Equal(t, 3, *attempts)
+		assert.Equal(t, 201, c.Count)
+
+		loaded, err := repo.Load(ctx, "counter-id")
+		require.NoError(t, err)
+		assert.Equal(t, 201, loaded.Count)
+		assert.Equal(t, 3, loaded.Version())
+	})
+	t.Run("GivesUp", func(t *testing.T) {
+		repo := es.NewAggregateRepository[*counter](counterAggregate, reg, NewEventStore(reg), es.UpdateRetries(1))
+		command, attempts := increase(repo, 5)
+
+		_, err := repo.Update(ctx, "counter-id", command)
+		assert.ErrorIs(t, err, es.ErrConcurrencyConflict)
+		assert.Equal(t, 2, *attempts)
+
+		loaded, err := repo.Load(ctx, "counter-id")
+		require.NoError(t, err)
+		assert.Equal(t, 200, loaded.Count)
+	})
+	t.Run("CommandFails", func(t *testing.T) {
+		repo := es.NewAggregateRepository[*counter](counterAggregate, reg, NewEventStore(reg))
+		attempts := 0
+
+		_, err := repo.Update(ctx, "counter-id", func(c *counter) error {
+			attempts++
+			return errors.ErrBadRequest
+		})
+		assert.ErrorIs(t, err, errors.ErrBadRequest)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestSnapshotStore_Load(t *testing.T) {
+	ctx := context.Background()
+	reg := newTestRegistry(t)
+	metrics := es.NewSnapshotCounters()
+	store := es.AggregateStoreWithMiddleware(
+		NewEventStore(reg),
+		NewSnapshotStore(reg, es.WithSnapshotStrategy(counterAggregate, es.EveryNEvents(2)), es.WithSnapshotMetrics(metrics)),
+	)
+	repo := es.NewAggregateRepository[*counter](counterAggregate, reg, store)
+
+	c, err := repo.Load(ctx, "counter-id")
+	require.NoError(t, err)
+	c.Increase(1)
+	c.Increase(2)
+	require.NoError(t, repo.Save(ctx, c))
+
+	c.Increase(3)
+	require.NoError(t, repo.Save(ctx, c))
+
+	loaded, err := repo.Load(ctx, "counter-id")
+	require.NoError(t, err)
+	assert.Equal(t, 6, loaded.Count)
+	assert.Equal(t, 3, loaded.Version())
+
+	counts := metrics.Counts(counterAggregate)
+	assert.Equal(t, int64(1), counts.Taken)
+	assert.Equal(t, int64(1), counts.Hits)
+}
@@ -0,0 +1,118 @@
+package memstream
+
+import (
+	"context"
+	"time"
+
+	"eda-in-golang/internal/am"
+)
+
+type deadLetterMessage struct {
+	id         string
+	name       string
+	subject    string
+	data       []byte
+	reason     string
+	deliveries int
+	groupName  string
+	deadAt     time.Time
+}
+
+var _ am.DeadLetterMessage = (*deadLetterMessage)(nil)
+
+var _ am.DeadLetterQueue = (*Stream)(nil)
+
+func (m deadLetterMessage) ID() string          { return m.id }
+func (m deadLetterMessage) MessageName() string { return m.name }
+func (m deadLetterMessage) Subject() string     { return m.subject }
+func (m deadLetterMessage) Data() []byte        { return m.data }
+func (m deadLetterMessage) Reason() string      { return m.reason }
+func (m deadLetterMessage) Deliveries() int     { return m.deliveries }
+func (m deadLetterMessage) GroupName() string   { return m.groupName }
+func (m deadLetterMessage) DeadAt() time.Time   { return m.deadAt }
+
+// DeadLetters returns up to limit messages waiting on the dead-letter subject;
+// a limit of zero returns them all
+func (s *Stream) DeadLetters(_ context.Context, subject string, limit int) ([]am.DeadLetterMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var msgs []am.DeadLetterMessage
+	for _, msg := range s.deadLetters[subject] {
+		if limit > 0 && len(msgs) >= limit {
+			break
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+// Replay republishes the dead-lettered messages onto their original subjects
+// and removes them from the dead-letter subject; with no ids every message is
+// replayed. A replayed message is handled only by the group it failed in.
+func (s *Stream) Replay(ctx context.Context, subject string, ids ...string) error {
+	var filter map[string]struct{}
+	if len(ids) > 0 {
+		filter = make(map[string]struct{}, len(ids))
+		for _, id := range ids {
+			filter[id] = struct{}{}
+		}
+	}
+
+	s.mu.Lock()
+	var replays, remaining []deadLetterMessage
+	for _, msg := range s.deadLetters[subject] {
+		if _, exists := filter[msg.id]; filter == nil || exists {
+			replays = append(replays, msg)
+		} else {
+			remaining = append(remaining, msg)
+		}
+	}
+	s.deadLetters[subject] = remaining
+	s.mu.Unlock()
+
+	for _, msg := range replays {
+		if msg.groupName == "" {
+			if err := s.Publish(ctx, msg.subject, msg); err != nil {
+				return err
+			}
+			continue
+		}
+
+		s.mu.Lock()
+		c, exists := s.groups[msg.groupName]
+		s.mu.Unlock()
+		if !exists {
+			continue
+		}
+		c.enqueue(&delivery{
+			id:      msg.id,
+			name:    msg.name,
+			subject: msg.subject,
+			data:    msg.data,
+		})
+	}
+
+	return nil
+}
+
+func (s *Stream) deadLetter(cfg am.SubscriberConfig, d *delivery, reason error) {
+	msg := deadLetterMessage{
+		id:         d.id,
+		name:       d.name,
+		subject:    d.subject,
+		data:       d.data,
+		reason:     "message was not acknowledged",
+		deliveries: d.attempts,
+		groupName:  cfg.GroupName(),
+		deadAt:     time.Now(),
+	}
+	if reason != nil {
+		msg.reason = reason.Error()
+	}
+
+	s.mu.Lock()
+	s.deadLetters[cfg.DeadLetter()] = append(s.deadLetters[cfg.DeadLetter()], msg)
+	s.mu.Unlock()
+}
@@ -0,0 +1,81 @@
+package memstream
+
+import (
+	"sync"
+
+	"eda-in-golang/internal/am"
+)
+
+type outcome int
+
+const (
+	outcomeNone outcome = iota
+	outcomeAck
+	outcomeNAck
+	outcomeKill
+	outcomeExpired
+)
+
+type rawMessage struct {
+	id      string
+	name    string
+	subject string
+	data    []byte
+	result  outcome
+	extendC chan struct{}
+	mu      sync.Mutex
+}
+
+var _ am.IncomingRawMessage = (*rawMessage)(nil)
+
+func (m *rawMessage) ID() string          { return m.id }
+func (m *rawMessage) MessageName() string { return m.name }
+func (m *rawMessage) Subject() string     { return m.subject }
+func (m *rawMessage) Data() []byte        { return m.data }
+
+func (m *rawMessage) Ack() error {
+	m.settle(outcomeAck)
+	return nil
+}
+
+func (m *rawMessage) NAck() error {
+	m.settle(outcomeNAck)
+	return nil
+}
+
+func (m *rawMessage) Extend() error {
+	select {
+	case m.extendC <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (m *rawMessage) Kill() error {
+	m.settle(outcomeKill)
+	return nil
+}
+
+// expire settles an unacknowledged message once its ack wait has run out
+func (m *rawMessage) expire() bool {
+	return m.settle(outcomeExpired)
+}
+
+func (m *rawMessage) settle(o outcome) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.result != outcomeNone {
+		return false
+	}
+	m.result = o
+
+	return true
+}
+
+func (m *rawMessage) outcome() outcome {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.result
+}
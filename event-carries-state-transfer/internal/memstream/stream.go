@@ -0,0 +1,380 @@
+package memstream
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stackus/errors"
+
+	"eda-in-golang/internal/am"
+)
+
+// shardQueueSize is how many messages each shard worker may have waiting
+const shardQueueSize = 8
+
+// ErrStreamClosed is returned when publishing to or subscribing on a stream
+// that has been closed
+var ErrStreamClosed = errors.Wrap(errors.ErrUnavailable, "the stream is closed")
+
+// Stream is an in-process broker that behaves like jetstream.Stream without
+// requiring a NATS server; useful for tests. The monolith runs on it when
+// TRANSPORT is memstream; messages are not kept once they have been handled.
+//
+// Every consumer is handled by its own goroutines; Close stops them.
+type Stream struct {
+	consumers   []*consumer
+	groups      map[string]*consumer
+	deadLetters map[string][]deadLetterMessage
+	ctx         context.Context
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+	mu          sync.Mutex
+	logger      zerolog.Logger
+}
+
+type (
+	consumer struct {
+		stream  *Stream
+		cfg     am.SubscriberConfig
+		members []member
+		next    int
+		queue   []*delivery
+		mu      sync.Mutex
+		ready   chan struct{}
+		logger  zerolog.Logger
+	}
+
+	member struct {
+		pattern string
+		handler am.MessageHandler[am.IncomingRawMessage]
+	}
+
+	delivery struct {
+		id       string
+		name     string
+		subject  string
+		data     []byte
+		attempts int
+	}
+)
+
+var _ am.RawMessageStream = (*Stream)(nil)
+
+func NewStream(logger zerolog.Logger) *Stream {
+	s := &Stream{
+		groups:      make(map[string]*consumer),
+		deadLetters: make(map[string][]deadLetterMessage),
+		logger:      logger,
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	return s
+}
+
+// Close stops the consumers; the contexts of the handlers that are running are
+// canceled and Close waits for them to return. The messages that have not been
+// handled yet are dropped.
+func (s *Stream) Close() error {
+	s.mu.Lock()
+	s.cancel()
+	s.mu.Unlock()
+
+	s.wg.Wait()
+
+	return nil
+}
+
+func (s *Stream) Publish(_ context.Context, topicName string, rawMsg am.RawMessage) error {
+	s.mu.Lock()
+	if s.ctx.Err() != nil {
+		s.mu.Unlock()
+		return ErrStreamClosed
+	}
+	consumers := make([]*consumer, len(s.consumers))
+	copy(consumers, s.consumers)
+	s.mu.Unlock()
+
+	for _, c := range consumers {
+		if !c.matches(topicName) {
+			continue
+		}
+		c.enqueue(&delivery{
+			id:      rawMsg.ID(),
+			name:    rawMsg.MessageName(),
+			subject: topicName,
+			data:    rawMsg.Data(),
+		})
+	}
+
+	return nil
+}
+
+func (s *Stream) Subscribe(topicName string, handler am.MessageHandler[am.IncomingRawMessage], options ...am.SubscriberOption) error {
+	subCfg := am.NewSubscriberConfig(options)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx.Err() != nil {
+		return ErrStreamClosed
+	}
+
+	m := member{
+		pattern: topicName,
+		handler: handler,
+	}
+
+	// subscribers sharing a group name share a single consumer; each message
+	// is handled by only one of its members
+	if groupName := subCfg.GroupName(); groupName != "" {
+		if c, exists := s.groups[groupName]; exists {
+			c.mu.Lock()
+			c.members = append(c.members, m)
+			c.mu.Unlock()
+			return nil
+		}
+	}
+
+	c := &consumer{
+		stream:  s,
+		cfg:     subCfg,
+		members: []member{m},
+		ready:   make(chan struct{}, 1),
+		logger:  s.logger,
+	}
+	s.consumers = append(s.consumers, c)
+	if groupName := subCfg.GroupName(); groupName != "" {
+		s.groups[groupName] = c
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		c.process()
+	}()
+
+	return nil
+}
+
+func (c *consumer) matches(subject string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, m := range c.members {
+		if subjectMatches(m.pattern, subject) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *consumer) enqueue(d *delivery) {
+	c.mu.Lock()
+	c.queue = append(c.queue, d)
+	c.mu.Unlock()
+
+	select {
+	case c.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (c *consumer) dequeue() *delivery {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.queue) == 0 {
+		return nil
+	}
+
+	d := c.queue[0]
+	c.queue = c.queue[1:]
+
+	return d
+}
+
+// handlerFor picks the next member interested in the subject; members of a
+// group take turns
+func (c *consumer) handlerFor(subject string) am.MessageHandler[am.IncomingRawMessage] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := 0; i < len(c.members); i++ {
+		m := c.members[(c.next+i)%len(c.members)]
+		if subjectMatches(m.pattern, subject) {
+			c.next = (c.next + i + 1) % len(c.members)
+			return m.handler
+		}
+	}
+
+	return nil
+}
+
+func (c *consumer) process() {
+	handle := c.handle
+	if workers := c.cfg.ShardWorkers(); workers > 1 {
+		var stop func()
+		handle, stop = c.shard(workers)
+		defer stop()
+	}
+
+	done := c.stream.ctx.Done()
+	for {
+		select {
+		case <-done:
+			return
+		case <-c.ready:
+			for d := c.dequeue(); d != nil && c.stream.ctx.Err() == nil; d = c.dequeue() {
+				handle(d)
+			}
+		}
+	}
+}
+
+func (c *consumer) handle(d *delivery) {
+	if c.stream.ctx.Err() != nil {
+		return
+	}
+
+	if redeliver, reason := c.deliver(d); redeliver && c.stream.ctx.Err() == nil {
+		c.redeliver(d, reason)
+	}
+}
+
+// shard hands each message to the worker picked by its shard key; each worker
+// handles its messages one at a time in the order they were queued. The
+// workers stop once their queues have been drained after stop is called.
+func (c *consumer) shard(workers int) (func(d *delivery), func()) {
+	queues := make([]chan *delivery, workers)
+	for i := range queues {
+		queues[i] = make(chan *delivery, shardQueueSize)
+		c.stream.wg.Add(1)
+		go func(deliveries <-chan *delivery) {
+			defer c.stream.wg.Done()
+			for d := range deliveries {
+				c.handle(d)
+			}
+		}(queues[i])
+	}
+
+	return func(d *delivery) {
+			queues[am.Shard(&rawMessage{data: d.data}, c.cfg.ShardKey(), workers)] <- d
+		}, func() {
+			for _, queue := range queues {
+				close(queue)
+			}
+		}
+}
+
+func (c *consumer) redeliver(d *delivery, reason error) {
+	policy := c.cfg.RetryPolicy()
+
+	if policy != nil && reason != nil && policy.IsPermanent(reason) {
+		c.drop(d, reason, "message failed permanently; dropping")
+		return
+	}
+
+	if maxDeliver := c.cfg.MaxRedeliver(); maxDeliver > 0 && d.attempts >= maxDeliver {
+		c.drop(d, reason, "message exceeded max deliveries; dropping")
+		return
+	}
+
+	if policy != nil {
+		time.AfterFunc(policy.Delay(d.attempts), func() { c.enqueue(d) })
+		return
+	}
+
+	c.enqueue(d)
+}
+
+func (c *consumer) drop(d *delivery, reason error, logMsg string) {
+	if c.cfg.DeadLetter() != "" {
+		c.stream.deadLetter(c.cfg, d, reason)
+		return
+	}
+
+	c.logger.Debug().
+		Str("MessageID", d.id).
+		Int("Attempts", d.attempts).
+		Msg(logMsg)
+}
+
+// deliver hands the message to a handler and reports whether it needs to be
+// redelivered and why
+func (c *consumer) deliver(d *delivery) (bool, error) {
+	handler := c.handlerFor(d.subject)
+	if handler == nil {
+		return false, nil
+	}
+
+	d.attempts++
+
+	msg := &rawMessage{
+		id:      d.id,
+		name:    d.name,
+		subject: d.subject,
+		data:    d.data,
+		extendC: make(chan struct{}, 1),
+	}
+
+	ctx, cancel := context.WithCancel(c.stream.ctx)
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- handler.HandleMessage(ctx, msg)
+	}()
+
+	if c.cfg.AckType() == am.AckTypeAuto {
+		_ = msg.Ack()
+	}
+
+	ackWait := time.NewTimer(c.cfg.AckWait())
+	defer ackWait.Stop()
+
+	for {
+		select {
+		case err := <-errc:
+			if err == nil {
+				_ = msg.Ack()
+			} else {
+				c.logger.Debug().Err(err).Str("MessageID", d.id).Msg("message handler returned an error")
+				_ = msg.NAck()
+			}
+			return msg.outcome() == outcomeNAck, err
+		case <-msg.extendC:
+			ackWait.Reset(c.cfg.AckWait())
+		case <-ackWait.C:
+			// the handler took too long; anything it does now is ignored
+			if msg.expire() {
+				return true, context.DeadlineExceeded
+			}
+			return msg.outcome() == outcomeNAck, nil
+		}
+	}
+}
+
+// subjectMatches reports whether a subject matches a NATS style pattern;
+// "*" matches a single token and ">" matches one or more trailing tokens
+func subjectMatches(pattern, subject string) bool {
+	pTokens := strings.Split(pattern, ".")
+	sTokens := strings.Split(subject, ".")
+
+	for i, pToken := range pTokens {
+		if pToken == ">" {
+			return len(sTokens) > i
+		}
+		if i >= len(sTokens) {
+			return false
+		}
+		if pToken != "*" && pToken != sTokens[i] {
+			return false
+		}
+	}
+
+	return len(pTokens) == len(sTokens)
+}
@@ -0,0 +1,375 @@
+package memstream
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stackus/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/ddd"
+)
+
+type testMessage struct {
+	id      string
+	name    string
+	subject string
+	data    []byte
+}
+
+func (m testMessage) ID() string          { return m.id }
+func (m testMessage) MessageName() string { return m.name }
+func (m testMessage) Subject() string     { return m.subject }
+func (m testMessage) Data() []byte        { return m.data }
+
+func TestSubjectMatches(t *testing.T) {
+	type args struct {
+		pattern string
+		subject string
+	}
+	type testCase struct {
+		args     args
+		expected bool
+	}
+
+	tests := map[string]testCase{
+		"exact_match": {
+			args:     args{pattern: "mallbots.orders", subject: "mallbots.orders"},
+			expected: true,
+		},
+		"exact_mismatch": {
+			args:     args{pattern: "mallbots.orders", subject: "mallbots.stores"},
+			expected: false,
+		},
+		"single_token_wildcard": {
+			args:     args{pattern: "mallbots.*.events", subject: "mallbots.orders.events"},
+			expected: true,
+		},
+		"single_token_wildcard_too_deep": {
+			args:     args{pattern: "mallbots.*", subject: "mallbots.orders.events"},
+			expected: false,
+		},
+		"tail_wildcard": {
+			args:     args{pattern: "mallbots.>", subject: "mallbots.orders.events"},
+			expected: true,
+		},
+		"tail_wildcard_requires_a_token": {
+			args:     args{pattern: "mallbots.>", subject: "mallbots"},
+			expected: false,
+		},
+		"shorter_subject": {
+			args:     args{pattern: "mallbots.orders", subject: "mallbots"},
+			expected: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, subjectMatches(tc.args.pattern, tc.args.subject))
+		})
+	}
+}
+
+func TestStream_GroupName(t *testing.T) {
+	stream := NewStream(zerolog.Nop())
+
+	var wg sync.WaitGroup
+	var grouped, ungrouped int32
+	wg.Add(3)
+
+	groupHandler := am.RawMessageHandlerFunc(func(ctx context.Context, msg am.IncomingRawMessage) error {
+		atomic.AddInt32(&grouped, 1)
+		wg.Done()
+		return nil
+	})
+
+	assert.NoError(t, stream.Subscribe("mallbots.>", groupHandler, am.GroupName("group")))
+	assert.NoError(t, stream.Subscribe("mallbots.>", groupHandler, am.GroupName("group")))
+	assert.NoError(t, stream.Subscribe("mallbots.orders", am.RawMessageHandlerFunc(func(ctx context.Context, msg am.IncomingRawMessage) error {
+		atomic.AddInt32(&ungrouped, 1)
+		wg.Done()
+		return nil
+	})))
+
+	assert.NoError(t, stream.Publish(context.Background(), "mallbots.orders", testMessage{id: "1", name: "test"}))
+	assert.NoError(t, stream.Publish(context.Background(), "mallbots.stores", testMessage{id: "2", name: "test"}))
+
+	waitFor(t, &wg)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&grouped))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&ungrouped))
+}
+
+func TestStream_Redelivery(t *testing.T) {
+	type args struct {
+		options []am.SubscriberOption
+		handler func(msg am.IncomingRawMessage) error
+	}
+	type testCase struct {
+		args     args
+		expected int32
+	}
+
+	tests := map[string]testCase{
+		"manual_ack_success": {
+			args: args{
+				handler: func(msg am.IncomingRawMessage) error { return nil },
+			},
+			expected: 1,
+		},
+		"manual_ack_redelivers_until_max": {
+			args: args{
+				options: []am.SubscriberOption{am.MaxRedeliver(3)},
+				handler: func(msg am.IncomingRawMessage) error { return fmt.Errorf("failed") },
+			},
+			expected: 3,
+		},
+		"auto_ack_does_not_redeliver": {
+			args: args{
+				options: []am.SubscriberOption{am.AckTypeAuto, am.MaxRedeliver(3)},
+				handler: func(msg am.IncomingRawMessage) error { return fmt.Errorf("failed") },
+			},
+			expected: 1,
+		},
+		"kill_does_not_redeliver": {
+			args: args{
+				options: []am.SubscriberOption{am.MaxRedeliver(3)},
+				handler: func(msg am.IncomingRawMessage) error {
+					_ = msg.Kill()
+					return fmt.Errorf("failed")
+				},
+			},
+			expected: 1,
+		},
+		"retry_policy_redelivers_transient_errors": {
+			args: args{
+				options: []am.SubscriberOption{
+					am.MaxRedeliver(3),
+					am.NewRetryPolicy(am.RetryInitialDelay(time.Millisecond), am.RetryJitter(0)),
+				},
+				handler: func(msg am.IncomingRawMessage) error { return fmt.Errorf("failed") },
+			},
+			expected: 3,
+		},
+		"retry_policy_stops_on_permanent_errors": {
+			args: args{
+				options: []am.SubscriberOption{
+					am.MaxRedeliver(3),
+					am.NewRetryPolicy(am.RetryInitialDelay(time.Millisecond)),
+				},
+				handler: func(msg am.IncomingRawMessage) error {
+					return errors.Wrap(errors.ErrBadRequest, "bad message")
+				},
+			},
+			expected: 1,
+		},
+		"ack_wait_redelivers": {
+			args: args{
+				options: []am.SubscriberOption{am.AckWait(10 * time.Millisecond), am.MaxRedeliver(2)},
+				handler: func(msg am.IncomingRawMessage) error {
+					time.Sleep(50 * time.Millisecond)
+					return nil
+				},
+			},
+			expected: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stream := NewStream(zerolog.Nop())
+
+			var deliveries int32
+			err := stream.Subscribe("mallbots.orders", am.RawMessageHandlerFunc(func(ctx context.Context, msg am.IncomingRawMessage) error {
+				atomic.AddInt32(&deliveries, 1)
+				return tc.args.handler(msg)
+			}), tc.args.options...)
+			assert.NoError(t, err)
+
+			assert.NoError(t, stream.Publish(context.Background(), "mallbots.orders", testMessage{id: "1", name: "test"}))
+
+			assert.Eventually(t, func() bool {
+				return atomic.LoadInt32(&deliveries) == tc.expected
+			}, time.Second, 5*time.Millisecond)
+			// give the stream a chance to deliver anything extra
+			time.Sleep(100 * time.Millisecond)
+			assert.Equal(t, tc.expected, atomic.LoadInt32(&deliveries))
+		})
+	}
+}
+
+func TestStream_DeadLetter(t *testing.T) {
+	stream := NewStream(zerolog.Nop())
+
+	var deliveries int32
+	var failing atomic.Bool
+	failing.Store(true)
+
+	err := stream.Subscribe("mallbots.orders", am.RawMessageHandlerFunc(func(ctx context.Context, msg am.IncomingRawMessage) error {
+		atomic.AddInt32(&deliveries, 1)
+		if failing.Load() {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	}), am.GroupName("orders"), am.MaxRedeliver(2), am.DeadLetter("mallbots.dlq"))
+	assert.NoError(t, err)
+
+	// another group on the subject that handled the message the first time
+	var audited int32
+	err = stream.Subscribe("mallbots.orders", am.RawMessageHandlerFunc(func(ctx context.Context, msg am.IncomingRawMessage) error {
+		atomic.AddInt32(&audited, 1)
+		return nil
+	}), am.GroupName("audit"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, stream.Publish(context.Background(), "mallbots.orders", testMessage{id: "1", name: "test", data: []byte("data")}))
+
+	var msgs []am.DeadLetterMessage
+	assert.Eventually(t, func() bool {
+		msgs, err = stream.DeadLetters(context.Background(), "mallbots.dlq", 0)
+		return err == nil && len(msgs) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, "1", msgs[0].ID())
+	assert.Equal(t, "mallbots.orders", msgs[0].Subject())
+	assert.Equal(t, []byte("data"), msgs[0].Data())
+	assert.Equal(t, "failed", msgs[0].Reason())
+	assert.Equal(t, 2, msgs[0].Deliveries())
+	assert.Equal(t, "orders", msgs[0].GroupName())
+
+	failing.Store(false)
+	assert.NoError(t, stream.Replay(context.Background(), "mallbots.dlq"))
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&deliveries) == 3
+	}, time.Second, 5*time.Millisecond)
+	// only the group the message failed in is sent the replay
+	assert.Equal(t, int32(1), atomic.LoadInt32(&audited))
+
+	msgs, err = stream.DeadLetters(context.Background(), "mallbots.dlq", 0)
+	assert.NoError(t, err)
+	assert.Empty(t, msgs)
+}
+
+func TestStream_ShardBy(t *testing.T) {
+	const keys = 4
+	const perKey = 25
+
+	stream := NewStream(zerolog.Nop())
+
+	var mu sync.Mutex
+	var active, maxActive int
+	handled := make(map[string][]int)
+	var wg sync.WaitGroup
+	wg.Add(keys * perKey)
+
+	err := stream.Subscribe("mallbots.orders", am.RawMessageHandlerFunc(func(ctx context.Context, msg am.IncomingRawMessage) error {
+		defer wg.Done()
+
+		mu.Lock()
+		active++
+		maxActive = max(maxActive, active)
+		mu.Unlock()
+
+		time.Sleep(time.Duration(rand.Intn(2000)) * time.Microsecond)
+
+		metadata := am.RawMessageMetadata(msg)
+		key := metadata.Get(ddd.AggregateIDKey).(string)
+		sequence, _ := strconv.Atoi(metadata.Get("sequence").(string))
+
+		mu.Lock()
+		defer mu.Unlock()
+		active--
+		handled[key] = append(handled[key], sequence)
+		return nil
+	}), am.GroupName("orders"), am.ShardBy{Key: ddd.AggregateIDKey, Workers: keys})
+	assert.NoError(t, err)
+
+	for i := range perKey {
+		for k := range keys {
+			metadata, err := structpb.NewStruct(map[string]any{
+				ddd.AggregateIDKey: fmt.Sprintf("order-%d", k),
+				"sequence":         strconv.Itoa(i),
+			})
+			assert.NoError(t, err)
+			data, err := proto.Marshal(&am.EventMessageData{Metadata: metadata})
+			assert.NoError(t, err)
+			assert.NoError(t, stream.Publish(context.Background(), "mallbots.orders", testMessage{
+				id:   fmt.Sprintf("%d-%d", k, i),
+				name: "test",
+				data: data,
+			}))
+		}
+	}
+
+	waitFor(t, &wg)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Greater(t, maxActive, 1, "the messages were not handled in parallel")
+	for key, sequences := range handled {
+		assert.Len(t, sequences, perKey)
+		assert.IsIncreasing(t, sequences, "the messages for %s were handled out of order", key)
+	}
+}
+
+func waitFor(t *testing.T, wg *sync.WaitGroup) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for messages")
+	}
+}
+
+func TestStream_Close(t *testing.T) {
+	stream := NewStream(zerolog.Nop())
+
+	started := make(chan struct{})
+	var canceled atomic.Bool
+
+	handler := am.RawMessageHandlerFunc(func(ctx context.Context, msg am.IncomingRawMessage) error {
+		close(started)
+		<-ctx.Done()
+		canceled.Store(true)
+		return ctx.Err()
+	})
+	assert.NoError(t, stream.Subscribe("mallbots.orders", handler, am.GroupName("orders")))
+	assert.NoError(t, stream.Subscribe("mallbots.stores", am.RawMessageHandlerFunc(func(ctx context.Context, msg am.IncomingRawMessage) error {
+		return nil
+	}), am.GroupName("stores"), am.ShardBy{Key: ddd.AggregateIDKey, Workers: 2}))
+
+	assert.NoError(t, stream.Publish(context.Background(), "mallbots.orders", testMessage{id: "1", name: "test"}))
+	<-started
+
+	closed := make(chan error, 1)
+	go func() { closed <- stream.Close() }()
+
+	select {
+	case err := <-closed:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the stream did not close")
+	}
+
+	// the running handler was canceled and waited on
+	assert.True(t, canceled.Load())
+	assert.ErrorIs(t, stream.Publish(context.Background(), "mallbots.orders", testMessage{id: "2", name: "test"}), ErrStreamClosed)
+	assert.ErrorIs(t, stream.Subscribe("mallbots.orders", handler), ErrStreamClosed)
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 
+	"eda-in-golang/internal/am"
 	"eda-in-golang/internal/config"
+	"eda-in-golang/internal/jetstream"
 	"eda-in-golang/internal/waiter"
 )
 
@@ -18,6 +20,9 @@ type Monolith interface {
 	DB() *sql.DB
 	JS() nats.JetStreamContext
 	Logger() zerolog.Logger
+	// Stream returns the stream the modules exchange messages over; the
+	// options apply when the configured transport is JetStream
+	Stream(logger zerolog.Logger, options ...jetstream.StreamOption) am.RawMessageStream
 	Mux() *chi.Mux
 	RPC() *grpc.Server
 	Waiter() waiter.Waiter
@@ -3,10 +3,13 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stackus/errors"
 
 	"eda-in-golang/internal/ddd"
@@ -19,39 +22,78 @@ type (
 		tableName string
 		db        DB
 		registry  registry.Registry
+		upcasters *es.Upcasters
 	}
 
+	EventStoreOption func(s *EventStore)
+
 	aggregateEvent struct {
-		id         string
-		name       string
-		payload    ddd.EventPayload
-		occurredAt time.Time
-		aggregate  es.EventSourcedAggregate
-		version    int
+		id            string
+		name          string
+		payload       ddd.EventPayload
+		metadata      ddd.Metadata
+		occurredAt    time.Time
+		aggregateName string
+		aggregateID   string
+		version       int
+		position      int64
 	}
 )
 
 var _ es.AggregateStore = (*EventStore)(nil)
 
-var _ ddd.AggregateEvent = (*aggregateEvent)(nil)
+const eventStoreSavepoint = "event_store_save"
+
+var _ es.EventFeed = (*EventStore)(nil)
+
+var _ es.HistoryReader = (*EventStore)(nil)
+
+var _ es.StoredEvent = (*aggregateEvent)(nil)
 
-func NewEventStore(tableName string, db DB, registry registry.Registry) EventStore {
-	return EventStore{
+func NewEventStore(tableName string, db DB, registry registry.Registry, options ...EventStoreOption) EventStore {
+	s := EventStore{
 		tableName: tableName,
 		db:        db,
 		registry:  registry,
 	}
+
+	for _, option := range options {
+		option(&s)
+	}
+
+	return s
+}
+
+// WithUpcasters brings events stored with an older schema version up to date
+// before they are deserialized
+func WithUpcasters(upcasters *es.Upcasters) EventStoreOption {
+	return func(s *EventStore) {
+		s.upcasters = upcasters
+	}
 }
 
 func (s EventStore) Load(ctx context.Context, aggregate es.EventSourcedAggregate) (err error) {
-	const query = `SELECT stream_version, event_id, event_name, event_data, occurred_at FROM %s WHERE stream_id = $1 AND stream_name = $2 AND stream_version > $3 ORDER BY stream_version ASC`
+	const query = `SELECT stream_version, event_id, event_name, event_version, event_data, metadata, occurred_at FROM %s WHERE stream_id = $1 AND stream_name = $2 AND stream_version > $3`
 
 	aggregateID := aggregate.ID()
 	aggregateName := aggregate.AggregateName()
 
+	conditions := query
+	args := []any{aggregateID, aggregateName, aggregate.Version()}
+	if bound, ok := es.LoadBoundFrom(ctx); ok {
+		if bound.Version > 0 {
+			args = append(args, bound.Version)
+			conditions += fmt.Sprintf(" AND stream_version <= $%d", len(args))
+		}
+		if !bound.AsOf.IsZero() {
+			args = append(args, bound.AsOf)
+			conditions += fmt.Sprintf(" AND occurred_at <= $%d", len(args))
+		}
+	}
+
 	var rows *sql.Rows
 
-	rows, err = s.db.QueryContext(ctx, s.table(query), aggregateID, aggregateName, aggregate.Version())
+	rows, err = s.db.QueryContext(ctx, s.table(conditions+" ORDER BY stream_version ASC"), args...)
 	if err != nil {
 		return err
 	}
@@ -63,46 +105,146 @@ func (s EventStore) Load(ctx context.Context, aggregate es.EventSourcedAggregate
 	}(rows)
 
 	for rows.Next() {
-		var eventID, eventName string
-		var payloadData []byte
-		var aggregateVersion int
-		var occurredAt time.Time
-		err := rows.Scan(&aggregateVersion, &eventID, &eventName, &payloadData, &occurredAt)
+		var event aggregateEvent
+		event, err = s.scanEvent(rows, aggregateName, aggregateID)
 		if err != nil {
 			return err
 		}
 
-		var payload interface{}
-		payload, err = s.registry.Deserialize(eventName, payloadData)
-		if err != nil {
+		if err = es.LoadEvent(aggregate, event); err != nil {
 			return err
 		}
+	}
+
+	return rows.Err()
+}
+
+// ReadAll returns up to limit events from every stream in the table, starting
+// at fromPosition and in the order they were stored. Save commits positions in
+// order, so a gap between the positions read is a rolled back save and will
+// not be filled later.
+func (s EventStore) ReadAll(ctx context.Context, fromPosition int64, limit int) ([]es.StoredEvent, error) {
+	const query = `SELECT stream_id, stream_name, stream_version, event_id, event_name, event_version, event_data, metadata, occurred_at, global_position FROM %s WHERE global_position >= $1 ORDER BY global_position ASC LIMIT %d`
 
-		event := aggregateEvent{
-			id:         eventID,
-			name:       eventName,
-			payload:    payload,
-			aggregate:  aggregate,
-			version:    aggregateVersion,
-			occurredAt: occurredAt,
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(query, s.tableName, limit), fromPosition)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			err = errors.Wrap(err, "closing event rows")
 		}
+	}(rows)
 
-		if err = es.LoadEvent(aggregate, event); err != nil {
-			return err
+	var events []es.StoredEvent
+
+	for rows.Next() {
+		var event aggregateEvent
+		event, err = s.scanEvent(rows, "", "")
+		if err != nil {
+			return nil, err
 		}
+
+		events = append(events, event)
 	}
 
-	return nil
+	return events, rows.Err()
+}
+
+// History returns the events of the aggregate with their payloads rendered as
+// JSON; the payloads are upcast and deserialized as they would be when loaded
+func (s EventStore) History(ctx context.Context, aggregateName, aggregateID string) ([]es.HistoryEvent, error) {
+	const query = `SELECT stream_version, event_id, event_name, event_version, event_data, metadata, occurred_at FROM %s WHERE stream_id = $1 AND stream_name = $2 ORDER BY stream_version ASC`
+
+	rows, err := s.db.QueryContext(ctx, s.table(query), aggregateID, aggregateName)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			err = errors.Wrap(err, "closing event rows")
+		}
+	}(rows)
+
+	var history []es.HistoryEvent
+
+	for rows.Next() {
+		var event aggregateEvent
+		event, err = s.scanEvent(rows, aggregateName, aggregateID)
+		if err != nil {
+			return nil, err
+		}
+
+		var payload []byte
+		if payload, err = es.RenderPayload(event.payload); err != nil {
+			return nil, err
+		}
+
+		history = append(history, es.HistoryEvent{
+			ID:         event.id,
+			Name:       event.name,
+			Version:    event.version,
+			OccurredAt: event.occurredAt,
+			Payload:    payload,
+			Metadata:   event.metadata,
+		})
+	}
+
+	return history, rows.Err()
+}
+
+// scanEvent reads an event row; rows from a single stream are read without the
+// stream columns, while rows from ReadAll include them along with the position
+func (s EventStore) scanEvent(rows *sql.Rows, aggregateName, aggregateID string) (aggregateEvent, error) {
+	var event aggregateEvent
+	var eventVersion int
+	var payloadData, metadataData []byte
+
+	dest := []any{&event.version, &event.id, &event.name, &eventVersion, &payloadData, &metadataData, &event.occurredAt}
+	if aggregateName == "" {
+		dest = append([]any{&event.aggregateID, &event.aggregateName}, dest...)
+		dest = append(dest, &event.position)
+	} else {
+		event.aggregateName = aggregateName
+		event.aggregateID = aggregateID
+	}
+
+	if err := rows.Scan(dest...); err != nil {
+		return event, err
+	}
+
+	payloadData, err := s.upcasters.Upcast(event.name, eventVersion, payloadData)
+	if err != nil {
+		return event, err
+	}
+
+	event.metadata = make(ddd.Metadata)
+	if err = json.Unmarshal(metadataData, &event.metadata); err != nil {
+		return event, err
+	}
+	// JSON does not keep the types of the aggregate keys; use the stream values
+	event.metadata.Set(ddd.AggregateNameKey, event.aggregateName)
+	event.metadata.Set(ddd.AggregateIDKey, event.aggregateID)
+	event.metadata.Set(ddd.AggregateVersionKey, event.version)
+
+	event.payload, err = s.registry.Deserialize(event.name, payloadData)
+	if err != nil {
+		return event, err
+	}
+
+	return event, nil
 }
 
 func (s EventStore) Save(ctx context.Context, aggregate es.EventSourcedAggregate) (err error) {
-	const query = `INSERT INTO %s (stream_id, stream_name, stream_version, event_id, event_name, event_data, occurred_at) VALUES`
+	const query = `INSERT INTO %s (stream_id, stream_name, stream_version, event_id, event_name, event_version, event_data, metadata, occurred_at) VALUES`
 
 	aggregateID := aggregate.ID()
 	aggregateName := aggregate.AggregateName()
 
 	placeholders := make([]string, len(aggregate.Events()))
-	values := make([]any, len(aggregate.Events())*7)
+	values := make([]any, len(aggregate.Events())*9)
 
 	for i, event := range aggregate.Events() {
 		var payloadData []byte
@@ -112,29 +254,97 @@ func (s EventStore) Save(ctx context.Context, aggregate es.EventSourcedAggregate
 			return err
 		}
 
-		placeholders[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d)",
-			i*7+1, i*7+2, i*7+3, i*7+4, i*7+5, i*7+6, i*7+7,
+		var metadataData []byte
+
+		metadataData, err = json.Marshal(event.Metadata())
+		if err != nil {
+			return err
+		}
+
+		placeholders[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			i*9+1, i*9+2, i*9+3, i*9+4, i*9+5, i*9+6, i*9+7, i*9+8, i*9+9,
 		)
 
-		values[i*7] = aggregateID
-		values[i*1+1] = aggregateName
-		values[i*7+2] = event.AggregateVersion()
-		values[i*7+3] = event.ID()
-		values[i*7+4] = event.EventName()
-		values[i*7+5] = payloadData
-		values[i*7+6] = event.OccurredAt()
+		values[i*9] = aggregateID
+		values[i*9+1] = aggregateName
+		values[i*9+2] = event.AggregateVersion()
+		values[i*9+3] = event.ID()
+		values[i*9+4] = event.EventName()
+		values[i*9+5] = s.upcasters.Version(event.EventName())
+		values[i*9+6] = payloadData
+		values[i*9+7] = metadataData
+		values[i*9+8] = event.OccurredAt()
+	}
+
+	_, inTx := s.db.(*sql.Tx)
+
+	db, done, err := s.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = done(err)
+	}()
+
+	// positions are handed out when the rows are inserted and not when they
+	// are committed; holding the lock until the transaction ends hands them out
+	// in commit order, so a position is only visible once every lower position
+	// has been committed or rolled back and readers of the feed can skip gaps
+	if _, err = db.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", s.tableName); err != nil {
+		return err
+	}
+
+	// a failed insert would abort the whole transaction; the savepoint keeps
+	// it usable so the caller can reload the aggregate and try again
+	if inTx {
+		if _, err = db.ExecContext(ctx, "SAVEPOINT "+eventStoreSavepoint); err != nil {
+			return err
+		}
 	}
-	if _, err = s.db.ExecContext(
+
+	if _, err = db.ExecContext(
 		ctx,
 		fmt.Sprintf("%s %s", s.table(query), strings.Join(placeholders, ",")),
 		values...,
 	); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			if inTx {
+				if _, rbErr := db.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+eventStoreSavepoint); rbErr != nil {
+					return rbErr
+				}
+			}
+			return errors.Wrapf(es.ErrConcurrencyConflict, "saving %s %s", aggregateName, aggregateID)
+		}
 		return err
 	}
 
 	return nil
 }
 
+// begin starts a transaction for the save unless the store was given one
+func (s EventStore) begin(ctx context.Context) (DB, func(error) error, error) {
+	db, ok := s.db.(interface {
+		BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	})
+	if !ok {
+		return s.db, func(err error) error { return err }, nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tx, func(err error) error {
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	}, nil
+}
+
 func (s EventStore) table(query string) string {
 	return fmt.Sprintf(query, s.tableName)
 }
@@ -142,8 +352,9 @@ func (s EventStore) table(query string) string {
 func (e aggregateEvent) ID() string                { return e.id }
 func (e aggregateEvent) EventName() string         { return e.name }
 func (e aggregateEvent) Payload() ddd.EventPayload { return e.payload }
-func (e aggregateEvent) Metadata() ddd.Metadata    { return ddd.Metadata{} }
+func (e aggregateEvent) Metadata() ddd.Metadata    { return e.metadata }
 func (e aggregateEvent) OccurredAt() time.Time     { return e.occurredAt }
-func (e aggregateEvent) AggregateName() string     { return e.aggregate.AggregateName() }
-func (e aggregateEvent) AggregateID() string       { return e.aggregate.ID() }
+func (e aggregateEvent) AggregateName() string     { return e.aggregateName }
+func (e aggregateEvent) AggregateID() string       { return e.aggregateID }
 func (e aggregateEvent) AggregateVersion() int     { return e.version }
+func (e aggregateEvent) Position() int64           { return e.position }
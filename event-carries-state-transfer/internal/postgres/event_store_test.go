@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/es"
+	"eda-in-golang/internal/registry"
+	"eda-in-golang/internal/registry/serdes"
+)
+
+type (
+	testAggregate struct {
+		es.Aggregate
+	}
+
+	testEvent struct {
+		Name string
+	}
+)
+
+func (testEvent) Key() string { return "test.Event" }
+
+func (testAggregate) ApplyEvent(ddd.Event) error { return nil }
+
+func TestEventStore_Save(t *testing.T) {
+	const insert = "INSERT INTO baskets.events (stream_id, stream_name, stream_version, event_id, event_name, event_version, event_data, metadata, occurred_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)"
+	const lock = "SELECT pg_advisory_xact_lock(hashtext($1))"
+
+	reg := registry.New()
+	require.NoError(t, serdes.NewJsonSerde(reg).Register(testEvent{}))
+
+	newAggregate := func() *testAggregate {
+		aggregate := &testAggregate{Aggregate: es.NewAggregate("aggregate-id", "test.Aggregate")}
+		aggregate.AddEvent(testEvent{}.Key(), &testEvent{Name: "event"})
+		return aggregate
+	}
+
+	// the lock is held until the save commits, handing out positions in the
+	// order the saves commit
+	t.Run("OwnTransaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(lock).WithArgs("baskets.events").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		store := NewEventStore("baskets.events", db, reg)
+		require.NoError(t, store.Save(context.Background(), newAggregate()))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("CallersTransaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(lock).WithArgs("baskets.events").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("SAVEPOINT " + eventStoreSavepoint).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		tx, err := db.Begin()
+		require.NoError(t, err)
+
+		store := NewEventStore("baskets.events", tx, reg)
+		require.NoError(t, store.Save(context.Background(), newAggregate()))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -2,10 +2,11 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
-	"github.com/jackc/pgconn"
-	"github.com/jackc/pgerrcode"
 	"github.com/stackus/errors"
 
 	"eda-in-golang/internal/am"
@@ -18,6 +19,10 @@ type InboxStore struct {
 }
 
 var _ tm.InboxStore = (*InboxStore)(nil)
+var _ tm.RetentionStore = (*InboxStore)(nil)
+var _ tm.InboxReader = (*InboxStore)(nil)
+
+const inboxSavepoint = "inbox_message"
 
 func NewInboxStore(tableName string, db DB) InboxStore {
 	return InboxStore{
@@ -26,22 +31,139 @@ func NewInboxStore(tableName string, db DB) InboxStore {
 	}
 }
 
+// Save records the receipt of a message. Within a transaction a savepoint is
+// taken first so that Discard can undo the receipt.
 func (s InboxStore) Save(ctx context.Context, msg am.RawMessage) error {
-	const query = "INSERT INTO %s (id, name, subject, data, received_at) VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)"
+	const query = `INSERT INTO %[1]s (id, name, subject, data, received_at) VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+ON CONFLICT (id) DO UPDATE SET received_at = EXCLUDED.received_at, processed_at = NULL, outcome = NULL, error = NULL
+WHERE %[1]s.outcome = $5`
 
-	_, err := s.db.ExecContext(ctx, s.table(query), msg.ID(), msg.MessageName(), msg.Subject(), msg.Data())
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			if pgErr.Code == pgerrcode.UniqueViolation {
-				return tm.ErrDuplicateMessage(msg.ID())
-			}
+	if _, ok := s.db.(*sql.Tx); ok {
+		if _, err := s.db.ExecContext(ctx, "SAVEPOINT "+inboxSavepoint); err != nil {
+			return err
 		}
 	}
 
+	// a message that failed before is taken over; any other is a duplicate
+	result, err := s.db.ExecContext(ctx, s.table(query), msg.ID(), msg.MessageName(), msg.Subject(), msg.Data(), tm.InboxFailed)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return tm.ErrDuplicateMessage(msg.ID())
+	}
+
+	return nil
+}
+
+func (s InboxStore) Discard(ctx context.Context) error {
+	if _, ok := s.db.(*sql.Tx); !ok {
+		return nil
+	}
+
+	_, err := s.db.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+inboxSavepoint)
+
+	return err
+}
+
+func (s InboxStore) Finish(ctx context.Context, msg am.RawMessage, result tm.InboxResult) error {
+	const query = `INSERT INTO %s (id, name, subject, data, received_at, processed_at, outcome, error, handler)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9)
+ON CONFLICT (id) DO UPDATE SET received_at = EXCLUDED.received_at, processed_at = EXCLUDED.processed_at,
+outcome = EXCLUDED.outcome, error = EXCLUDED.error, handler = EXCLUDED.handler`
+
+	_, err := s.db.ExecContext(ctx, s.table(query),
+		msg.ID(), msg.MessageName(), msg.Subject(), msg.Data(),
+		result.ReceivedAt, result.ProcessedAt, result.Outcome, result.Error, result.Handler,
+	)
+
 	return err
 }
 
-func (s InboxStore) table(query string) string {
-	return fmt.Sprintf(query, s.tableName)
+func (s InboxStore) FindMessages(ctx context.Context, query tm.InboxQuery) ([]tm.InboxRecord, error) {
+	var conditions []string
+	var args []any
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if query.Outcome != "" {
+		addCondition("outcome = $%d", query.Outcome)
+	}
+	if query.Handler != "" {
+		addCondition("handler = $%d", query.Handler)
+	}
+	if query.Name != "" {
+		addCondition("name = $%d", query.Name)
+	}
+	if !query.Since.IsZero() {
+		addCondition("received_at >= $%d", query.Since)
+	}
+	if !query.Until.IsZero() {
+		addCondition("received_at < $%d", query.Until)
+	}
+
+	sqlQuery := "SELECT id, name, subject, received_at, processed_at, outcome, error, handler FROM %s"
+	if len(conditions) > 0 {
+		sqlQuery += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sqlQuery += " ORDER BY received_at DESC"
+	if query.Limit > 0 {
+		sqlQuery += fmt.Sprintf(" LIMIT %d", query.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.table(sqlQuery), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			err = errors.Wrap(err, "closing inbox rows")
+		}
+	}(rows)
+
+	var records []tm.InboxRecord
+
+	for rows.Next() {
+		var record tm.InboxRecord
+		var processedAt sql.NullTime
+		var outcome, errMsg, handler sql.NullString
+		err = rows.Scan(&record.ID, &record.Name, &record.Subject, &record.ReceivedAt, &processedAt, &outcome, &errMsg, &handler)
+		if err != nil {
+			return nil, err
+		}
+		record.ProcessedAt = processedAt.Time
+		record.Outcome = outcome.String
+		record.Error = errMsg.String
+		record.Handler = handler.String
+
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// DeleteBefore removes up to limit messages that were received before the cutoff
+func (s InboxStore) DeleteBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	const query = "DELETE FROM %[1]s WHERE id IN (SELECT id FROM %[1]s WHERE received_at < $1 LIMIT %[2]d)"
+
+	result, err := s.db.ExecContext(ctx, s.table(query, limit), cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (s InboxStore) table(query string, args ...any) string {
+	params := []any{s.tableName}
+	params = append(params, args...)
+	return fmt.Sprintf(query, params...)
 }
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/tm"
+)
+
+func TestInboxStore_FindMessages(t *testing.T) {
+	columns := []string{"id", "name", "subject", "received_at", "processed_at", "outcome", "error", "handler"}
+	since := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		query tm.InboxQuery
+		sql   string
+		args  []any
+	}{
+		"Everything": {
+			query: tm.InboxQuery{},
+			sql:   "SELECT id, name, subject, received_at, processed_at, outcome, error, handler FROM ordering.inbox ORDER BY received_at DESC",
+		},
+		"FailedSince": {
+			query: tm.FailedSince(since),
+			sql:   "SELECT id, name, subject, received_at, processed_at, outcome, error, handler FROM ordering.inbox WHERE outcome = $1 AND received_at >= $2 ORDER BY received_at DESC",
+			args:  []any{tm.InboxFailed, since},
+		},
+		"AllFilters": {
+			query: tm.InboxQuery{
+				Outcome: tm.InboxSkipped,
+				Handler: "ordering",
+				Name:    "baskets.BasketCheckedOut",
+				Since:   since,
+				Until:   since.Add(time.Hour),
+				Limit:   10,
+			},
+			sql: "SELECT id, name, subject, received_at, processed_at, outcome, error, handler FROM ordering.inbox " +
+				"WHERE outcome = $1 AND handler = $2 AND name = $3 AND received_at >= $4 AND received_at < $5 ORDER BY received_at DESC LIMIT 10",
+			args: []any{tm.InboxSkipped, "ordering", "baskets.BasketCheckedOut", since, since.Add(time.Hour)},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+
+			args := make([]driver.Value, len(tc.args))
+			for i, arg := range tc.args {
+				args[i] = arg
+			}
+			mock.ExpectQuery(tc.sql).WithArgs(args...).WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("message-1", "baskets.BasketCheckedOut", "mallbots.baskets", since, since.Add(time.Second), tm.InboxFailed, "boom", "ordering").
+				AddRow("message-2", "baskets.BasketCheckedOut", "mallbots.baskets", since, nil, nil, nil, nil),
+			)
+
+			records, err := NewInboxStore("ordering.inbox", db).FindMessages(context.Background(), tc.query)
+			require.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+
+			require.Len(t, records, 2)
+			assert.Equal(t, tm.InboxRecord{
+				ID:          "message-1",
+				Name:        "baskets.BasketCheckedOut",
+				Subject:     "mallbots.baskets",
+				Handler:     "ordering",
+				Outcome:     tm.InboxFailed,
+				Error:       "boom",
+				ReceivedAt:  since,
+				ProcessedAt: since.Add(time.Second),
+			}, records[0])
+			assert.Equal(t, time.Second, records[0].Duration())
+			assert.Zero(t, records[1].Duration(), "the message has not been processed")
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+
+	"github.com/stackus/errors"
+)
+
+// KeyStore keeps the keys used to encrypt the personal data of each subject.
+// Forgetting a subject deletes its key but keeps the row, so that no new key
+// is created for it afterwards.
+type KeyStore struct {
+	tableName string
+	db        DB
+}
+
+const keySize = 32
+
+func NewKeyStore(tableName string, db DB) KeyStore {
+	return KeyStore{
+		tableName: tableName,
+		db:        db,
+	}
+}
+
+// Create makes a key for the subject and returns it; the key is only made when
+// the subject has none, and a forgotten subject gets a nil key
+func (s KeyStore) Create(ctx context.Context, subject string) ([]byte, error) {
+	const query = "INSERT INTO %s (subject, key) VALUES ($1, $2) ON CONFLICT (subject) DO NOTHING"
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.db.ExecContext(ctx, s.table(query), subject, key); err != nil {
+		return nil, errors.Wrap(err, "creating subject key")
+	}
+
+	return s.Find(ctx, subject)
+}
+
+// Find returns the key of the subject, or a nil key when the subject has been
+// forgotten
+func (s KeyStore) Find(ctx context.Context, subject string) ([]byte, error) {
+	const query = "SELECT key FROM %s WHERE subject = $1"
+
+	var key []byte
+	err := s.db.QueryRowContext(ctx, s.table(query), subject).Scan(&key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.ErrNotFound.Msgf("the subject `%s` has no key", subject)
+		}
+		return nil, errors.Wrap(err, "reading subject key")
+	}
+
+	return key, nil
+}
+
+// FindOrCreate returns the key of the subject, making one for a subject that
+// has none yet, such as one that existed before its personal data was
+// encrypted
+func (s KeyStore) FindOrCreate(ctx context.Context, subject string) ([]byte, error) {
+	key, err := s.Find(ctx, subject)
+	if errors.Is(err, errors.ErrNotFound) {
+		return s.Create(ctx, subject)
+	}
+
+	return key, err
+}
+
+func (s KeyStore) Forget(ctx context.Context, subject string) error {
+	const query = `INSERT INTO %s (subject, key, forgotten_at) VALUES ($1, NULL, CURRENT_TIMESTAMP)
+ON CONFLICT (subject) DO UPDATE SET key = NULL, forgotten_at = EXCLUDED.forgotten_at`
+
+	_, err := s.db.ExecContext(ctx, s.table(query), subject)
+
+	return err
+}
+
+func (s KeyStore) table(query string) string {
+	return fmt.Sprintf(query, s.tableName)
+}
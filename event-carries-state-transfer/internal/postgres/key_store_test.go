@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stackus/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeyStore(t *testing.T) (KeyStore, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	return NewKeyStore("customers.customer_keys", db), mock
+}
+
+func TestKeyStore_Create(t *testing.T) {
+	store, mock := newTestKeyStore(t)
+
+	mock.ExpectExec("INSERT INTO customers.customer_keys (subject, key) VALUES ($1, $2) ON CONFLICT (subject) DO NOTHING").
+		WithArgs("customer-id", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT key FROM customers.customer_keys WHERE subject = $1").
+		WithArgs("customer-id").
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow([]byte("stored-key")))
+
+	// the stored key is returned when the subject already had one
+	key, err := store.Create(context.Background(), "customer-id")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("stored-key"), key)
+}
+
+func TestKeyStore_Find(t *testing.T) {
+	const query = "SELECT key FROM customers.customer_keys WHERE subject = $1"
+
+	t.Run("Key", func(t *testing.T) {
+		store, mock := newTestKeyStore(t)
+		mock.ExpectQuery(query).WithArgs("customer-id").
+			WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow([]byte("key")))
+
+		key, err := store.Find(context.Background(), "customer-id")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("key"), key)
+	})
+	t.Run("Forgotten", func(t *testing.T) {
+		store, mock := newTestKeyStore(t)
+		mock.ExpectQuery(query).WithArgs("customer-id").
+			WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow(nil))
+
+		key, err := store.Find(context.Background(), "customer-id")
+		require.NoError(t, err)
+		assert.Nil(t, key)
+	})
+	t.Run("Missing", func(t *testing.T) {
+		store, mock := newTestKeyStore(t)
+		mock.ExpectQuery(query).WithArgs("customer-id").
+			WillReturnRows(sqlmock.NewRows([]string{"key"}))
+
+		_, err := store.Find(context.Background(), "customer-id")
+		assert.True(t, errors.Is(err, errors.ErrNotFound))
+	})
+}
+
+func TestKeyStore_FindOrCreate(t *testing.T) {
+	const find = "SELECT key FROM customers.customer_keys WHERE subject = $1"
+
+	t.Run("Existing", func(t *testing.T) {
+		store, mock := newTestKeyStore(t)
+		mock.ExpectQuery(find).WithArgs("customer-id").
+			WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow([]byte("key")))
+
+		key, err := store.FindOrCreate(context.Background(), "customer-id")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("key"), key)
+	})
+	t.Run("Missing", func(t *testing.T) {
+		store, mock := newTestKeyStore(t)
+		mock.ExpectQuery(find).WithArgs("customer-id").
+			WillReturnRows(sqlmock.NewRows([]string{"key"}))
+		mock.ExpectExec("INSERT INTO customers.customer_keys (subject, key) VALUES ($1, $2) ON CONFLICT (subject) DO NOTHING").
+			WithArgs("customer-id", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(find).WithArgs("customer-id").
+			WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow([]byte("new-key")))
+
+		key, err := store.FindOrCreate(context.Background(), "customer-id")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("new-key"), key)
+	})
+	t.Run("Forgotten", func(t *testing.T) {
+		store, mock := newTestKeyStore(t)
+		mock.ExpectQuery(find).WithArgs("customer-id").
+			WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow(nil))
+
+		key, err := store.FindOrCreate(context.Background(), "customer-id")
+		require.NoError(t, err)
+		assert.Nil(t, key)
+	})
+}
+
+func TestKeyStore_Forget(t *testing.T) {
+	store, mock := newTestKeyStore(t)
+
+	mock.ExpectExec(`INSERT INTO customers.customer_keys (subject, key, forgotten_at) VALUES ($1, NULL, CURRENT_TIMESTAMP)
+ON CONFLICT (subject) DO UPDATE SET key = NULL, forgotten_at = EXCLUDED.forgotten_at`).
+		WithArgs("customer-id").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, store.Forget(context.Background(), "customer-id"))
+}
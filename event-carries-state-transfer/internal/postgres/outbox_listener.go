@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/rs/zerolog"
+	"github.com/stackus/errors"
+
+	"eda-in-golang/internal/tm"
+)
+
+const outboxListenerRetryDelay = 5 * time.Second
+
+// OutboxListener waits for the notifications sent by OutboxStore.Save; the
+// channel is named after the outbox table
+type OutboxListener struct {
+	channel string
+	db      *sql.DB
+	logger  zerolog.Logger
+}
+
+var _ tm.OutboxListener = (*OutboxListener)(nil)
+
+func NewOutboxListener(tableName string, db *sql.DB, logger zerolog.Logger) OutboxListener {
+	return OutboxListener{
+		channel: tableName,
+		db:      db,
+		logger:  logger,
+	}
+}
+
+// Listen calls notify for each notification until the context is done. Lost
+// connections are reopened, and notify is called after every reconnect because
+// notifications sent in the meantime are gone.
+func (l OutboxListener) Listen(ctx context.Context, notify func()) error {
+	for {
+		err := l.listen(ctx, notify)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		l.logger.Error().Err(err).Str("Channel", l.channel).Msg("outbox listener lost its connection")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(outboxListenerRetryDelay):
+		}
+
+		notify()
+	}
+}
+
+func (l OutboxListener) listen(ctx context.Context, notify func()) error {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(conn *sql.Conn) {
+		err := conn.Close()
+		if err != nil {
+			err = errors.Wrap(err, "closing listener connection")
+		}
+	}(conn)
+
+	return conn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.ErrInternal.Msgf("outbox listener requires the pgx driver, got %T", driverConn)
+		}
+		pgxConn := c.Conn()
+
+		if _, err := pgxConn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+			return err
+		}
+		defer func() {
+			// the connection goes back into the pool; stop listening on it
+			_, _ = pgxConn.Exec(context.Background(), "UNLISTEN "+pgx.Identifier{l.channel}.Sanitize())
+		}()
+
+		for {
+			if _, err := pgxConn.WaitForNotification(ctx); err != nil {
+				return err
+			}
+			notify()
+		}
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
@@ -24,9 +26,12 @@ type outboxMessage struct {
 	name    string
 	subject string
 	data    []byte
+	seq     int64
 }
 
 var _ tm.OutboxStore = (*OutboxStore)(nil)
+var _ tm.RetentionStore = (*OutboxStore)(nil)
+var _ tm.OutboxBacklogReader = (*OutboxStore)(nil)
 var _ am.RawMessage = (*outboxMessage)(nil)
 
 func NewOutboxStore(tableName string, db DB) OutboxStore {
@@ -47,15 +52,104 @@ func (s OutboxStore) Save(ctx context.Context, msg am.RawMessage) error {
 				return tm.ErrDuplicateMessage(msg.ID())
 			}
 		}
+		return err
 	}
 
+	// wake up any listening processors; inside a transaction the notification
+	// is only sent once the transaction commits
+	_, err = s.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", s.tableName, msg.ID())
+
 	return err
 }
 
-func (s OutboxStore) FindUnpublished(ctx context.Context, limit int) ([]am.RawMessage, error) {
-	const query = "SELECT id, name, subject, data FROM %s WHERE published_at IS NULL LIMIT %d"
+// FindUnpublished claims up to limit unpublished messages for the length of
+// the lease; rows claimed by another processor are skipped and the messages
+// are returned in the order they were saved. When ordered is true a message is
+// not claimed while an earlier message with the same subject is leased, and
+// only one processor at a time may claim the messages of a subject.
+func (s OutboxStore) FindUnpublished(ctx context.Context, limit int, lease time.Duration, ordered bool) (rawMsgs []am.RawMessage, err error) {
+	if !ordered {
+		return s.claim(ctx, s.db, limit, lease, nil)
+	}
+
+	// the subject locks are held until the transaction ends, by which time the
+	// leases of the claimed rows are visible to the other processors
+	db, done, err := s.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = done(err)
+	}()
+
+	subjects, err := s.lockSubjects(ctx, db)
+	if err != nil || len(subjects) == 0 {
+		return nil, err
+	}
+
+	return s.claim(ctx, db, limit, lease, subjects)
+}
+
+// lockSubjects takes a transaction level advisory lock for each subject with
+// unpublished messages and returns the subjects it was able to lock
+func (s OutboxStore) lockSubjects(ctx context.Context, db DB) ([]string, error) {
+	const query = `SELECT subject FROM (SELECT DISTINCT subject FROM %s WHERE published_at IS NULL) s
+WHERE pg_try_advisory_xact_lock(hashtext($1), hashtext(s.subject))`
+
+	rows, err := db.QueryContext(ctx, s.table(query), s.tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			err = errors.Wrap(err, "closing subject rows")
+		}
+	}(rows)
+
+	var subjects []string
+	for rows.Next() {
+		var subject string
+		if err = rows.Scan(&subject); err != nil {
+			return nil, err
+		}
+		subjects = append(subjects, subject)
+	}
+
+	return subjects, rows.Err()
+}
+
+// claim leases the next unpublished messages; when subjects are given only the
+// messages of those subjects are claimed, in the order they were saved
+func (s OutboxStore) claim(ctx context.Context, db DB, limit int, lease time.Duration, subjects []string) ([]am.RawMessage, error) {
+	const query = `WITH claimed AS (
+  SELECT id FROM %[1]s o
+  WHERE published_at IS NULL AND (locked_until IS NULL OR locked_until < CURRENT_TIMESTAMP)%[3]s
+  ORDER BY seq LIMIT %[2]d
+  FOR UPDATE SKIP LOCKED
+)
+UPDATE %[1]s o SET locked_until = CURRENT_TIMESTAMP + make_interval(secs => $1)
+FROM claimed WHERE o.id = claimed.id
+RETURNING o.id, o.name, o.subject, o.data, o.seq`
+	const orderedBySubject = `
+  AND o.subject = ANY ($2)
+  AND NOT EXISTS (
+    SELECT 1 FROM %[1]s p
+    WHERE p.subject = o.subject AND p.seq < o.seq AND p.published_at IS NULL AND p.locked_until >= CURRENT_TIMESTAMP
+  )`
 
-	rows, err := s.db.QueryContext(ctx, s.table(query, limit))
+	var condition string
+	args := []any{lease.Seconds()}
+	if subjects != nil {
+		subjectArray := &pgtype.TextArray{}
+		if err := subjectArray.Set(subjects); err != nil {
+			return nil, err
+		}
+		condition = s.table(orderedBySubject)
+		args = append(args, subjectArray)
+	}
+
+	rows, err := db.QueryContext(ctx, s.table(query, limit, condition), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -66,23 +160,60 @@ func (s OutboxStore) FindUnpublished(ctx context.Context, limit int) ([]am.RawMe
 		}
 	}(rows)
 
-	var msgs []am.RawMessage
+	var msgs []outboxMessage
 
 	for rows.Next() {
 		msg := outboxMessage{}
-		err = rows.Scan(&msg.id, &msg.name, &msg.subject, &msg.data)
+		err = rows.Scan(&msg.id, &msg.name, &msg.subject, &msg.data, &msg.seq)
 		if err != nil {
-			return msgs, err
+			return nil, err
 		}
 
 		msgs = append(msgs, msg)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// UPDATE ... RETURNING does not keep the order of the claimed rows
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].seq < msgs[j].seq
+	})
+
+	rawMsgs := make([]am.RawMessage, len(msgs))
+	for i, msg := range msgs {
+		rawMsgs[i] = msg
+	}
 
-	return msgs, rows.Err()
+	return rawMsgs, nil
+}
+
+// begin starts a transaction when the store was given a database; a store
+// given a transaction keeps using it and leaves ending it to its owner
+func (s OutboxStore) begin(ctx context.Context) (DB, func(error) error, error) {
+	db, ok := s.db.(interface {
+		BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	})
+	if !ok {
+		return s.db, func(err error) error { return err }, nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tx, func(err error) error {
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	}, nil
 }
 
 func (s OutboxStore) MarkAsPublished(ctx context.Context, ids ...string) error {
-	const query = "UPDATE %s SET published_at = CURRENT_TIMESTAMP WHERE id = ANY ($1)"
+	const query = "UPDATE %s SET published_at = CURRENT_TIMESTAMP, locked_until = NULL WHERE id = ANY ($1)"
 
 	msgIDs := &pgtype.TextArray{}
 	err := msgIDs.Set(ids)
@@ -95,6 +226,56 @@ func (s OutboxStore) MarkAsPublished(ctx context.Context, ids ...string) error {
 	return err
 }
 
+// MarkAsFailed records the failed attempt and gives up the lease so that the
+// message is tried again by the next processor to check the outbox
+func (s OutboxStore) MarkAsFailed(ctx context.Context, id string, reason error) error {
+	const query = "UPDATE %s SET attempts = attempts + 1, last_error = $2, locked_until = NULL WHERE id = $1"
+
+	_, err := s.db.ExecContext(ctx, s.table(query), id, reason.Error())
+
+	return err
+}
+
+// Release gives up the lease on messages that were claimed but not published
+func (s OutboxStore) Release(ctx context.Context, ids ...string) error {
+	const query = "UPDATE %s SET locked_until = NULL WHERE id = ANY ($1)"
+
+	msgIDs := &pgtype.TextArray{}
+	err := msgIDs.Set(ids)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, s.table(query), msgIDs)
+
+	return err
+}
+
+// Backlog counts the unpublished messages and returns when the oldest of them
+// was saved
+func (s OutboxStore) Backlog(ctx context.Context) (count int, oldestAt time.Time, err error) {
+	const query = "SELECT count(*), min(created_at) FROM %s WHERE published_at IS NULL"
+
+	var oldest sql.NullTime
+	if err = s.db.QueryRowContext(ctx, s.table(query)).Scan(&count, &oldest); err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return count, oldest.Time, nil
+}
+
+// DeleteBefore removes up to limit messages that were published before the cutoff
+func (s OutboxStore) DeleteBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	const query = "DELETE FROM %[1]s WHERE id IN (SELECT id FROM %[1]s WHERE published_at < $1 LIMIT %[2]d)"
+
+	result, err := s.db.ExecContext(ctx, s.table(query, limit), cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 func (s OutboxStore) table(query string, args ...any) string {
 	params := []any{s.tableName}
 	params = append(params, args...)
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxStore_MarkAsFailed(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	// the attempt is recorded and the lease is released so the message can be
+	// claimed again without waiting for the lease to run out
+	mock.ExpectExec("UPDATE baskets.outbox SET attempts = attempts + 1, last_error = $2, locked_until = NULL WHERE id = $1").
+		WithArgs("message-id", "publish failed").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	store := NewOutboxStore("baskets.outbox", db)
+	require.NoError(t, store.MarkAsFailed(context.Background(), "message-id", errors.New("publish failed")))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxStore_Release(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectExec("UPDATE baskets.outbox SET locked_until = NULL WHERE id = ANY ($1)").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	store := NewOutboxStore("baskets.outbox", db)
+	require.NoError(t, store.Release(context.Background(), "message-1", "message-2"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/stackus/errors"
+
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/es"
+)
+
+type (
+	// ProjectionHandlers builds the handlers of a projection on top of the
+	// transaction a batch of events is projected in
+	ProjectionHandlers func(db DB) ddd.EventHandler[ddd.AggregateEvent]
+
+	// Projection is an es.Projection kept in Postgres; each batch of events and
+	// the checkpoint that follows it are written in one transaction
+	Projection struct {
+		name      string
+		tableName string
+		db        *sql.DB
+		tx        *sql.Tx
+		handlers  ProjectionHandlers
+		tables    []string
+	}
+
+	ProjectionOption func(p *Projection)
+)
+
+var _ es.Projection = (*Projection)(nil)
+
+func NewProjection(name, tableName string, db *sql.DB, handlers ProjectionHandlers, options ...ProjectionOption) Projection {
+	p := Projection{
+		name:      name,
+		tableName: tableName,
+		db:        db,
+		handlers:  handlers,
+	}
+
+	for _, option := range options {
+		option(&p)
+	}
+
+	return p
+}
+
+// ProjectionTables sets the tables the projection writes to; they are cleared
+// when the projection is rebuilt
+func ProjectionTables(tableNames ...string) ProjectionOption {
+	return func(p *Projection) {
+		p.tables = tableNames
+	}
+}
+
+func (p Projection) ProjectionName() string {
+	return p.name
+}
+
+func (p Projection) Checkpoint(ctx context.Context) (int64, error) {
+	const query = "SELECT position FROM %s WHERE name = $1"
+
+	var position int64
+
+	err := p.conn().QueryRowContext(ctx, p.table(query), p.name).Scan(&position)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	return position, nil
+}
+
+// Project skips the batch with es.ErrCheckpointMoved when another projector,
+// or a rebuild, holds the checkpoint
+func (p Projection) Project(ctx context.Context, checkpoint int64, events []es.StoredEvent) (err error) {
+	const updateQuery = "UPDATE %s SET position = $2 WHERE name = $1"
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx := p.tx
+	if tx == nil {
+		tx, err = p.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				_ = tx.Rollback()
+			} else {
+				err = tx.Commit()
+			}
+		}()
+	}
+
+	var position int64
+	if position, err = p.lock(ctx, tx); err != nil {
+		return err
+	}
+
+	if position != checkpoint {
+		return errors.Wrapf(es.ErrCheckpointMoved, "%s is at %d; expected %d", p.name, position, checkpoint)
+	}
+
+	handlers := p.handlers(tx)
+	for _, event := range events {
+		if err = handlers.HandleEvent(ctx, event); err != nil {
+			return errors.Wrapf(err, "projecting %s at %d", event.EventName(), event.Position())
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, p.table(updateQuery), p.name, events[len(events)-1].Position())
+
+	return err
+}
+
+// Rebuild clears the projection tables and resets the checkpoint, then calls
+// replay with a projection bound to the same transaction. Readers keep seeing
+// the old rows until the transaction commits, and live projectors skip their
+// batches until then. A dry run rolls the transaction back once replay returns.
+func (p Projection) Rebuild(ctx context.Context, dryRun bool, replay func(ctx context.Context, projection es.Projection) error) (err error) {
+	const deleteQuery = "DELETE FROM %s"
+	const resetQuery = "UPDATE %s SET position = 0 WHERE name = $1"
+
+	var tx *sql.Tx
+	tx, err = p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil || dryRun {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	if _, err = p.lock(ctx, tx); err != nil {
+		return err
+	}
+
+	// DELETE rather than TRUNCATE; TRUNCATE would lock out readers until commit
+	for _, tableName := range p.tables {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(deleteQuery, tableName)); err != nil {
+			return err
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, p.table(resetQuery), p.name); err != nil {
+		return err
+	}
+
+	rebuilding := p
+	rebuilding.tx = tx
+
+	return replay(ctx, rebuilding)
+}
+
+// lock takes the checkpoint row for the transaction, creating it when needed
+func (p Projection) lock(ctx context.Context, tx *sql.Tx) (int64, error) {
+	const insertQuery = "INSERT INTO %s (name, position) VALUES ($1, 0) ON CONFLICT (name) DO NOTHING"
+	const lockQuery = "SELECT position FROM %s WHERE name = $1 FOR UPDATE SKIP LOCKED"
+
+	if _, err := tx.ExecContext(ctx, p.table(insertQuery), p.name); err != nil {
+		return 0, err
+	}
+
+	var position int64
+	if err := tx.QueryRowContext(ctx, p.table(lockQuery), p.name).Scan(&position); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errors.Wrapf(es.ErrCheckpointMoved, "%s is locked", p.name)
+		}
+		return 0, err
+	}
+
+	return position, nil
+}
+
+func (p Projection) conn() DB {
+	if p.tx != nil {
+		return p.tx
+	}
+
+	return p.db
+}
+
+func (p Projection) table(query string) string {
+	return fmt.Sprintf(query, p.tableName)
+}
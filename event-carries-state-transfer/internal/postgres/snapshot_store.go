@@ -11,96 +11,53 @@ import (
 	"eda-in-golang/internal/registry"
 )
 
-type SnapshotStore struct {
-	es.AggregateStore
+// SnapshotRows keeps the snapshots of an es.SnapshotStore in a table; the
+// time a snapshot was taken is the time its row was last updated
+type SnapshotRows struct {
 	tableName string
 	db        DB
-	registry  registry.Registry
 }
 
-var _ es.AggregateStore = (*SnapshotStore)(nil)
+var _ es.SnapshotRows = (*SnapshotRows)(nil)
 
-func NewSnapshotStore(tableName string, db DB, registry registry.Registry) es.AggregateStoreMiddleware {
-	snapshots := SnapshotStore{
+func NewSnapshotStore(tableName string, db DB, registry registry.Registry, options ...es.SnapshotStoreOption) es.AggregateStoreMiddleware {
+	return es.NewSnapshotStore(NewSnapshotRows(tableName, db), registry, options...)
+}
+
+func NewSnapshotRows(tableName string, db DB) SnapshotRows {
+	return SnapshotRows{
 		tableName: tableName,
 		db:        db,
-		registry:  registry,
-	}
-
-	return func(store es.AggregateStore) es.AggregateStore {
-		snapshots.AggregateStore = store
-		return snapshots
 	}
 }
 
-func (s SnapshotStore) Load(ctx context.Context, aggregate es.EventSourcedAggregate) error {
-	const query = `SELECT stream_version, snapshot_name, snapshot_data FROM %s WHERE stream_id = $1 AND stream_name = $2 LIMIT 1`
+func (r SnapshotRows) Get(ctx context.Context, aggregateName, aggregateID string) (es.SnapshotRow, bool, error) {
+	const query = `SELECT stream_version, snapshot_name, snapshot_data, updated_at FROM %s WHERE stream_id = $1 AND stream_name = $2 LIMIT 1`
 
-	var entityVersion int
-	var snapshotName string
-	var snapshotData []byte
+	var row es.SnapshotRow
 
-	if err := s.db.QueryRowContext(ctx, s.table(query), aggregate.ID(), aggregate.AggregateName()).Scan(&entityVersion, &snapshotName, &snapshotData); err != nil {
+	err := r.db.QueryRowContext(ctx, r.table(query), aggregateID, aggregateName).Scan(&row.Version, &row.Name, &row.Data, &row.TakenAt)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return s.AggregateStore.Load(ctx, aggregate)
+			return row, false, nil
 		}
-		return err
-	}
-
-	v, err := s.registry.Deserialize(snapshotName, snapshotData, registry.ValidateImplements((*es.Snapshot)(nil)))
-	if err != nil {
-		return err
-	}
-
-	if err := es.LoadSnapshot(aggregate, v.(es.Snapshot), entityVersion); err != nil {
-		return err
+		return row, false, err
 	}
 
-	return s.AggregateStore.Load(ctx, aggregate)
+	return row, true, nil
 }
 
-func (s SnapshotStore) Save(ctx context.Context, aggregate es.EventSourcedAggregate) error {
+func (r SnapshotRows) Put(ctx context.Context, aggregateName, aggregateID string, row es.SnapshotRow) error {
 	const query = `INSERT INTO %s (stream_id, stream_name, stream_version, snapshot_name, snapshot_data)
 VALUES ($1, $2, $3, $4, $5)
 ON CONFLICT (stream_id, stream_name) DO
 UPDATE SET stream_version = EXCLUDED.stream_version, snapshot_name = EXCLUDED.snapshot_name, snapshot_data = EXCLUDED.snapshot_data`
 
-	if err := s.AggregateStore.Save(ctx, aggregate); err != nil {
-		return err
-	}
-
-	if !s.shouldSnapshot(aggregate) {
-		return nil
-	}
-
-	sser, ok := aggregate.(es.Snapshotter)
-	if !ok {
-		return fmt.Errorf("%T does not implelement es.Snapshotter", aggregate)
-	}
-
-	snapshot := sser.ToSnapshot()
-
-	data, err := s.registry.Serialize(snapshot.SnapshotName(), snapshot)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.db.ExecContext(ctx, s.table(query), aggregate.ID(), aggregate.AggregateName(), aggregate.PendingVersion(), snapshot.SnapshotName(), data)
+	_, err := r.db.ExecContext(ctx, r.table(query), aggregateID, aggregateName, row.Version, row.Name, row.Data)
 
 	return err
 }
 
-// TODO use injected & configurable strategies
-func (SnapshotStore) shouldSnapshot(aggregate es.EventSourcedAggregate) bool {
-	var maxChanges = 3 // low for demonstration; production envs should use higher values 50, 75, 100...
-	var pendingVersion = aggregate.PendingVersion()
-	var pendingChanges = len(aggregate.Events())
-
-	return pendingVersion >= maxChanges && ((pendingChanges >= maxChanges) ||
-		(pendingVersion%maxChanges < pendingChanges) ||
-		(pendingVersion%maxChanges == 0))
-}
-
-func (s SnapshotStore) table(query string) string {
-	return fmt.Sprintf(query, s.tableName)
+func (r SnapshotRows) table(query string) string {
+	return fmt.Sprintf(query, r.tableName)
 }
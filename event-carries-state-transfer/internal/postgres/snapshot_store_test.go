@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/es"
+)
+
+func TestSnapshotRows_Get(t *testing.T) {
+	const query = "SELECT stream_version, snapshot_name, snapshot_data, updated_at FROM baskets.snapshots WHERE stream_id = $1 AND stream_name = $2 LIMIT 1"
+
+	takenAt := time.Now()
+
+	t.Run("Found", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WithArgs("basket-id", "baskets.Basket").
+			WillReturnRows(sqlmock.NewRows([]string{"stream_version", "snapshot_name", "snapshot_data", "updated_at"}).
+				AddRow(3, "baskets.BasketV1", []byte("{}"), takenAt))
+
+		row, exists, err := NewSnapshotRows("baskets.snapshots", db).Get(context.Background(), "baskets.Basket", "basket-id")
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, es.SnapshotRow{Version: 3, Name: "baskets.BasketV1", Data: []byte("{}"), TakenAt: takenAt}, row)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("Missing", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WithArgs("basket-id", "baskets.Basket").
+			WillReturnRows(sqlmock.NewRows([]string{"stream_version", "snapshot_name", "snapshot_data", "updated_at"}))
+
+		_, exists, err := NewSnapshotRows("baskets.snapshots", db).Get(context.Background(), "baskets.Basket", "basket-id")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,53 @@
+package prom
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"eda-in-golang/internal/es"
+)
+
+var (
+	eventStoreLoadDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "event_store_load_duration_seconds",
+		Help:      "How long aggregates took to load from the event store",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"aggregate", "outcome"})
+
+	eventStoreReplayLength = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "event_store_replayed_events",
+		Help:      "The events replayed onto aggregates as they were loaded",
+		Buckets:   []float64{0, 1, 2, 5, 10, 25, 50, 100, 250, 500, 1000},
+	}, []string{"aggregate"})
+)
+
+type aggregateStore struct {
+	es.AggregateStore
+}
+
+// AggregateStoreMetrics records how long loads take and how many events are
+// replayed. Placed after the snapshot store, the events replayed on top of a
+// snapshot are the ones counted.
+func AggregateStoreMetrics() es.AggregateStoreMiddleware {
+	return func(store es.AggregateStore) es.AggregateStore {
+		return aggregateStore{AggregateStore: store}
+	}
+}
+
+func (s aggregateStore) Load(ctx context.Context, aggregate es.EventSourcedAggregate) error {
+	start := time.Now()
+	fromVersion := aggregate.Version()
+
+	err := s.AggregateStore.Load(ctx, aggregate)
+	eventStoreLoadDuration.WithLabelValues(aggregate.AggregateName(), outcome(err)).Observe(time.Since(start).Seconds())
+	if err == nil {
+		eventStoreReplayLength.WithLabelValues(aggregate.AggregateName()).Observe(float64(aggregate.Version() - fromVersion))
+	}
+
+	return err
+}
@@ -0,0 +1,78 @@
+package prom
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"eda-in-golang/internal/am"
+)
+
+var (
+	handlerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "message_handler_duration_seconds",
+		Help:      "How long incoming messages took to handle by subject, consumer group and outcome",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"subject", "group", "outcome"})
+
+	publishedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_published_total",
+		Help:      "The messages published to the stream by subject and outcome",
+	}, []string{"subject", "outcome"})
+)
+
+type (
+	messageHandler struct {
+		handler   am.RawMessageHandler
+		groupName string
+	}
+
+	messageStream struct {
+		am.RawMessageStream
+	}
+)
+
+// HandlerMetrics records the latency and outcome of each message handled for
+// the consumer group
+func HandlerMetrics(groupName string) am.RawMessageHandlerMiddleware {
+	return func(handler am.RawMessageHandler) am.RawMessageHandler {
+		return messageHandler{
+			handler:   handler,
+			groupName: groupName,
+		}
+	}
+}
+
+// StreamMetrics counts the messages published to the stream and records the
+// handler metrics of every subscription made through it
+func StreamMetrics() am.RawMessageStreamMiddleware {
+	return func(stream am.RawMessageStream) am.RawMessageStream {
+		return messageStream{RawMessageStream: stream}
+	}
+}
+
+func (h messageHandler) HandleMessage(ctx context.Context, msg am.IncomingRawMessage) error {
+	start := time.Now()
+
+	err := h.handler.HandleMessage(ctx, msg)
+	handlerDuration.WithLabelValues(msg.Subject(), h.groupName, outcome(err)).Observe(time.Since(start).Seconds())
+
+	return err
+}
+
+func (s messageStream) Publish(ctx context.Context, topicName string, msg am.RawMessage) error {
+	err := s.RawMessageStream.Publish(ctx, topicName, msg)
+	publishedMessages.WithLabelValues(topicName, outcome(err)).Inc()
+
+	return err
+}
+
+func (s messageStream) Subscribe(topicName string, handler am.RawMessageHandler, options ...am.SubscriberOption) error {
+	groupName := am.NewSubscriberConfig(options).GroupName()
+
+	return s.RawMessageStream.Subscribe(topicName, HandlerMetrics(groupName)(handler), options...)
+}
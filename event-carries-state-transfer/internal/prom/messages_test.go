@@ -0,0 +1,61 @@
+package prom
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/memstream"
+)
+
+type testMessage struct {
+	id      string
+	name    string
+	subject string
+}
+
+func (m testMessage) ID() string          { return m.id }
+func (m testMessage) MessageName() string { return m.name }
+func (m testMessage) Subject() string     { return m.subject }
+func (m testMessage) Data() []byte        { return nil }
+
+func TestStreamMetrics(t *testing.T) {
+	stream := am.RawMessageStreamWithMiddleware(memstream.NewStream(zerolog.Nop()), StreamMetrics())
+
+	handled := make(chan struct{}, 2)
+	err := stream.Subscribe("test.metrics", am.RawMessageHandlerFunc(func(ctx context.Context, msg am.IncomingRawMessage) error {
+		defer func() { handled <- struct{}{} }()
+		if msg.ID() == "bad" {
+			_ = msg.Kill()
+			return errors.New("bad message")
+		}
+		return msg.Ack()
+	}), am.GroupName("test-group"), am.MaxRedeliver(0))
+	require.NoError(t, err)
+
+	published := publishedMessages.WithLabelValues("test.metrics", outcomeSuccess)
+	before := testutil.ToFloat64(published)
+
+	for _, id := range []string{"good", "bad"} {
+		require.NoError(t, stream.Publish(context.Background(), "test.metrics", testMessage{id: id, name: "test.Message", subject: "test.metrics"}))
+	}
+	for range 2 {
+		select {
+		case <-handled:
+		case <-time.After(time.Second):
+			t.Fatal("the messages were not handled")
+		}
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(published)-before)
+	require.Eventually(t, func() bool {
+		return testutil.CollectAndCount(handlerDuration, namespace+"_message_handler_duration_seconds") == 2
+	}, time.Second, 10*time.Millisecond, "expected a success and a failure series for the group")
+}
@@ -0,0 +1,60 @@
+package prom
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"eda-in-golang/internal/tm"
+)
+
+var (
+	outboxBacklog = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "outbox_backlog_messages",
+		Help:      "The messages waiting in the outbox to be published",
+	}, []string{"outbox"})
+
+	outboxOldestAge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "outbox_oldest_message_age_seconds",
+		Help:      "How long the oldest message waiting in the outbox has been there",
+	}, []string{"outbox"})
+
+	inboxDuplicates = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "inbox_duplicates_total",
+		Help:      "The messages dropped by the inbox because they had been handled before",
+	}, []string{"handler", "subject"})
+)
+
+type (
+	outboxMetrics struct {
+		outboxName string
+	}
+
+	inboxMetrics struct{}
+)
+
+var _ tm.OutboxMetrics = (*outboxMetrics)(nil)
+var _ tm.InboxMetrics = (*inboxMetrics)(nil)
+
+// OutboxMetrics records the backlog of the named outbox
+func OutboxMetrics(outboxName string) tm.OutboxMetrics {
+	return outboxMetrics{outboxName: outboxName}
+}
+
+// InboxMetrics counts the duplicate messages dropped by the inbox
+func InboxMetrics() tm.InboxMetrics {
+	return inboxMetrics{}
+}
+
+func (m outboxMetrics) OutboxBacklog(count int, oldestAge time.Duration) {
+	outboxBacklog.WithLabelValues(m.outboxName).Set(float64(count))
+	outboxOldestAge.WithLabelValues(m.outboxName).Set(oldestAge.Seconds())
+}
+
+func (inboxMetrics) InboxDuplicate(handlerName, subject string) {
+	inboxDuplicates.WithLabelValues(handlerName, subject).Inc()
+}
@@ -0,0 +1,26 @@
+package prom
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "mallbots"
+
+const (
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+)
+
+// Handler serves the metrics of the application to Prometheus
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return outcomeFailure
+	}
+	return outcomeSuccess
+}
@@ -0,0 +1,53 @@
+package prom
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"eda-in-golang/internal/sec"
+)
+
+var (
+	sagasStarted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sagas_started_total",
+		Help:      "The sagas started",
+	}, []string{"saga"})
+
+	sagaCompensations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "saga_compensations_total",
+		Help:      "The sagas that began to compensate",
+	}, []string{"saga"})
+
+	sagasCompleted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sagas_completed_total",
+		Help:      "The sagas completed, either by running every step or by compensating",
+	}, []string{"saga", "outcome"})
+)
+
+type sagaMetrics struct{}
+
+var _ sec.SagaMetrics = (*sagaMetrics)(nil)
+
+// SagaMetrics counts the sagas started, compensated and completed
+func SagaMetrics() sec.SagaMetrics {
+	return sagaMetrics{}
+}
+
+func (sagaMetrics) SagaStarted(sagaName string) {
+	sagasStarted.WithLabelValues(sagaName).Inc()
+}
+
+func (sagaMetrics) SagaCompensating(sagaName string) {
+	sagaCompensations.WithLabelValues(sagaName).Inc()
+}
+
+func (sagaMetrics) SagaCompleted(sagaName string, compensated bool) {
+	result := "succeeded"
+	if compensated {
+		result = "compensated"
+	}
+	sagasCompleted.WithLabelValues(sagaName, result).Inc()
+}
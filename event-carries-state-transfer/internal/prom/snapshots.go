@@ -0,0 +1,49 @@
+package prom
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"eda-in-golang/internal/es"
+)
+
+const (
+	loadedFromSnapshot = "snapshot"
+	loadedFromEvents   = "events"
+)
+
+var (
+	snapshotLoads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "snapshot_store_loads_total",
+		Help:      "The aggregates loaded, by whether they started from a snapshot or replayed all of their events",
+	}, []string{"aggregate", "source"})
+
+	snapshotsTaken = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "snapshot_store_snapshots_taken_total",
+		Help:      "The snapshots taken as aggregates were saved",
+	}, []string{"aggregate"})
+)
+
+type snapshotMetrics struct{}
+
+var _ es.SnapshotMetrics = (*snapshotMetrics)(nil)
+
+// SnapshotMetrics counts the loads served by a snapshot, the loads that
+// replayed every event and the snapshots taken, for each aggregate
+func SnapshotMetrics() es.SnapshotMetrics {
+	return snapshotMetrics{}
+}
+
+func (snapshotMetrics) SnapshotHit(aggregateName string) {
+	snapshotLoads.WithLabelValues(aggregateName, loadedFromSnapshot).Inc()
+}
+
+func (snapshotMetrics) FullReplay(aggregateName string) {
+	snapshotLoads.WithLabelValues(aggregateName, loadedFromEvents).Inc()
+}
+
+func (snapshotMetrics) SnapshotTaken(aggregateName string) {
+	snapshotsTaken.WithLabelValues(aggregateName).Inc()
+}
@@ -0,0 +1,27 @@
+package prom
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotMetrics(t *testing.T) {
+	const aggregateName = "test.SnapshotAggregate"
+
+	hits := snapshotLoads.WithLabelValues(aggregateName, loadedFromSnapshot)
+	replays := snapshotLoads.WithLabelValues(aggregateName, loadedFromEvents)
+	taken := snapshotsTaken.WithLabelValues(aggregateName)
+	hitsBefore, replaysBefore, takenBefore := testutil.ToFloat64(hits), testutil.ToFloat64(replays), testutil.ToFloat64(taken)
+
+	metrics := SnapshotMetrics()
+	metrics.SnapshotHit(aggregateName)
+	metrics.SnapshotHit(aggregateName)
+	metrics.FullReplay(aggregateName)
+	metrics.SnapshotTaken(aggregateName)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(hits)-hitsBefore)
+	assert.Equal(t, 1.0, testutil.ToFloat64(replays)-replaysBefore)
+	assert.Equal(t, 1.0, testutil.ToFloat64(taken)-takenBefore)
+}
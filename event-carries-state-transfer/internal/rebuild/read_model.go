@@ -0,0 +1,68 @@
+package rebuild
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"eda-in-golang/internal/es"
+	"eda-in-golang/internal/monolith"
+)
+
+type (
+	// Module is a module with read models that can be rebuilt
+	Module interface {
+		monolith.Module
+		ReadModels(ctx context.Context, mono monolith.Monolith) ([]ReadModel, error)
+	}
+
+	// ReadModel is a read model that can be cleared and rebuilt from the
+	// events it was built from
+	ReadModel interface {
+		ReadModelName() string
+		// Rebuild clears the read model and replays its source events; a dry
+		// run replays them and then discards the result
+		Rebuild(ctx context.Context, dryRun bool, progress Progress) error
+	}
+
+	// Progress reports the position of the last event replayed and how many
+	// events have been replayed so far
+	Progress func(position int64, replayed int)
+
+	// RebuildableProjection is a projection that replays its events within a
+	// single unit of work that replaces the current read model
+	RebuildableProjection interface {
+		ProjectionName() string
+		Rebuild(ctx context.Context, dryRun bool, replay func(ctx context.Context, projection es.Projection) error) error
+	}
+
+	projectionReadModel struct {
+		projection RebuildableProjection
+		feed       es.EventFeed
+		logger     zerolog.Logger
+		options    []es.ProjectorOption
+	}
+)
+
+var _ ReadModel = (*projectionReadModel)(nil)
+
+// FromProjection rebuilds a projection by catching it up with the event feed
+// from the first event
+func FromProjection(projection RebuildableProjection, feed es.EventFeed, logger zerolog.Logger, options ...es.ProjectorOption) ReadModel {
+	return projectionReadModel{
+		projection: projection,
+		feed:       feed,
+		logger:     logger,
+		options:    options,
+	}
+}
+
+func (r projectionReadModel) ReadModelName() string {
+	return r.projection.ProjectionName()
+}
+
+func (r projectionReadModel) Rebuild(ctx context.Context, dryRun bool, progress Progress) error {
+	return r.projection.Rebuild(ctx, dryRun, func(ctx context.Context, projection es.Projection) error {
+		return es.NewProjector(r.feed, projection, r.logger, r.options...).CatchUp(ctx, es.ProjectorProgress(progress))
+	})
+}
@@ -0,0 +1,114 @@
+package registry
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultKeyCacheTTL      = time.Minute
+	defaultKeyLookupTimeout = 5 * time.Second
+)
+
+type (
+	// KeyFinder looks up the key of a subject; it returns a nil key for a
+	// subject that has been forgotten
+	KeyFinder func(ctx context.Context, subject string) ([]byte, error)
+
+	// KeyCache is the Keys of a registry; keys are looked up once and then
+	// kept for a while so that serializing a value does not wait on the key
+	// store each time. A subject forgotten elsewhere may still be read with
+	// its cached key until the key expires.
+	KeyCache struct {
+		find    KeyFinder
+		ttl     time.Duration
+		timeout time.Duration
+		keys    map[string]cachedKey
+		mu      sync.RWMutex
+	}
+
+	KeyCacheOption func(c *KeyCache)
+
+	cachedKey struct {
+		key       []byte
+		expiresAt time.Time
+	}
+)
+
+var _ Keys = (*KeyCache)(nil)
+
+func NewKeyCache(find KeyFinder, options ...KeyCacheOption) *KeyCache {
+	c := &KeyCache{
+		find:    find,
+		ttl:     defaultKeyCacheTTL,
+		timeout: defaultKeyLookupTimeout,
+		keys:    make(map[string]cachedKey),
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// KeyCacheTTL sets how long a key is kept before it is looked up again
+func KeyCacheTTL(ttl time.Duration) KeyCacheOption {
+	return func(c *KeyCache) {
+		if ttl > 0 {
+			c.ttl = ttl
+		}
+	}
+}
+
+// KeyLookupTimeout sets how long a lookup of a key that is not cached may take
+func KeyLookupTimeout(timeout time.Duration) KeyCacheOption {
+	return func(c *KeyCache) {
+		if timeout > 0 {
+			c.timeout = timeout
+		}
+	}
+}
+
+func (c *KeyCache) Key(subject string) ([]byte, error) {
+	c.mu.RLock()
+	cached, exists := c.keys[subject]
+	c.mu.RUnlock()
+
+	if exists && time.Now().Before(cached.expiresAt) {
+		return cached.key, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	key, err := c.find(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Add(subject, key)
+
+	return key, nil
+}
+
+// Add caches a key that was just created so that it can be used before it
+// could be looked up, such as inside the transaction that created it
+func (c *KeyCache) Add(subject string, key []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.keys[subject] = cachedKey{
+		key:       key,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+// Evict drops the cached key of the subject
+func (c *KeyCache) Evict(subject string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.keys, subject)
+}
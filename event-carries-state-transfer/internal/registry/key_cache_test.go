@@ -0,0 +1,98 @@
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/registry"
+)
+
+type keyFinder struct {
+	keys    map[string][]byte
+	lookups int
+}
+
+func (f *keyFinder) Find(ctx context.Context, subject string) ([]byte, error) {
+	f.lookups++
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		return nil, errors.New("the lookup has no deadline")
+	}
+	key, exists := f.keys[subject]
+	if !exists {
+		return nil, errors.New("not found")
+	}
+	return key, nil
+}
+
+func TestKeyCache_Key(t *testing.T) {
+	t.Run("CachesKeys", func(t *testing.T) {
+		finder := &keyFinder{keys: map[string][]byte{"subject": []byte("key")}}
+		cache := registry.NewKeyCache(finder.Find)
+
+		for range 3 {
+			key, err := cache.Key("subject")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("key"), key)
+		}
+		assert.Equal(t, 1, finder.lookups)
+	})
+	t.Run("CachesForgottenSubjects", func(t *testing.T) {
+		finder := &keyFinder{keys: map[string][]byte{"subject": nil}}
+		cache := registry.NewKeyCache(finder.Find)
+
+		for range 2 {
+			key, err := cache.Key("subject")
+			require.NoError(t, err)
+			assert.Nil(t, key)
+		}
+		assert.Equal(t, 1, finder.lookups)
+	})
+	t.Run("DoesNotCacheErrors", func(t *testing.T) {
+		finder := &keyFinder{keys: map[string][]byte{}}
+		cache := registry.NewKeyCache(finder.Find)
+
+		_, err := cache.Key("subject")
+		assert.Error(t, err)
+
+		finder.keys["subject"] = []byte("key")
+		key, err := cache.Key("subject")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("key"), key)
+	})
+	t.Run("ExpiresKeys", func(t *testing.T) {
+		finder := &keyFinder{keys: map[string][]byte{"subject": []byte("key")}}
+		cache := registry.NewKeyCache(finder.Find, registry.KeyCacheTTL(10*time.Millisecond))
+
+		_, err := cache.Key("subject")
+		require.NoError(t, err)
+		finder.keys["subject"] = nil
+		time.Sleep(20 * time.Millisecond)
+
+		key, err := cache.Key("subject")
+		require.NoError(t, err)
+		assert.Nil(t, key)
+		assert.Equal(t, 2, finder.lookups)
+	})
+}
+
+func TestKeyCache_AddAndEvict(t *testing.T) {
+	finder := &keyFinder{keys: map[string][]byte{"subject": nil}}
+	cache := registry.NewKeyCache(finder.Find)
+
+	cache.Add("subject", []byte("key"))
+	key, err := cache.Key("subject")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("key"), key)
+	assert.Zero(t, finder.lookups)
+
+	cache.Evict("subject")
+	key, err = cache.Key("subject")
+	require.NoError(t, err)
+	assert.Nil(t, key)
+	assert.Equal(t, 1, finder.lookups)
+}
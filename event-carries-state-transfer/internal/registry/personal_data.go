@@ -0,0 +1,148 @@
+package registry
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type (
+	// PersonalData is implemented by the registered values that carry
+	// personal data; the fields are encrypted with the key of the subject
+	// they belong to so that deleting the key leaves them unreadable
+	PersonalData interface {
+		PersonalDataSubject() string
+		PersonalDataFields() []*string
+	}
+
+	// Keys holds the encryption key of each subject. Key returns a nil key
+	// for a subject that has been forgotten; keys are created by the owner of
+	// the subject before any of its personal data is serialized
+	Keys interface {
+		Key(subject string) ([]byte, error)
+	}
+)
+
+// Redacted replaces the personal data that can no longer be decrypted
+const Redacted = "[redacted]"
+
+const encryptedPrefix = "pii:v1:"
+
+// WithPersonalData encrypts the personal data fields of values as they are
+// serialized and decrypts them as they are deserialized. Without it the fields
+// are serialized as they are and encrypted fields are left encrypted.
+func WithPersonalData(keys Keys) RegistryOption {
+	return func(r *registry) {
+		r.keys = keys
+	}
+}
+
+// encryptPersonalData works on a copy built from the serialized data so the
+// value that was passed in is not modified
+func (r *registry) encryptPersonalData(reg registered, data []byte) ([]byte, error) {
+	v := reg.factory()
+	if err := reg.deserializer(data, v); err != nil {
+		return nil, err
+	}
+
+	pd := v.(PersonalData)
+	key, err := r.keys.Key(pd.PersonalDataSubject())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range pd.PersonalDataFields() {
+		if *field == "" || strings.HasPrefix(*field, encryptedPrefix) {
+			continue
+		}
+		if key == nil {
+			*field = Redacted
+			continue
+		}
+		if *field, err = encryptField(key, *field); err != nil {
+			return nil, err
+		}
+	}
+
+	return reg.serializer(v)
+}
+
+func (r *registry) decryptPersonalData(pd PersonalData) error {
+	var key []byte
+	var loaded bool
+
+	for _, field := range pd.PersonalDataFields() {
+		if !strings.HasPrefix(*field, encryptedPrefix) {
+			continue
+		}
+		if !loaded {
+			var err error
+			if key, err = r.keys.Key(pd.PersonalDataSubject()); err != nil {
+				return err
+			}
+			loaded = true
+		}
+		if key == nil {
+			*field = Redacted
+			continue
+		}
+		value, err := decryptField(key, *field)
+		if err != nil {
+			return err
+		}
+		*field = value
+	}
+
+	return nil
+}
+
+func encryptField(key []byte, value string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+
+	return encryptedPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func decryptField(key []byte, value string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("encrypted field is too short")
+	}
+
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,65 @@
+package registry_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/registry"
+	"eda-in-golang/internal/registry/serdes"
+)
+
+type contact struct {
+	ID    string
+	Name  string
+	Notes string
+}
+
+func (contact) Key() string { return "test.Contact" }
+
+func (c *contact) PersonalDataSubject() string   { return c.ID }
+func (c *contact) PersonalDataFields() []*string { return []*string{&c.Name} }
+
+type keys map[string][]byte
+
+func (k keys) Key(subject string) ([]byte, error) {
+	key, exists := k[subject]
+	if !exists {
+		return nil, fmt.Errorf("the subject %s has no key", subject)
+	}
+	return key, nil
+}
+
+func TestRegistry_PersonalData(t *testing.T) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	ks := keys{"contact-id": key}
+	reg := registry.New(registry.WithPersonalData(ks))
+	require.NoError(t, serdes.NewJsonSerde(reg).Register(contact{}))
+
+	original := &contact{ID: "contact-id", Name: "Jane", Notes: "prefers email"}
+	data, err := reg.Serialize(original.Key(), original)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Jane", original.Name, "the serialized value was modified")
+	assert.False(t, strings.Contains(string(data), "Jane"), "the name was not encrypted")
+	assert.True(t, strings.Contains(string(data), "prefers email"), "an untagged field was encrypted")
+
+	v, err := reg.Deserialize(original.Key(), data)
+	require.NoError(t, err)
+	assert.Equal(t, original, v)
+
+	// forget the subject
+	ks[original.ID] = nil
+
+	v, err = reg.Deserialize(original.Key(), data)
+	require.NoError(t, err)
+	assert.Equal(t, registry.Redacted, v.(*contact).Name)
+	assert.Equal(t, "prefers email", v.(*contact).Notes)
+}
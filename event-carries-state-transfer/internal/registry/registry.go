@@ -31,13 +31,22 @@ type registered struct {
 
 type registry struct {
 	registered map[string]registered
+	keys       Keys
 	mu         sync.RWMutex
 }
 
-func New() *registry {
-	return &registry{
+type RegistryOption func(r *registry)
+
+func New(options ...RegistryOption) *registry {
+	r := &registry{
 		registered: make(map[string]registered),
 	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
 }
 
 func (r *registry) Serialize(key string, v interface{}) ([]byte, error) {
@@ -46,7 +55,16 @@ func (r *registry) Serialize(key string, v interface{}) ([]byte, error) {
 		return nil, UnregisteredKey(key)
 	}
 
-	return reg.serializer(v)
+	data, err := reg.serializer(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := v.(PersonalData); !ok || r.keys == nil {
+		return data, nil
+	}
+
+	return r.encryptPersonalData(reg, data)
 }
 
 func (r *registry) Deserialize(key string, data []byte, options ...BuildOption) (interface{}, error) {
@@ -60,6 +78,12 @@ func (r *registry) Deserialize(key string, data []byte, options ...BuildOption)
 		return nil, err
 	}
 
+	if pd, ok := v.(PersonalData); ok && r.keys != nil {
+		if err = r.decryptPersonalData(pd); err != nil {
+			return nil, err
+		}
+	}
+
 	return v, nil
 }
 
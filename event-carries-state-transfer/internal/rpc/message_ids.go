@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"eda-in-golang/internal/am"
+)
+
+// MessageIDsServerInterceptor starts a correlation for each request so that
+// the messages published while handling it share a correlation ID and name the
+// request as their cause. A request made by a client that passes its
+// correlation ID along continues that correlation instead.
+func MessageIDsServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		requestID := uuid.NewString()
+		ids := am.MessageIDs{
+			CorrelationID: requestID,
+			MessageID:     requestID,
+		}
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(am.CorrelationIDKey); len(values) > 0 && values[0] != "" {
+				ids.CorrelationID = values[0]
+			}
+		}
+
+		return handler(am.WithMessageIDs(ctx, ids), req)
+	}
+}
+
+// MessageIDsClientInterceptor passes the correlation ID of the message or
+// request being handled along with the requests made while handling it
+func MessageIDsClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if correlationID := am.MessageIDsFrom(ctx).CorrelationID; correlationID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, am.CorrelationIDKey, correlationID)
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,59 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"eda-in-golang/internal/am"
+)
+
+func TestMessageIDsServerInterceptor(t *testing.T) {
+	intercept := func(ctx context.Context) am.MessageIDs {
+		var ids am.MessageIDs
+		_, err := MessageIDsServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ any) (any, error) {
+			ids = am.MessageIDsFrom(ctx)
+			return nil, nil
+		})
+		require.NoError(t, err)
+		return ids
+	}
+
+	t.Run("StartsACorrelation", func(t *testing.T) {
+		first := intercept(context.Background())
+		second := intercept(context.Background())
+
+		assert.NotEmpty(t, first.CorrelationID)
+		assert.Equal(t, first.CorrelationID, first.MessageID)
+		assert.NotEqual(t, first.CorrelationID, second.CorrelationID, "each request starts its own correlation")
+	})
+	t.Run("ContinuesACorrelation", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(am.CorrelationIDKey, "correlation-id"))
+
+		ids := intercept(ctx)
+		assert.Equal(t, "correlation-id", ids.CorrelationID)
+		assert.NotEqual(t, "correlation-id", ids.MessageID)
+	})
+}
+
+func TestMessageIDsClientInterceptor(t *testing.T) {
+	intercept := func(ctx context.Context) metadata.MD {
+		var md metadata.MD
+		err := MessageIDsClientInterceptor()(ctx, "/test.Service/Method", nil, nil, nil,
+			func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				md, _ = metadata.FromOutgoingContext(ctx)
+				return nil
+			},
+		)
+		require.NoError(t, err)
+		return md
+	}
+
+	ctx := am.WithMessageIDs(context.Background(), am.MessageIDs{CorrelationID: "correlation-id", MessageID: "message-id"})
+	assert.Equal(t, []string{"correlation-id"}, intercept(ctx).Get(am.CorrelationIDKey))
+	assert.Empty(t, intercept(context.Background()).Get(am.CorrelationIDKey))
+}
@@ -2,13 +2,20 @@ package sec
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/stackus/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"eda-in-golang/internal/am"
 	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/tracing"
 )
 
+const tracerName = "eda-in-golang/internal/sec"
+
 type (
 	Orchestrator[T any] interface {
 		Start(ctx context.Context, id string, data T) error
@@ -16,34 +23,75 @@ type (
 		HandleReply(ctx context.Context, reply ddd.Reply) error
 	}
 
+	// SagaMetrics is told when sagas start, begin to compensate and complete
+	SagaMetrics interface {
+		SagaStarted(sagaName string)
+		SagaCompensating(sagaName string)
+		SagaCompleted(sagaName string, compensated bool)
+	}
+
+	OrchestratorOption func(cfg *orchestratorCfg)
+
+	orchestratorCfg struct {
+		metrics SagaMetrics
+	}
+
 	orchestrator[T any] struct {
 		saga      Saga[T]
 		repo      SagaRepository[T]
 		publisher am.CommandPublisher
+		metrics   SagaMetrics
 	}
 )
 
 var _ Orchestrator[any] = (*orchestrator[any])(nil)
 
-func NewOrchestrator[T any](saga Saga[T], repo SagaRepository[T], publisher am.CommandPublisher) Orchestrator[T] {
+func NewOrchestrator[T any](saga Saga[T], repo SagaRepository[T], publisher am.CommandPublisher, options ...OrchestratorOption) Orchestrator[T] {
+	cfg := orchestratorCfg{
+		metrics: noSagaMetrics{},
+	}
+
+	for _, option := range options {
+		option(&cfg)
+	}
+
 	return orchestrator[T]{
 		saga:      saga,
 		repo:      repo,
 		publisher: publisher,
+		metrics:   cfg.metrics,
 	}
 }
 
-func (o orchestrator[T]) Start(ctx context.Context, id string, data T) error {
+// OrchestratorMetrics sets the metrics told how the sagas progress
+func OrchestratorMetrics(metrics SagaMetrics) OrchestratorOption {
+	return func(cfg *orchestratorCfg) {
+		if metrics != nil {
+			cfg.metrics = metrics
+		}
+	}
+}
+
+func (o orchestrator[T]) Start(ctx context.Context, id string, data T) (err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, o.saga.Name()+" start",
+		trace.WithAttributes(
+			attribute.String("saga.name", o.saga.Name()),
+			attribute.String("saga.id", id),
+		),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	sagaCtx := &SagaKontext[T]{
 		ID:   id,
 		Data: data,
 		Step: -1,
 	}
 
-	err := o.repo.Save(ctx, o.saga.Name(), sagaCtx)
+	err = o.repo.Save(ctx, o.saga.Name(), sagaCtx)
 	if err != nil {
 		return err
 	}
+	o.metrics.SagaStarted(o.saga.Name())
 
 	result := o.execute(ctx, sagaCtx)
 	if result.err != nil {
@@ -57,14 +105,23 @@ func (o orchestrator[T]) ReplyTopic() string {
 	return o.saga.ReplyTopic()
 }
 
-func (o orchestrator[T]) HandleReply(ctx context.Context, reply ddd.Reply) error {
+func (o orchestrator[T]) HandleReply(ctx context.Context, reply ddd.Reply) (err error) {
 	sagaID, sagaName := o.getSagaInfoFromReply(reply)
 	if sagaID == "" || sagaName == "" || sagaName != o.saga.Name() {
 		// returning nil to drop bad replies
 		return nil
 	}
 
-	sagaCtx, err := o.repo.Load(ctx, o.saga.Name(), sagaID)
+	ctx, span := otel.Tracer(tracerName).Start(ctx, o.saga.Name()+" reply "+reply.ReplyName(),
+		trace.WithAttributes(
+			attribute.String("saga.name", o.saga.Name()),
+			attribute.String("saga.id", sagaID),
+		),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	var sagaCtx *SagaKontext[T]
+	sagaCtx, err = o.repo.Load(ctx, o.saga.Name(), sagaID)
 	if err != nil {
 		return err
 	}
@@ -80,7 +137,9 @@ func (o orchestrator[T]) HandleReply(ctx context.Context, reply ddd.Reply) error
 func (o orchestrator[T]) handle(ctx context.Context, sagaCtx *SagaKontext[T], reply ddd.Reply) (stepResult[T], error) {
 	step := o.saga.getSteps()[sagaCtx.Step]
 
-	err := step.handle(ctx, sagaCtx, reply)
+	stepCtx, span := o.startStepSpan(ctx, "handle", sagaCtx)
+	err := step.handle(stepCtx, sagaCtx, reply)
+	tracing.End(span, err)
 	if err != nil {
 		return stepResult[T]{}, err
 	}
@@ -99,6 +158,7 @@ func (o orchestrator[T]) handle(ctx context.Context, sagaCtx *SagaKontext[T], re
 		return stepResult[T]{}, errors.ErrInternal.Msg("received failed reply but already compensating")
 	default:
 		sagaCtx.compensate()
+		o.metrics.SagaCompensating(o.saga.Name())
 		return o.execute(ctx, sagaCtx), nil
 	}
 }
@@ -124,12 +184,28 @@ func (o orchestrator[T]) execute(ctx context.Context, sagaCtx *SagaKontext[T]) s
 
 	if step == nil {
 		sagaCtx.complete()
+		o.metrics.SagaCompleted(o.saga.Name(), sagaCtx.Compensating)
 		return stepResult[T]{ctx: sagaCtx}
 	}
 
 	sagaCtx.advance(delta)
 
-	return step.execute(ctx, sagaCtx)
+	ctx, span := o.startStepSpan(ctx, "execute", sagaCtx)
+	result := step.execute(ctx, sagaCtx)
+	tracing.End(span, result.err)
+
+	return result
+}
+
+func (o orchestrator[T]) startStepSpan(ctx context.Context, operation string, sagaCtx *SagaKontext[T]) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, fmt.Sprintf("%s step %d %s", o.saga.Name(), sagaCtx.Step, operation),
+		trace.WithAttributes(
+			attribute.String("saga.name", o.saga.Name()),
+			attribute.String("saga.id", sagaCtx.ID),
+			attribute.Int("saga.step", sagaCtx.Step),
+			attribute.Bool("saga.compensating", sagaCtx.Compensating),
+		),
+	)
 }
 
 func (o orchestrator[T]) processResult(ctx context.Context, result stepResult[T]) (err error) {
@@ -167,3 +243,9 @@ func (o orchestrator[T]) getSagaInfoFromReply(reply ddd.Reply) (string, string)
 
 	return sagaID, sagaName
 }
+
+type noSagaMetrics struct{}
+
+func (noSagaMetrics) SagaStarted(string)         {}
+func (noSagaMetrics) SagaCompensating(string)    {}
+func (noSagaMetrics) SagaCompleted(string, bool) {}
@@ -4,26 +4,72 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 
 	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/tracing"
 )
 
 type ErrDuplicateMessage string
 
-type InboxStore interface {
-	Save(ctx context.Context, msg am.RawMessage) error
-}
+const (
+	InboxHandled = "handled"
+	InboxFailed  = "failed"
+	// InboxSkipped is recorded when a message that was handled before is
+	// delivered again; the record then describes the latest delivery
+	InboxSkipped = "skipped"
+)
+
+type (
+	InboxStore interface {
+		// Save records the receipt of a message; messages that were received
+		// before and did not fail are reported with ErrDuplicateMessage
+		Save(ctx context.Context, msg am.RawMessage) error
+		// Discard undoes the Save along with anything done after it
+		Discard(ctx context.Context) error
+		// Finish records how the handling of a message turned out
+		Finish(ctx context.Context, msg am.RawMessage, result InboxResult) error
+	}
+
+	InboxResult struct {
+		Handler     string
+		Outcome     string
+		Error       string
+		ReceivedAt  time.Time
+		ProcessedAt time.Time
+	}
+
+	// InboxMetrics is told about each message the inbox drops as a duplicate
+	InboxMetrics interface {
+		InboxDuplicate(handlerName, subject string)
+	}
+
+	InboxOption func(i *inbox)
+)
 
 type inbox struct {
-	handler am.RawMessageHandler
-	store   InboxStore
+	handler     am.RawMessageHandler
+	store       InboxStore
+	failures    InboxStore
+	metrics     InboxMetrics
+	handlerName string
 }
 
 var _ am.RawMessageHandler = (*inbox)(nil)
 
-func NewInboxHandlerMiddleware(store InboxStore) am.RawMessageHandlerMiddleware {
+func NewInboxHandlerMiddleware(store InboxStore, options ...InboxOption) am.RawMessageHandlerMiddleware {
 	i := inbox{store: store}
 
+	for _, option := range options {
+		option(&i)
+	}
+
 	return func(handler am.RawMessageHandler) am.RawMessageHandler {
 		i.handler = handler
 
@@ -31,19 +77,89 @@ func NewInboxHandlerMiddleware(store InboxStore) am.RawMessageHandlerMiddleware
 	}
 }
 
-func (i inbox) HandleMessage(ctx context.Context, msg am.IncomingRawMessage) error {
+// InboxHandlerName sets the handler name recorded with each message
+func InboxHandlerName(name string) InboxOption {
+	return func(i *inbox) {
+		i.handlerName = name
+	}
+}
+
+// InboxFailures sets the store used to record failed messages. The store must
+// not share the transaction used by the handler, which is rolled back when the
+// handler fails.
+func InboxFailures(store InboxStore) InboxOption {
+	return func(i *inbox) {
+		i.failures = store
+	}
+}
+
+// InboxDuplicateMetrics sets the metrics told about duplicate messages
+func InboxDuplicateMetrics(metrics InboxMetrics) InboxOption {
+	return func(i *inbox) {
+		i.metrics = metrics
+	}
+}
+
+func (i inbox) HandleMessage(ctx context.Context, msg am.IncomingRawMessage) (err error) {
+	receivedAt := time.Now()
+
+	ctx = tracing.Extract(ctx, am.RawMessageMetadata(msg))
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "inbox "+msg.MessageName(),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingDestinationName(msg.Subject()),
+			semconv.MessagingMessageID(msg.ID()),
+			attribute.String("inbox.handler", i.handlerName),
+		),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	// try to insert the message
-	err := i.store.Save(ctx, msg)
+	err = i.store.Save(ctx, msg)
 	if err != nil {
 		var errDupe ErrDuplicateMessage
 		if errors.As(err, &errDupe) {
 			// duplicate message; return without an error to let the message Ack
-			return nil
+			span.SetAttributes(attribute.Bool("inbox.duplicate", true))
+			if i.metrics != nil {
+				i.metrics.InboxDuplicate(i.handlerName, msg.Subject())
+			}
+			return i.store.Finish(ctx, msg, InboxResult{
+				Handler:     i.handlerName,
+				Outcome:     InboxSkipped,
+				ReceivedAt:  receivedAt,
+				ProcessedAt: time.Now(),
+			})
 		}
 		return err
 	}
 
-	return i.handler.HandleMessage(ctx, msg)
+	result := InboxResult{
+		Handler:    i.handlerName,
+		Outcome:    InboxHandled,
+		ReceivedAt: receivedAt,
+	}
+
+	err = i.handler.HandleMessage(ctx, msg)
+	result.ProcessedAt = time.Now()
+	if err == nil {
+		return i.store.Finish(ctx, msg, result)
+	}
+
+	if i.failures != nil {
+		result.Outcome = InboxFailed
+		result.Error = err.Error()
+		// the receipt is undone first; it would otherwise block the failure
+		// from being recorded until the transaction was rolled back
+		if dErr := i.store.Discard(ctx); dErr != nil {
+			return errors.Join(err, dErr)
+		}
+		if fErr := i.failures.Finish(ctx, msg, result); fErr != nil {
+			return errors.Join(err, fErr)
+		}
+	}
+
+	return err
 }
 
 func (e ErrDuplicateMessage) Error() string {
@@ -0,0 +1,113 @@
+package tm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/am"
+)
+
+type testIncomingMessage struct {
+	testMessage
+}
+
+func (testIncomingMessage) Ack() error    { return nil }
+func (testIncomingMessage) NAck() error   { return nil }
+func (testIncomingMessage) Extend() error { return nil }
+func (testIncomingMessage) Kill() error   { return nil }
+
+// testInboxStore records the outcome of each message it is told about
+type testInboxStore struct {
+	received  map[string]bool
+	outcomes  map[string]InboxResult
+	discarded int
+}
+
+func newTestInboxStore() *testInboxStore {
+	return &testInboxStore{
+		received: make(map[string]bool),
+		outcomes: make(map[string]InboxResult),
+	}
+}
+
+func (s *testInboxStore) Save(_ context.Context, msg am.RawMessage) error {
+	if s.received[msg.ID()] && s.outcomes[msg.ID()].Outcome != InboxFailed {
+		return ErrDuplicateMessage(msg.ID())
+	}
+	s.received[msg.ID()] = true
+	return nil
+}
+
+func (s *testInboxStore) Discard(context.Context) error {
+	s.discarded++
+	return nil
+}
+
+func (s *testInboxStore) Finish(_ context.Context, msg am.RawMessage, result InboxResult) error {
+	s.received[msg.ID()] = true
+	s.outcomes[msg.ID()] = result
+	return nil
+}
+
+type testInboxMetrics struct {
+	duplicates int
+}
+
+func (m *testInboxMetrics) InboxDuplicate(string, string) { m.duplicates++ }
+
+func TestInbox_HandleMessage(t *testing.T) {
+	msg := testIncomingMessage{testMessage{id: "message-id", subject: "test.messages"}}
+
+	newInbox := func(handler am.RawMessageHandler) (am.RawMessageHandler, *testInboxStore, *testInboxStore, *testInboxMetrics) {
+		store, failures, metrics := newTestInboxStore(), newTestInboxStore(), &testInboxMetrics{}
+		mw := NewInboxHandlerMiddleware(store,
+			InboxHandlerName("test"),
+			InboxFailures(failures),
+			InboxDuplicateMetrics(metrics),
+		)
+		return mw(handler), store, failures, metrics
+	}
+	succeed := am.RawMessageHandlerFunc(func(context.Context, am.IncomingRawMessage) error { return nil })
+
+	t.Run("Handled", func(t *testing.T) {
+		inbox, store, _, _ := newInbox(succeed)
+
+		require.NoError(t, inbox.HandleMessage(context.Background(), msg))
+
+		result := store.outcomes["message-id"]
+		assert.Equal(t, InboxHandled, result.Outcome)
+		assert.Equal(t, "test", result.Handler)
+		assert.False(t, result.ProcessedAt.Before(result.ReceivedAt))
+	})
+	t.Run("Failed", func(t *testing.T) {
+		inbox, store, failures, _ := newInbox(am.RawMessageHandlerFunc(func(context.Context, am.IncomingRawMessage) error {
+			return errors.New("handler failed")
+		}))
+
+		assert.Error(t, inbox.HandleMessage(context.Background(), msg))
+
+		assert.Equal(t, 1, store.discarded)
+		assert.NotContains(t, store.outcomes, "message-id")
+		assert.Equal(t, InboxFailed, failures.outcomes["message-id"].Outcome)
+		assert.Equal(t, "handler failed", failures.outcomes["message-id"].Error)
+	})
+	t.Run("Skipped", func(t *testing.T) {
+		handled := 0
+		inbox, store, _, metrics := newInbox(am.RawMessageHandlerFunc(func(context.Context, am.IncomingRawMessage) error {
+			handled++
+			return nil
+		}))
+
+		require.NoError(t, inbox.HandleMessage(context.Background(), msg))
+		require.NoError(t, inbox.HandleMessage(context.Background(), msg))
+
+		assert.Equal(t, 1, handled)
+		assert.Equal(t, 1, metrics.duplicates)
+		assert.Equal(t, InboxSkipped, store.outcomes["message-id"].Outcome)
+		assert.Equal(t, "test", store.outcomes["message-id"].Handler)
+	})
+}
@@ -0,0 +1,52 @@
+package tm
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// InboxReader answers questions about the messages a module has received,
+	// e.g. which messages failed in the last hour
+	InboxReader interface {
+		FindMessages(ctx context.Context, query InboxQuery) ([]InboxRecord, error)
+	}
+
+	// InboxQuery filters the inbox; zero values are not used as filters
+	InboxQuery struct {
+		Outcome string
+		Handler string
+		Name    string
+		Since   time.Time
+		Until   time.Time
+		Limit   int
+	}
+
+	InboxRecord struct {
+		ID          string
+		Name        string
+		Subject     string
+		Handler     string
+		Outcome     string
+		Error       string
+		ReceivedAt  time.Time
+		ProcessedAt time.Time
+	}
+)
+
+// Duration is how long the message took to handle; zero until it is processed
+func (r InboxRecord) Duration() time.Duration {
+	if r.ProcessedAt.IsZero() {
+		return 0
+	}
+
+	return r.ProcessedAt.Sub(r.ReceivedAt)
+}
+
+// FailedSince is a query for the messages that failed after the given time
+func FailedSince(since time.Time) InboxQuery {
+	return InboxQuery{
+		Outcome: InboxFailed,
+		Since:   since,
+	}
+}
@@ -3,14 +3,26 @@ package tm
 import (
 	"context"
 	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 
 	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/tracing"
 )
 
+const tracerName = "eda-in-golang/internal/tm"
+
 type OutboxStore interface {
 	Save(ctx context.Context, msg am.RawMessage) error
-	FindUnpublished(ctx context.Context, limit int) ([]am.RawMessage, error)
+	FindUnpublished(ctx context.Context, limit int, lease time.Duration, ordered bool) ([]am.RawMessage, error)
 	MarkAsPublished(ctx context.Context, ids ...string) error
+	MarkAsFailed(ctx context.Context, id string, reason error) error
+	// Release gives up the lease on messages that were claimed but not tried
+	Release(ctx context.Context, ids ...string) error
 }
 
 type outbox struct {
@@ -30,9 +42,17 @@ func NewOutboxStreamMiddleware(store OutboxStore) am.RawMessageStreamMiddleware
 	}
 }
 
-func (o outbox) Publish(ctx context.Context, topicName string, msg am.RawMessage) error {
+func (o outbox) Publish(ctx context.Context, topicName string, msg am.RawMessage) (err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "outbox save "+msg.MessageName(),
+		trace.WithAttributes(
+			semconv.MessagingDestinationName(topicName),
+			semconv.MessagingMessageID(msg.ID()),
+		),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	// save the message to the outbox store
-	err := o.store.Save(ctx, msg)
+	err = o.store.Save(ctx, msg)
 
 	var errDupe ErrDuplicateMessage
 	if errors.As(err, &errDupe) {
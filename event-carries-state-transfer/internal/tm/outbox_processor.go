@@ -2,31 +2,144 @@ package tm
 
 import (
 	"context"
+	"hash/fnv"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 
 	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/tracing"
 )
 
-const messageLimit = 10
-const pollingIntervalMilliseconds = 500 * time.Millisecond
+const defaultBatchSize = 10
+const defaultPollingInterval = 500 * time.Millisecond
+const defaultWorkers = 1
+const defaultLease = 30 * time.Second
 
 type OutboxProcessor interface {
 	Start(ctx context.Context) error
 }
 
-type outboxProcessor struct {
-	publisher am.RawMessageStream
-	store     OutboxStore
-	logger    zerolog.Logger
+// OutboxBacklogReader reports how many messages are waiting to be published
+// and when the oldest of them was saved
+type OutboxBacklogReader interface {
+	Backlog(ctx context.Context) (count int, oldestAt time.Time, err error)
+}
+
+// OutboxMetrics is told the size of the backlog and the age of its oldest
+// message each time the processor checks the outbox
+type OutboxMetrics interface {
+	OutboxBacklog(count int, oldestAge time.Duration)
+}
+
+// OutboxListener calls notify whenever new messages may have been saved to the
+// outbox; it blocks until the context is done
+type OutboxListener interface {
+	Listen(ctx context.Context, notify func()) error
+}
+
+type (
+	outboxProcessor struct {
+		publisher       am.RawMessageStream
+		store           OutboxStore
+		batchSize       int
+		pollingInterval time.Duration
+		workers         int
+		lease           time.Duration
+		ordered         bool
+		listener        OutboxListener
+		backlog         OutboxBacklogReader
+		metrics         OutboxMetrics
+		logger          zerolog.Logger
+	}
+
+	OutboxProcessorOption func(p *outboxProcessor)
+)
+
+func NewOutboxProcessor(publisher am.RawMessageStream, store OutboxStore, logger zerolog.Logger, options ...OutboxProcessorOption) OutboxProcessor {
+	p := &outboxProcessor{
+		publisher:       publisher,
+		store:           store,
+		batchSize:       defaultBatchSize,
+		pollingInterval: defaultPollingInterval,
+		workers:         defaultWorkers,
+		lease:           defaultLease,
+		ordered:         true,
+		logger:          logger,
+	}
+
+	for _, option := range options {
+		option(p)
+	}
+
+	return p
+}
+
+// OutboxBatchSize sets how many messages are claimed from the outbox at once
+func OutboxBatchSize(size int) OutboxProcessorOption {
+	return func(p *outboxProcessor) {
+		if size > 0 {
+			p.batchSize = size
+		}
+	}
+}
+
+// OutboxPollingInterval sets how long to wait between checks of the outbox
+func OutboxPollingInterval(interval time.Duration) OutboxProcessorOption {
+	return func(p *outboxProcessor) {
+		if interval > 0 {
+			p.pollingInterval = interval
+		}
+	}
+}
+
+// OutboxWorkers sets how many messages from a batch are published in parallel
+func OutboxWorkers(workers int) OutboxProcessorOption {
+	return func(p *outboxProcessor) {
+		if workers > 0 {
+			p.workers = workers
+		}
+	}
+}
+
+// OutboxLease sets how long claimed messages are held by this processor; other
+// processors may claim them once the lease runs out
+func OutboxLease(lease time.Duration) OutboxProcessorOption {
+	return func(p *outboxProcessor) {
+		if lease > 0 {
+			p.lease = lease
+		}
+	}
+}
+
+// OutboxOrderedBySubject controls whether messages with the same subject are
+// always published in the order they were saved. Turning it off lets the
+// workers publish a batch in any order.
+func OutboxOrderedBySubject(ordered bool) OutboxProcessorOption {
+	return func(p *outboxProcessor) {
+		p.ordered = ordered
+	}
+}
+
+// OutboxNotifications wakes the processor as soon as the listener reports new
+// messages; polling continues in case any notifications are lost
+func OutboxNotifications(listener OutboxListener) OutboxProcessorOption {
+	return func(p *outboxProcessor) {
+		p.listener = listener
+	}
 }
 
-func NewOutboxProcessor(publisher am.RawMessageStream, store OutboxStore, logger zerolog.Logger) OutboxProcessor {
-	return &outboxProcessor{
-		publisher: publisher,
-		store:     store,
-		logger:    logger,
+// OutboxBacklogMetrics reports the backlog read from the outbox to the metrics
+// before each check of the outbox
+func OutboxBacklogMetrics(backlog OutboxBacklogReader, metrics OutboxMetrics) OutboxProcessorOption {
+	return func(p *outboxProcessor) {
+		p.backlog = backlog
+		p.metrics = metrics
 	}
 }
 
@@ -46,38 +159,186 @@ func (p outboxProcessor) Start(ctx context.Context) error {
 }
 
 func (p outboxProcessor) processMessages(ctx context.Context) error {
-	ticker := time.NewTicker(pollingIntervalMilliseconds)
+	ticker := time.NewTicker(p.pollingInterval)
 	defer ticker.Stop()
 
+	wakeC := make(chan struct{}, 1)
+	if p.listener != nil {
+		go func() {
+			err := p.listener.Listen(ctx, func() {
+				select {
+				case wakeC <- struct{}{}:
+				default:
+				}
+			})
+			if err != nil && ctx.Err() == nil {
+				p.logger.Error().Err(err).Msg("outbox listener stopped; falling back to polling")
+			}
+		}()
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
-			messages, err := p.store.FindUnpublished(ctx, messageLimit)
+		case <-wakeC:
+		}
+
+		p.reportBacklog(ctx)
+
+		if err := p.drain(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (p outboxProcessor) reportBacklog(ctx context.Context) {
+	if p.backlog == nil || p.metrics == nil {
+		return
+	}
+
+	count, oldestAt, err := p.backlog.Backlog(ctx)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("failed to read the outbox backlog")
+		return
+	}
+
+	var oldestAge time.Duration
+	if count > 0 {
+		oldestAge = time.Since(oldestAt)
+	}
+	p.metrics.OutboxBacklog(count, oldestAge)
+}
+
+// drain keeps claiming batches until the outbox has no more messages ready
+func (p outboxProcessor) drain(ctx context.Context) error {
+	for {
+		count, err := p.processBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if count < p.batchSize || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (p outboxProcessor) processBatch(ctx context.Context) (int, error) {
+	messages, err := p.store.FindUnpublished(ctx, p.batchSize, p.lease, p.ordered)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	p.logger.Info().Int("count", len(messages)).Msg("found unpublished messages to process")
+
+	var mu sync.Mutex
+	var ids []string
+	var errs []error
+	var wg sync.WaitGroup
+
+	for _, queue := range p.partition(messages) {
+		if len(queue) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(queue []am.RawMessage) {
+			defer wg.Done()
+			published, err := p.publishQueue(ctx, queue)
+			mu.Lock()
+			defer mu.Unlock()
+			ids = append(ids, published...)
 			if err != nil {
-				return err
+				errs = append(errs, err)
 			}
+		}(queue)
+	}
 
-			if len(messages) > 0 {
-				p.logger.Info().Int("count", len(messages)).Msg("found unpublished messages to process")
-			}
+	wg.Wait()
 
-			var ids []string
-			for _, msg := range messages {
-				err := p.publisher.Publish(ctx, msg.Subject(), msg)
-				if err != nil {
-					return err
-				}
-				ids = append(ids, msg.ID())
-			}
+	if len(ids) > 0 {
+		if err = p.store.MarkAsPublished(ctx, ids...); err != nil {
+			return 0, err
+		}
+	}
 
-			if len(ids) > 0 {
-				err = p.store.MarkAsPublished(ctx, ids...)
-				if err != nil {
-					return err
-				}
+	if len(errs) > 0 {
+		return 0, errs[0]
+	}
+
+	return len(messages), nil
+}
+
+// partition splits the batch between the workers; when ordered, every message
+// with the same subject is given to the same worker
+func (p outboxProcessor) partition(messages []am.RawMessage) [][]am.RawMessage {
+	queues := make([][]am.RawMessage, p.workers)
+
+	for i, msg := range messages {
+		worker := i % p.workers
+		if p.ordered {
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(msg.Subject()))
+			worker = int(h.Sum32() % uint32(p.workers))
+		}
+		queues[worker] = append(queues[worker], msg)
+	}
+
+	return queues
+}
+
+// publishQueue publishes the messages one at a time and returns the IDs of the
+// ones that were published; a failed message stays in the outbox and, when
+// ordered, holds back the rest of the messages with the same subject. The
+// leases of the messages held back are released so that they are claimed
+// again along with the failed message.
+func (p outboxProcessor) publishQueue(ctx context.Context, queue []am.RawMessage) ([]string, error) {
+	var ids, skipped []string
+	failedSubjects := make(map[string]struct{})
+
+	for _, msg := range queue {
+		if _, failed := failedSubjects[msg.Subject()]; p.ordered && failed {
+			skipped = append(skipped, msg.ID())
+			continue
+		}
+
+		err := p.publish(ctx, msg)
+		if err != nil {
+			// leave the message in the outbox to be tried again
+			p.logger.Error().Err(err).Str("MessageID", msg.ID()).Msg("failed to publish outbox message")
+			if err = p.store.MarkAsFailed(ctx, msg.ID(), err); err != nil {
+				return ids, err
 			}
+			failedSubjects[msg.Subject()] = struct{}{}
+			continue
 		}
+		ids = append(ids, msg.ID())
 	}
+
+	if len(skipped) > 0 {
+		if err := p.store.Release(ctx, skipped...); err != nil {
+			return ids, err
+		}
+	}
+
+	return ids, nil
+}
+
+// publish continues the trace of the message saved to the outbox so that the
+// time it spent waiting there is part of the trace
+func (p outboxProcessor) publish(ctx context.Context, msg am.RawMessage) (err error) {
+	ctx = tracing.Extract(ctx, am.RawMessageMetadata(msg))
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "outbox publish "+msg.MessageName(),
+		trace.WithAttributes(
+			semconv.MessagingDestinationName(msg.Subject()),
+			semconv.MessagingMessageID(msg.ID()),
+		),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	return p.publisher.Publish(ctx, msg.Subject(), msg)
 }
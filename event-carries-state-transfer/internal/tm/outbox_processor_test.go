@@ -0,0 +1,174 @@
+package tm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/internal/am"
+)
+
+type testMessage struct {
+	id      string
+	subject string
+}
+
+func (m testMessage) ID() string          { return m.id }
+func (m testMessage) MessageName() string { return "test.Message" }
+func (m testMessage) Subject() string     { return m.subject }
+func (m testMessage) Data() []byte        { return nil }
+
+type testOutboxStore struct {
+	messages  []am.RawMessage
+	published []string
+	failed    []string
+	released  []string
+	mu        sync.Mutex
+}
+
+func (s *testOutboxStore) Save(context.Context, am.RawMessage) error { return nil }
+
+func (s *testOutboxStore) FindUnpublished(context.Context, int, time.Duration, bool) ([]am.RawMessage, error) {
+	return s.messages, nil
+}
+
+func (s *testOutboxStore) MarkAsPublished(_ context.Context, ids ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.published = append(s.published, ids...)
+	return nil
+}
+
+func (s *testOutboxStore) MarkAsFailed(_ context.Context, id string, _ error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed = append(s.failed, id)
+	return nil
+}
+
+func (s *testOutboxStore) Release(_ context.Context, ids ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.released = append(s.released, ids...)
+	return nil
+}
+
+// testPublisher records the order the messages of each subject were published
+// in; publishing the messages in fail returns an error
+type testPublisher struct {
+	am.RawMessageStream
+	fail      map[string]bool
+	published map[string][]string
+	mu        sync.Mutex
+}
+
+func (p *testPublisher) Publish(_ context.Context, topicName string, msg am.RawMessage) error {
+	// give the other workers a chance to get ahead
+	time.Sleep(time.Duration(rand.Intn(500)) * time.Microsecond)
+
+	if p.fail[msg.ID()] {
+		return errors.New("publish failed")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published[topicName] = append(p.published[topicName], msg.ID())
+	return nil
+}
+
+func testMessages(subjects, perSubject int) []am.RawMessage {
+	var msgs []am.RawMessage
+	for i := range perSubject {
+		for s := range subjects {
+			msgs = append(msgs, testMessage{
+				id:      fmt.Sprintf("subject-%d-%d", s, i),
+				subject: fmt.Sprintf("subject-%d", s),
+			})
+		}
+	}
+	return msgs
+}
+
+func TestOutboxProcessor_partition(t *testing.T) {
+	msgs := testMessages(5, 4)
+
+	t.Run("Ordered", func(t *testing.T) {
+		p := NewOutboxProcessor(nil, nil, zerolog.Nop(), OutboxWorkers(3)).(*outboxProcessor)
+
+		workers := make(map[string]int)
+		total := 0
+		for worker, queue := range p.partition(msgs) {
+			total += len(queue)
+			for _, msg := range queue {
+				if w, exists := workers[msg.Subject()]; exists {
+					assert.Equal(t, w, worker, "subject %s was split between workers", msg.Subject())
+				}
+				workers[msg.Subject()] = worker
+			}
+		}
+		assert.Equal(t, len(msgs), total)
+	})
+	t.Run("Unordered", func(t *testing.T) {
+		p := NewOutboxProcessor(nil, nil, zerolog.Nop(), OutboxWorkers(3), OutboxOrderedBySubject(false)).(*outboxProcessor)
+
+		queues := p.partition(msgs)
+		require.Len(t, queues, 3)
+		for _, queue := range queues {
+			assert.InDelta(t, len(msgs)/3, len(queue), 1)
+		}
+	})
+}
+
+func TestOutboxProcessor_processBatch(t *testing.T) {
+	msgs := testMessages(5, 20)
+
+	t.Run("PublishesInOrder", func(t *testing.T) {
+		store := &testOutboxStore{messages: msgs}
+		publisher := &testPublisher{published: make(map[string][]string)}
+		p := NewOutboxProcessor(publisher, store, zerolog.Nop(), OutboxWorkers(4), OutboxBatchSize(len(msgs)))
+
+		count, err := p.(*outboxProcessor).processBatch(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, len(msgs), count)
+		assert.Len(t, store.published, len(msgs))
+
+		for s := range 5 {
+			subject := fmt.Sprintf("subject-%d", s)
+			require.Len(t, publisher.published[subject], 20)
+			for i, id := range publisher.published[subject] {
+				assert.Equal(t, fmt.Sprintf("%s-%d", subject, i), id)
+			}
+		}
+	})
+	t.Run("FailureHoldsBackTheSubject", func(t *testing.T) {
+		store := &testOutboxStore{messages: msgs}
+		publisher := &testPublisher{
+			fail:      map[string]bool{"subject-2-5": true},
+			published: make(map[string][]string),
+		}
+		p := NewOutboxProcessor(publisher, store, zerolog.Nop(), OutboxWorkers(4), OutboxBatchSize(len(msgs)))
+
+		_, err := p.(*outboxProcessor).processBatch(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"subject-2-5"}, store.failed)
+		assert.Len(t, publisher.published["subject-2"], 5)
+		assert.Len(t, publisher.published["subject-3"], 20)
+		assert.NotContains(t, store.published, "subject-2-6")
+
+		// the messages held back are not left leased until the lease runs out
+		var held []string
+		for i := 6; i < 20; i++ {
+			held = append(held, fmt.Sprintf("subject-2-%d", i))
+		}
+		assert.Equal(t, held, store.released)
+	})
+}
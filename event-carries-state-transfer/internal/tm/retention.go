@@ -0,0 +1,186 @@
+package tm
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const defaultRetentionInterval = time.Hour
+const defaultRetentionBatchSize = 1000
+const defaultOutboxRetention = 7 * 24 * time.Hour
+const defaultInboxRetention = 7 * 24 * time.Hour
+
+// MinInboxRetention is the shortest time a received message is remembered by
+// the inbox. Deleting inbox rows sooner would let a late redelivery, or the
+// replay of a dead-lettered message, be handled a second time.
+const MinInboxRetention = 24 * time.Hour
+
+type (
+	// RetentionStore deletes up to limit rows that are older than the cutoff;
+	// for the outbox only rows that have been published are considered
+	RetentionStore interface {
+		DeleteBefore(ctx context.Context, cutoff time.Time, limit int) (int64, error)
+	}
+
+	Retention interface {
+		Start(ctx context.Context) error
+		Counts() RetentionCounts
+	}
+
+	// RetentionCounts are the rows removed since the retention was started
+	RetentionCounts struct {
+		Outbox  int64
+		Inbox   int64
+		LastRun time.Time
+	}
+
+	retention struct {
+		outbox          RetentionStore
+		inbox           RetentionStore
+		interval        time.Duration
+		batchSize       int
+		outboxRetention time.Duration
+		inboxRetention  time.Duration
+		counts          RetentionCounts
+		mu              sync.Mutex
+		logger          zerolog.Logger
+	}
+
+	RetentionOption func(r *retention)
+)
+
+// NewRetention periodically removes old rows from an outbox and inbox; either
+// store may be nil when a module does not have that table
+func NewRetention(outbox, inbox RetentionStore, logger zerolog.Logger, options ...RetentionOption) Retention {
+	r := &retention{
+		outbox:          outbox,
+		inbox:           inbox,
+		interval:        defaultRetentionInterval,
+		batchSize:       defaultRetentionBatchSize,
+		outboxRetention: defaultOutboxRetention,
+		inboxRetention:  defaultInboxRetention,
+		logger:          logger,
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	if r.inboxRetention < MinInboxRetention {
+		r.logger.Warn().
+			Dur("Requested", r.inboxRetention).
+			Dur("Minimum", MinInboxRetention).
+			Msg("inbox retention is too short to protect against duplicates; using the minimum")
+		r.inboxRetention = MinInboxRetention
+	}
+
+	return r
+}
+
+func RetentionInterval(interval time.Duration) RetentionOption {
+	return func(r *retention) {
+		if interval > 0 {
+			r.interval = interval
+		}
+	}
+}
+
+// RetentionBatchSize sets how many rows are deleted by a single statement
+func RetentionBatchSize(size int) RetentionOption {
+	return func(r *retention) {
+		if size > 0 {
+			r.batchSize = size
+		}
+	}
+}
+
+// OutboxRetention sets how long published messages are kept in the outbox
+func OutboxRetention(window time.Duration) RetentionOption {
+	return func(r *retention) {
+		if window > 0 {
+			r.outboxRetention = window
+		}
+	}
+}
+
+// InboxRetention sets how long received messages are kept in the inbox; it is
+// never less than MinInboxRetention
+func InboxRetention(window time.Duration) RetentionOption {
+	return func(r *retention) {
+		if window > 0 {
+			r.inboxRetention = window
+		}
+	}
+}
+
+// Start runs the cleanup right away and then every interval until the context
+// is done
+func (r *retention) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *retention) Counts() RetentionCounts {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.counts
+}
+
+func (r *retention) run(ctx context.Context) {
+	now := time.Now()
+
+	outboxDeleted := r.purge(ctx, "outbox", r.outbox, now.Add(-r.outboxRetention))
+	inboxDeleted := r.purge(ctx, "inbox", r.inbox, now.Add(-r.inboxRetention))
+
+	r.mu.Lock()
+	r.counts.Outbox += outboxDeleted
+	r.counts.Inbox += inboxDeleted
+	r.counts.LastRun = now
+	r.mu.Unlock()
+
+	if outboxDeleted > 0 || inboxDeleted > 0 {
+		r.logger.Info().
+			Int64("Outbox", outboxDeleted).
+			Int64("Inbox", inboxDeleted).
+			Msg("removed expired messages")
+	}
+}
+
+// purge deletes in batches so that no single statement holds locks on a large
+// part of the table
+func (r *retention) purge(ctx context.Context, name string, store RetentionStore, cutoff time.Time) int64 {
+	if store == nil {
+		return 0
+	}
+
+	var total int64
+	for ctx.Err() == nil {
+		deleted, err := store.DeleteBefore(ctx, cutoff, r.batchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Error().Err(err).Str("Table", name).Msg("failed to remove expired messages")
+			}
+			break
+		}
+		total += deleted
+		if deleted < int64(r.batchSize) {
+			break
+		}
+	}
+
+	return total
+}
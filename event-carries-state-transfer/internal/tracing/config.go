@@ -0,0 +1,13 @@
+package tracing
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+type TracingConfig struct {
+	Exporter    string `default:"none"`
+	Endpoint    string `default:"localhost:4317"`
+	ServiceName string `envconfig:"SERVICE_NAME" default:"mallbots"`
+}
@@ -0,0 +1,101 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+
+	"eda-in-golang/internal/ddd"
+)
+
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// NewTracerProvider returns a provider that sends spans to the configured
+// exporter; with no exporter the spans are still created so that the trace
+// context is passed along, but they are not sent anywhere
+func NewTracerProvider(ctx context.Context, cfg TracingConfig) (*sdktrace.TracerProvider, error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName))),
+	}
+
+	switch cfg.Exporter {
+	case ExporterNone, "":
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case ExporterOTLP:
+		exporter, err := otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpoint(cfg.Endpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+
+	return sdktrace.NewTracerProvider(options...), nil
+}
+
+// Register makes the provider and the trace context propagation used by this
+// package the global ones
+func Register(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+}
+
+// Inject adds the trace context of ctx to the metadata of an outgoing message
+func Inject(ctx context.Context, metadata ddd.Metadata) {
+	propagator.Inject(ctx, metadataCarrier(metadata))
+}
+
+// Extract returns a context that continues the trace carried by the metadata
+// of an incoming message
+func Extract(ctx context.Context, metadata ddd.Metadata) context.Context {
+	return propagator.Extract(ctx, metadataCarrier(metadata))
+}
+
+// End records the outcome of the work done in the span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+type metadataCarrier ddd.Metadata
+
+var _ propagation.TextMapCarrier = (*metadataCarrier)(nil)
+
+func (c metadataCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
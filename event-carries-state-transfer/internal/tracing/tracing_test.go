@@ -0,0 +1,76 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/memstream"
+	"eda-in-golang/internal/registry"
+	"eda-in-golang/internal/registry/serdes"
+	"eda-in-golang/internal/tracing"
+)
+
+type orderPlaced struct {
+	OrderID string
+}
+
+func (orderPlaced) Key() string { return "test.OrderPlaced" }
+
+func TestTracing_MessageMetadata(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracing.Register(tp)
+
+	reg := registry.New()
+	require.NoError(t, serdes.NewJsonSerde(reg).Register(orderPlaced{}))
+	stream := am.NewEventStream(reg, memstream.NewStream(zerolog.Nop()))
+
+	handled := make(chan struct{})
+	err := stream.Subscribe("test.orders", am.MessageHandlerFunc[am.IncomingEventMessage](
+		func(ctx context.Context, msg am.IncomingEventMessage) error {
+			defer close(handled)
+			return msg.Ack()
+		},
+	))
+	require.NoError(t, err)
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "checkout")
+	err = stream.Publish(ctx, "test.orders", ddd.NewEvent(orderPlaced{}.Key(), &orderPlaced{OrderID: "order-id"}))
+	require.NoError(t, err)
+	span.End()
+
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Fatal("the event was not handled")
+	}
+	require.NoError(t, tp.ForceFlush(context.Background()))
+
+	spans := make(map[string]tracetest.SpanStub)
+	require.Eventually(t, func() bool {
+		for _, s := range exporter.GetSpans() {
+			spans[s.Name] = s
+		}
+		return len(spans) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	root := spans["checkout"]
+	publish := spans["publish test.OrderPlaced"]
+	handle := spans["handle test.OrderPlaced"]
+
+	assert.Equal(t, root.SpanContext.TraceID(), handle.SpanContext.TraceID(), "the handler did not continue the trace")
+	assert.Equal(t, root.SpanContext.SpanID(), publish.Parent.SpanID())
+	assert.Equal(t, publish.SpanContext.SpanID(), handle.Parent.SpanID())
+	assert.True(t, handle.Parent.IsRemote())
+}
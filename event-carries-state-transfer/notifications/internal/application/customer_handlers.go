@@ -26,17 +26,29 @@ func (h CustomerHandlers[T]) HandleEvent(ctx context.Context, event T) error {
 		return h.onCustomerRegistered(ctx, event)
 	case customerspb.CustomerSmsChangedEvent:
 		return h.onCustomerSmsChanged(ctx, event)
+	case customerspb.CustomerForgottenEvent:
+		return h.onCustomerForgotten(ctx, event)
 	}
 
 	return nil
 }
 
+// onCustomerRegistered caches the customer read from the customers module; the
+// personal data in the event is encrypted
 func (h CustomerHandlers[T]) onCustomerRegistered(ctx context.Context, event T) error {
 	payload := event.Payload().(*customerspb.CustomerRegistered)
-	return h.cache.Add(ctx, payload.Id, payload.Name, payload.SmsNumber)
+	_, err := h.cache.Find(ctx, payload.Id)
+	return err
 }
 
+// onCustomerSmsChanged drops the cached customer; the new number is read from
+// the customers module the next time the customer is needed
 func (h CustomerHandlers[T]) onCustomerSmsChanged(ctx context.Context, event T) error {
 	payload := event.Payload().(*customerspb.CustomerSmsChanged)
-	return h.cache.UpdateSmsNumber(ctx, payload.Id, payload.SmsNumber)
+	return h.cache.Remove(ctx, payload.Id)
+}
+
+func (h CustomerHandlers[T]) onCustomerForgotten(ctx context.Context, event T) error {
+	payload := event.Payload().(*customerspb.CustomerForgotten)
+	return h.cache.Remove(ctx, payload.Id)
 }
@@ -6,4 +6,5 @@ type CustomerCacheRepository interface {
 	CustomerRepository
 	Add(ctx context.Context, customerID, name, smsNumber string) error
 	UpdateSmsNumber(ctx context.Context, customerID, smsNumber string) error
+	Remove(ctx context.Context, customerID string) error
 }
@@ -33,6 +33,7 @@ func RegisterIntegrationEventHandlers(subscriber am.EventSubscriber, handler ddd
 	err = subscriber.Subscribe(customerspb.CustomerAggregateChannel, evtMsgHandler, am.MessageFilter{
 		customerspb.CustomerRegisteredEvent,
 		customerspb.CustomerSmsChangedEvent,
+		customerspb.CustomerForgottenEvent,
 	}, am.GroupName("notification-customers"))
 	if err != nil {
 		return err
@@ -57,6 +58,8 @@ func (h integrationHandlers[T]) HandleEvent(ctx context.Context, event T) error
 		return h.onCustomerRegistered(ctx, event)
 	case customerspb.CustomerSmsChangedEvent:
 		return h.onCustomerSmsChanged(ctx, event)
+	case customerspb.CustomerForgottenEvent:
+		return h.onCustomerForgotten(ctx, event)
 	case orderingpb.OrderCreatedEvent:
 		return h.onOrderCreated(ctx, event)
 	case orderingpb.OrderReadiedEvent:
@@ -68,14 +71,24 @@ func (h integrationHandlers[T]) HandleEvent(ctx context.Context, event T) error
 	return nil
 }
 
+// onCustomerRegistered caches the customer read from the customers module; the
+// personal data in the event is encrypted
 func (h integrationHandlers[T]) onCustomerRegistered(ctx context.Context, event T) error {
 	payload := event.Payload().(*customerspb.CustomerRegistered)
-	return h.customers.Add(ctx, payload.GetId(), payload.GetName(), payload.GetSmsNumber())
+	_, err := h.customers.Find(ctx, payload.GetId())
+	return err
 }
 
+// onCustomerSmsChanged drops the cached customer; the new number is read from
+// the customers module the next time the customer is needed
 func (h integrationHandlers[T]) onCustomerSmsChanged(ctx context.Context, event T) error {
 	payload := event.Payload().(*customerspb.CustomerSmsChanged)
-	return h.customers.UpdateSmsNumber(ctx, payload.GetId(), payload.GetSmsNumber())
+	return h.customers.Remove(ctx, payload.GetId())
+}
+
+func (h integrationHandlers[T]) onCustomerForgotten(ctx context.Context, event T) error {
+	payload := event.Payload().(*customerspb.CustomerForgotten)
+	return h.customers.Remove(ctx, payload.GetId())
 }
 
 func (h integrationHandlers[T]) onOrderCreated(ctx context.Context, event T) error {
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/customers/customerspb"
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/notifications/internal/domain"
+)
+
+// testCustomerCache reads the customers it does not have from the customers
+// module, as the postgres cache does
+type testCustomerCache struct {
+	cached    map[string]*domain.Customer
+	customers map[string]*domain.Customer
+}
+
+func (c testCustomerCache) Find(ctx context.Context, customerID string) (*domain.Customer, error) {
+	if customer, exists := c.cached[customerID]; exists {
+		return customer, nil
+	}
+	customer := c.customers[customerID]
+	return customer, c.Add(ctx, customer.ID, customer.Name, customer.SmsNumber)
+}
+
+func (c testCustomerCache) Add(_ context.Context, customerID, name, smsNumber string) error {
+	c.cached[customerID] = &domain.Customer{ID: customerID, Name: name, SmsNumber: smsNumber}
+	return nil
+}
+
+func (c testCustomerCache) UpdateSmsNumber(_ context.Context, customerID, smsNumber string) error {
+	c.cached[customerID].SmsNumber = smsNumber
+	return nil
+}
+
+func (c testCustomerCache) Remove(_ context.Context, customerID string) error {
+	delete(c.cached, customerID)
+	return nil
+}
+
+func newTestCustomerCache() testCustomerCache {
+	return testCustomerCache{
+		cached: map[string]*domain.Customer{
+			"other-id": {ID: "other-id", Name: "John", SmsNumber: "555-0101"},
+		},
+		customers: map[string]*domain.Customer{
+			"customer-id": {ID: "customer-id", Name: "Jane", SmsNumber: "555-0100"},
+			"other-id":    {ID: "other-id", Name: "John", SmsNumber: "555-0101"},
+		},
+	}
+}
+
+func TestIntegrationHandlers_onCustomerRegistered(t *testing.T) {
+	customers := newTestCustomerCache()
+	handlers := NewIntegrationEventHandlers(nil, customers)
+
+	// the personal data in the event cannot be read outside of the customers module
+	err := handlers.HandleEvent(context.Background(), ddd.NewEvent(
+		customerspb.CustomerRegisteredEvent, &customerspb.CustomerRegistered{
+			Id: "customer-id", Name: "pii:v1:encrypted", SmsNumber: "pii:v1:encrypted",
+		},
+	))
+	require.NoError(t, err)
+
+	assert.Equal(t, &domain.Customer{ID: "customer-id", Name: "Jane", SmsNumber: "555-0100"}, customers.cached["customer-id"])
+}
+
+func TestIntegrationHandlers_onCustomerSmsChanged(t *testing.T) {
+	customers := newTestCustomerCache()
+	customers.customers["other-id"].SmsNumber = "555-0199"
+	handlers := NewIntegrationEventHandlers(nil, customers)
+
+	err := handlers.HandleEvent(context.Background(), ddd.NewEvent(
+		customerspb.CustomerSmsChangedEvent, &customerspb.CustomerSmsChanged{Id: "other-id", SmsNumber: "pii:v1:encrypted"},
+	))
+	require.NoError(t, err)
+
+	customer, err := customers.Find(context.Background(), "other-id")
+	require.NoError(t, err)
+	assert.Equal(t, "555-0199", customer.SmsNumber)
+}
+
+func TestIntegrationHandlers_onCustomerForgotten(t *testing.T) {
+	customers := newTestCustomerCache()
+	require.NoError(t, customers.Add(context.Background(), "customer-id", "Jane", "555-0100"))
+	handlers := NewIntegrationEventHandlers(nil, customers)
+
+	err := handlers.HandleEvent(context.Background(), ddd.NewEvent(
+		customerspb.CustomerForgottenEvent, &customerspb.CustomerForgotten{Id: "customer-id"},
+	))
+	require.NoError(t, err)
+
+	assert.NotContains(t, customers.cached, "customer-id")
+	assert.Contains(t, customers.cached, "other-id")
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"eda-in-golang/internal/am"
 	"eda-in-golang/notifications/internal/application"
 )
 
@@ -23,19 +24,25 @@ func LogApplicationAccess(app application.App, logger zerolog.Logger) Applicatio
 }
 
 func (a ApplicationLogger) NotifyOrderCreated(ctx context.Context, notify application.OrderCreated) (err error) {
-	a.logger.Info().Msg("--> Notifications.NotifyOrderCreated")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Notifications.NotifyOrderCreated") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Notifications.NotifyOrderCreated")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Notifications.NotifyOrderCreated")
+	}()
 	return a.App.NotifyOrderCreated(ctx, notify)
 }
 
 func (a ApplicationLogger) NotifyOrderCanceled(ctx context.Context, notify application.OrderCanceled) (err error) {
-	a.logger.Info().Msg("--> Notifications.NotifyOrderCanceled")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Notifications.NotifyOrderCanceled") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Notifications.NotifyOrderCanceled")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Notifications.NotifyOrderCanceled")
+	}()
 	return a.App.NotifyOrderCanceled(ctx, notify)
 }
 
 func (a ApplicationLogger) NotifyOrderReady(ctx context.Context, notify application.OrderReady) (err error) {
-	a.logger.Info().Msg("--> Notifications.NotifyOrderReady")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Notifications.NotifyOrderReady") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Notifications.NotifyOrderReady")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Notifications.NotifyOrderReady")
+	}()
 	return a.App.NotifyOrderReady(ctx, notify)
 }
@@ -52,6 +52,14 @@ func (r CustomerCacheRepository) UpdateSmsNumber(ctx context.Context, customerID
 	return err
 }
 
+func (r CustomerCacheRepository) Remove(ctx context.Context, customerID string) error {
+	const query = `DELETE FROM %s WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, r.table(query), customerID)
+
+	return err
+}
+
 func (r CustomerCacheRepository) Find(ctx context.Context, customerID string) (*domain.Customer, error) {
 	const query = `SELECT name, sms_number FROM %s WHERE id = $1 LIMIT 1`
 
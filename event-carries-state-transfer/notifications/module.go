@@ -6,8 +6,8 @@ import (
 	"eda-in-golang/customers/customerspb"
 	"eda-in-golang/internal/am"
 	"eda-in-golang/internal/ddd"
-	"eda-in-golang/internal/jetstream"
 	"eda-in-golang/internal/monolith"
+	"eda-in-golang/internal/prom"
 	"eda-in-golang/internal/registry"
 	"eda-in-golang/notifications/internal/application"
 	"eda-in-golang/notifications/internal/grpc"
@@ -25,6 +25,12 @@ func (Module) Name() string {
 
 func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 	// setup Driven adapters
+	conn, err := grpc.Dial(ctx, mono.Config().Rpc.Address())
+	if err != nil {
+		return err
+	}
+	// the personal data in customer events can only be read by the customers
+	// module; customers are read through its API instead
 	reg := registry.New()
 	if err = customerspb.Registrations(reg); err != nil {
 		return err
@@ -32,11 +38,11 @@ func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error)
 	if err = orderingpb.Registrations(reg); err != nil {
 		return err
 	}
-	eventStream := am.NewEventStream(reg, jetstream.NewStream(mono.Config().Nats.Stream, mono.JS(), mono.Logger()))
-	conn, err := grpc.Dial(ctx, mono.Config().Rpc.Address())
-	if err != nil {
-		return err
-	}
+	stream := am.RawMessageStreamWithMiddleware(
+		mono.Stream(mono.Logger()),
+		prom.StreamMetrics(),
+	)
+	eventStream := am.NewEventStream(reg, stream)
 	customers := postgres.NewCustomerCacheRepository("notifications.customers_cache", mono.DB(), grpc.NewCustomerRepository(conn))
 
 	// setup application
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/es"
 	"eda-in-golang/ordering/internal/application/commands"
 	"eda-in-golang/ordering/internal/application/queries"
 	"eda-in-golang/ordering/internal/domain"
@@ -24,6 +25,8 @@ type (
 	}
 	Queries interface {
 		GetOrder(ctx context.Context, query queries.GetOrder) (*domain.Order, error)
+		GetOrderAt(ctx context.Context, query queries.GetOrderAt) (*domain.Order, error)
+		GetOrderHistory(ctx context.Context, query queries.GetOrderHistory) ([]es.HistoryEvent, error)
 	}
 
 	Application struct {
@@ -40,12 +43,14 @@ type (
 	}
 	appQueries struct {
 		queries.GetOrderHandler
+		queries.GetOrderAtHandler
+		queries.GetOrderHistoryHandler
 	}
 )
 
 var _ App = (*Application)(nil)
 
-func New(orders domain.OrderRepository, publisher ddd.EventPublisher[ddd.Event]) *Application {
+func New(orders domain.OrderRepository, history es.HistoryReader, publisher ddd.EventPublisher[ddd.Event]) *Application {
 	return &Application{
 		appCommands: appCommands{
 			CreateOrderHandler:   commands.NewCreateOrderHandler(orders, publisher),
@@ -56,7 +61,9 @@ func New(orders domain.OrderRepository, publisher ddd.EventPublisher[ddd.Event])
 			CompleteOrderHandler: commands.NewCompleteOrderHandler(orders, publisher),
 		},
 		appQueries: appQueries{
-			GetOrderHandler: queries.NewGetOrderHandler(orders),
+			GetOrderHandler:        queries.NewGetOrderHandler(orders),
+			GetOrderAtHandler:      queries.NewGetOrderAtHandler(orders),
+			GetOrderHistoryHandler: queries.NewGetOrderHistoryHandler(history),
 		},
 	}
 }
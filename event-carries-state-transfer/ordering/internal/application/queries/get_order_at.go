@@ -0,0 +1,49 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/stackus/errors"
+
+	"eda-in-golang/ordering/internal/domain"
+)
+
+// GetOrderAt finds the order as it was at a version or at a point in time;
+// exactly one of Version or AsOf must be set
+type GetOrderAt struct {
+	ID      string
+	Version int
+	AsOf    time.Time
+}
+
+type GetOrderAtHandler struct {
+	repo domain.OrderRepository
+}
+
+func NewGetOrderAtHandler(repo domain.OrderRepository) GetOrderAtHandler {
+	return GetOrderAtHandler{repo: repo}
+}
+
+func (h GetOrderAtHandler) GetOrderAt(ctx context.Context, query GetOrderAt) (*domain.Order, error) {
+	var order *domain.Order
+	var err error
+
+	switch {
+	case query.Version > 0 && query.AsOf.IsZero():
+		order, err = h.repo.LoadAtVersion(ctx, query.ID, query.Version)
+	case query.Version == 0 && !query.AsOf.IsZero():
+		order, err = h.repo.LoadAsOf(ctx, query.ID, query.AsOf)
+	default:
+		return nil, errors.ErrInvalidArgument.Msg("either a version or a point in time is required")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "get order at query")
+	}
+
+	if order.Version() == 0 {
+		return nil, errors.ErrNotFound.Msgf("the order `%s` did not exist yet", query.ID)
+	}
+
+	return order, nil
+}
@@ -0,0 +1,35 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/stackus/errors"
+
+	"eda-in-golang/internal/es"
+	"eda-in-golang/ordering/internal/domain"
+)
+
+type GetOrderHistory struct {
+	ID string
+}
+
+type GetOrderHistoryHandler struct {
+	history es.HistoryReader
+}
+
+func NewGetOrderHistoryHandler(history es.HistoryReader) GetOrderHistoryHandler {
+	return GetOrderHistoryHandler{history: history}
+}
+
+func (h GetOrderHistoryHandler) GetOrderHistory(ctx context.Context, query GetOrderHistory) ([]es.HistoryEvent, error) {
+	events, err := h.history.History(ctx, domain.OrderAggregate, query.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get order history query")
+	}
+
+	if len(events) == 0 {
+		return nil, errors.ErrNotFound.Msgf("the order `%s` has no history", query.ID)
+	}
+
+	return events, nil
+}
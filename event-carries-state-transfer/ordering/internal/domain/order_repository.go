@@ -1,8 +1,13 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type OrderRepository interface {
 	Load(ctx context.Context, id string) (*Order, error)
+	LoadAtVersion(ctx context.Context, id string, version int) (*Order, error)
+	LoadAsOf(ctx context.Context, id string, asOf time.Time) (*Order, error)
 	Save(ctx context.Context, order *Order) error
 }
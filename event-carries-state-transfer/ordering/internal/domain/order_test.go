@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"testing"
+
+	estesting "eda-in-golang/internal/es/testing"
+)
+
+func newTestOrder() *Order { return NewOrder("order-id") }
+
+func TestOrder_Cancel(t *testing.T) {
+	created := &OrderCreated{
+		CustomerID: "customer-id",
+		PaymentID:  "payment-id",
+		Items:      []Item{{ProductID: "product-id", StoreID: "store-id", Price: 9.99, Quantity: 1}},
+	}
+
+	t.Run("cancels_a_pending_order", func(t *testing.T) {
+		estesting.Given(newTestOrder, created).
+			When(func(order *Order) error {
+				_, err := order.Cancel()
+				return err
+			}).
+			Then(t, &OrderCanceled{CustomerID: "customer-id", PaymentID: "payment-id"})
+	})
+
+	t.Run("rejects_a_canceled_order", func(t *testing.T) {
+		estesting.Given(newTestOrder, created, &OrderCanceled{CustomerID: "customer-id", PaymentID: "payment-id"}).
+			When(func(order *Order) error {
+				_, err := order.Cancel()
+				return err
+			}).
+			ThenError(t, ErrOrderCannotBeCancelled)
+	})
+}
@@ -5,7 +5,10 @@ import (
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"eda-in-golang/internal/es"
 	"eda-in-golang/ordering/internal/application"
 	"eda-in-golang/ordering/internal/application/commands"
 	"eda-in-golang/ordering/internal/application/queries"
@@ -70,6 +73,40 @@ func (s server) GetOrder(ctx context.Context, request *orderingpb.GetOrderReques
 	}, nil
 }
 
+func (s server) GetOrderAt(ctx context.Context, request *orderingpb.GetOrderAtRequest) (*orderingpb.GetOrderAtResponse, error) {
+	query := queries.GetOrderAt{
+		ID:      request.GetId(),
+		Version: int(request.GetVersion()),
+	}
+	if request.GetAsOf() != nil {
+		query.AsOf = request.GetAsOf().AsTime()
+	}
+
+	order, err := s.app.GetOrderAt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderingpb.GetOrderAtResponse{
+		Order:   s.orderFromDomain(order),
+		Version: int32(order.Version()),
+	}, nil
+}
+
+func (s server) GetOrderHistory(ctx context.Context, request *orderingpb.GetOrderHistoryRequest) (*orderingpb.GetOrderHistoryResponse, error) {
+	events, err := s.app.GetOrderHistory(ctx, queries.GetOrderHistory{ID: request.GetId()})
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.historyFromDomain(events)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderingpb.GetOrderHistoryResponse{Events: history}, nil
+}
+
 func (s server) orderFromDomain(order *domain.Order) *orderingpb.Order {
 	items := make([]*orderingpb.Item, len(order.Items))
 	for i, item := range order.Items {
@@ -106,3 +143,23 @@ func (s server) itemFromDomain(item domain.Item) *orderingpb.Item {
 		Quantity:    int32(item.Quantity),
 	}
 }
+
+func (s server) historyFromDomain(events []es.HistoryEvent) ([]*orderingpb.HistoryEvent, error) {
+	history := make([]*orderingpb.HistoryEvent, len(events))
+	for i, event := range events {
+		metadata, err := structpb.NewStruct(event.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		history[i] = &orderingpb.HistoryEvent{
+			Id:         event.ID,
+			Name:       event.Name,
+			Version:    int32(event.Version),
+			OccurredAt: timestamppb.New(event.OccurredAt),
+			Payload:    string(event.Payload),
+			Metadata:   metadata,
+		}
+	}
+
+	return history, nil
+}
@@ -50,6 +50,28 @@ func (s serverTx) GetOrder(ctx context.Context, request *orderingpb.GetOrderRequ
 	return next.GetOrder(ctx, request)
 }
 
+func (s serverTx) GetOrderAt(ctx context.Context, request *orderingpb.GetOrderAtRequest) (resp *orderingpb.GetOrderAtResponse, err error) {
+	ctx = s.c.Scoped(ctx)
+	defer func(tx *sql.Tx) {
+		err = s.closeTx(tx, err)
+	}(di.Get(ctx, "tx").(*sql.Tx))
+
+	next := server{app: di.Get(ctx, "app").(application.App)}
+
+	return next.GetOrderAt(ctx, request)
+}
+
+func (s serverTx) GetOrderHistory(ctx context.Context, request *orderingpb.GetOrderHistoryRequest) (resp *orderingpb.GetOrderHistoryResponse, err error) {
+	ctx = s.c.Scoped(ctx)
+	defer func(tx *sql.Tx) {
+		err = s.closeTx(tx, err)
+	}(di.Get(ctx, "tx").(*sql.Tx))
+
+	next := server{app: di.Get(ctx, "app").(application.App)}
+
+	return next.GetOrderHistory(ctx, request)
+}
+
 func (s serverTx) CancelOrder(ctx context.Context, request *orderingpb.CancelOrderRequest) (resp *orderingpb.CancelOrderResponse, err error) {
 	ctx = s.c.Scoped(ctx)
 	defer func(tx *sql.Tx) {
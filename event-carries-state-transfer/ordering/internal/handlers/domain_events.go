@@ -60,7 +60,7 @@ func (h domainHandlers[T]) onOrderCreated(ctx context.Context, event ddd.Event)
 			PaymentId:  payload.PaymentID,
 			ShoppingId: payload.ShoppingID,
 			Items:      items,
-		}),
+		}, ddd.Metadata{ddd.AggregateIDKey: payload.ID()}),
 	)
 }
 
@@ -71,7 +71,7 @@ func (h domainHandlers[T]) onOrderRejected(ctx context.Context, event ddd.Event)
 			Id:         payload.ID(),
 			CustomerId: payload.CustomerID,
 			PaymentId:  payload.PaymentID,
-		}),
+		}, ddd.Metadata{ddd.AggregateIDKey: payload.ID()}),
 	)
 }
 
@@ -82,7 +82,7 @@ func (h domainHandlers[T]) onOrderApproved(ctx context.Context, event ddd.Event)
 			Id:         payload.ID(),
 			CustomerId: payload.CustomerID,
 			PaymentId:  payload.PaymentID,
-		}),
+		}, ddd.Metadata{ddd.AggregateIDKey: payload.ID()}),
 	)
 }
 
@@ -94,7 +94,7 @@ func (h domainHandlers[T]) onOrderReadied(ctx context.Context, event ddd.Event)
 			CustomerId: payload.CustomerID,
 			PaymentId:  payload.PaymentID,
 			Total:      payload.GetTotal(),
-		}),
+		}, ddd.Metadata{ddd.AggregateIDKey: payload.ID()}),
 	)
 }
 
@@ -105,7 +105,7 @@ func (h domainHandlers[T]) onOrderCanceled(ctx context.Context, event ddd.Event)
 			Id:         payload.ID(),
 			CustomerId: payload.CustomerID,
 			PaymentId:  payload.PaymentID,
-		}),
+		}, ddd.Metadata{ddd.AggregateIDKey: payload.ID()}),
 	)
 }
 
@@ -116,6 +116,6 @@ func (h domainHandlers[T]) onOrderCompleted(ctx context.Context, event ddd.Event
 			Id:         payload.ID(),
 			CustomerId: payload.CustomerID,
 			InvoiceId:  payload.InvoiceID,
-		}),
+		}, ddd.Metadata{ddd.AggregateIDKey: payload.ID()}),
 	)
 }
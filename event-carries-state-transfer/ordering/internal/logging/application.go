@@ -5,6 +5,8 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/es"
 	"eda-in-golang/ordering/internal/application"
 	"eda-in-golang/ordering/internal/application/commands"
 	"eda-in-golang/ordering/internal/application/queries"
@@ -26,31 +28,45 @@ func LogApplicationAccess(application application.App, logger zerolog.Logger) Ap
 }
 
 func (a Application) CreateOrder(ctx context.Context, cmd commands.CreateOrder) (err error) {
-	a.logger.Info().Msg("--> Ordering.CreateOrder")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Ordering.CreateOrder") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Ordering.CreateOrder")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Ordering.CreateOrder") }()
 	return a.App.CreateOrder(ctx, cmd)
 }
 
 func (a Application) CancelOrder(ctx context.Context, cmd commands.CancelOrder) (err error) {
-	a.logger.Info().Msg("--> Ordering.CancelOrder")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Ordering.CancelOrder") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Ordering.CancelOrder")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Ordering.CancelOrder") }()
 	return a.App.CancelOrder(ctx, cmd)
 }
 
 func (a Application) ReadyOrder(ctx context.Context, cmd commands.ReadyOrder) (err error) {
-	a.logger.Info().Msg("--> Ordering.ReadyOrder")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Ordering.ReadyOrder") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Ordering.ReadyOrder")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Ordering.ReadyOrder") }()
 	return a.App.ReadyOrder(ctx, cmd)
 }
 
 func (a Application) CompleteOrder(ctx context.Context, cmd commands.CompleteOrder) (err error) {
-	a.logger.Info().Msg("--> Ordering.CompleteOrder")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Ordering.CompleteOrder") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Ordering.CompleteOrder")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Ordering.CompleteOrder") }()
 	return a.App.CompleteOrder(ctx, cmd)
 }
 
 func (a Application) GetOrder(ctx context.Context, query queries.GetOrder) (order *domain.Order, err error) {
-	a.logger.Info().Msg("--> Ordering.GetOrder")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Ordering.GetOrder") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Ordering.GetOrder")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Ordering.GetOrder") }()
 	return a.App.GetOrder(ctx, query)
 }
+
+func (a Application) GetOrderAt(ctx context.Context, query queries.GetOrderAt) (order *domain.Order, err error) {
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Ordering.GetOrderAt")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Ordering.GetOrderAt") }()
+	return a.App.GetOrderAt(ctx, query)
+}
+
+func (a Application) GetOrderHistory(ctx context.Context, query queries.GetOrderHistory) (events []es.HistoryEvent, err error) {
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Ordering.GetOrderHistory")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Ordering.GetOrderHistory")
+	}()
+	return a.App.GetOrderHistory(ctx, query)
+}
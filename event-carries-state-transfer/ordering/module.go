@@ -16,6 +16,7 @@ import (
 	"eda-in-golang/internal/es"
 	"eda-in-golang/internal/jetstream"
 	"eda-in-golang/internal/monolith"
+	"eda-in-golang/internal/prom"
 	"eda-in-golang/internal/registry"
 	"eda-in-golang/internal/registry/serdes"
 	"eda-in-golang/internal/tm"
@@ -56,11 +57,17 @@ func (Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 		return mono.Logger(), nil
 	})
 	container.AddSingleton("stream", func(c di.Container) (any, error) {
-		return jetstream.NewStream(mono.Config().Nats.Stream, mono.JS(), c.Get("logger").(zerolog.Logger)), nil
+		return am.RawMessageStreamWithMiddleware(
+			mono.Stream(c.Get("logger").(zerolog.Logger), jetstream.ConfirmPublish()),
+			prom.StreamMetrics(),
+		), nil
 	})
 	container.AddSingleton("domainDispatcher", func(c di.Container) (any, error) {
 		return ddd.NewEventDispatcher[ddd.Event](), nil
 	})
+	container.AddSingleton("snapshotMetrics", func(c di.Container) (any, error) {
+		return prom.SnapshotMetrics(), nil
+	})
 	container.AddSingleton("db", func(c di.Container) (any, error) {
 		return mono.DB(), nil
 	})
@@ -72,6 +79,25 @@ func (Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 			c.Get("stream").(am.RawMessageStream),
 			pg.NewOutboxStore("ordering.outbox", c.Get("db").(*sql.DB)),
 			c.Get("logger").(zerolog.Logger),
+			tm.OutboxBatchSize(mono.Config().Outbox.BatchSize),
+			tm.OutboxPollingInterval(mono.Config().Outbox.PollingInterval),
+			tm.OutboxWorkers(mono.Config().Outbox.Workers),
+			tm.OutboxLease(mono.Config().Outbox.Lease),
+			tm.OutboxOrderedBySubject(mono.Config().Outbox.OrderedBySubject),
+			tm.OutboxNotifications(pg.NewOutboxListener("ordering.outbox", c.Get("db").(*sql.DB), c.Get("logger").(zerolog.Logger))),
+			tm.OutboxBacklogMetrics(pg.NewOutboxStore("ordering.outbox", c.Get("db").(*sql.DB)), prom.OutboxMetrics("ordering.outbox")),
+		), nil
+	})
+	container.AddSingleton("retention", func(c di.Container) (any, error) {
+		db := c.Get("db").(*sql.DB)
+		return tm.NewRetention(
+			pg.NewOutboxStore("ordering.outbox", db),
+			pg.NewInboxStore("ordering.inbox", db),
+			c.Get("logger").(zerolog.Logger),
+			tm.RetentionInterval(mono.Config().Retention.Interval),
+			tm.RetentionBatchSize(mono.Config().Retention.BatchSize),
+			tm.OutboxRetention(mono.Config().Retention.Outbox),
+			tm.InboxRetention(mono.Config().Retention.Inbox),
 		), nil
 	})
 	container.AddScoped("tx", func(c di.Container) (any, error) {
@@ -95,16 +121,31 @@ func (Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 	container.AddScoped("inboxMiddleware", func(c di.Container) (any, error) {
 		tx := c.Get("tx").(*sql.Tx)
 		inboxStore := pg.NewInboxStore("ordering.inbox", tx)
-		return tm.NewInboxHandlerMiddleware(inboxStore), nil
+		return tm.NewInboxHandlerMiddleware(
+			inboxStore,
+			tm.InboxHandlerName("ordering"),
+			tm.InboxFailures(pg.NewInboxStore("ordering.inbox", c.Get("db").(*sql.DB))),
+			tm.InboxDuplicateMetrics(prom.InboxMetrics()),
+		), nil
 	})
 	container.AddScoped("aggregateStore", func(c di.Container) (any, error) {
 		tx := c.Get("tx").(*sql.Tx)
 		reg := c.Get("registry").(registry.Registry)
 		return es.AggregateStoreWithMiddleware(
 			pg.NewEventStore("ordering.events", tx, reg),
-			pg.NewSnapshotStore("ordering.snapshots", tx, reg),
+			pg.NewSnapshotStore("ordering.snapshots", tx, reg,
+				es.WithSnapshotStrategy(domain.OrderAggregate, es.AnyOf(
+					es.EveryNEvents(3),
+					es.OnEvents(domain.OrderCompletedEvent, domain.OrderCanceledEvent),
+				)),
+				es.WithSnapshotMetrics(c.Get("snapshotMetrics").(es.SnapshotMetrics)),
+			),
+			prom.AggregateStoreMetrics(),
 		), nil
 	})
+	container.AddSingleton("history", func(c di.Container) (any, error) {
+		return pg.NewEventStore("ordering.events", c.Get("db").(*sql.DB), c.Get("registry").(registry.Registry)), nil
+	})
 	container.AddScoped("orders", func(c di.Container) (any, error) {
 		return es.NewAggregateRepository[*domain.Order](
 			domain.OrderAggregate,
@@ -118,6 +159,7 @@ func (Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 		return logging.LogApplicationAccess(
 			application.New(
 				c.Get("orders").(domain.OrderRepository),
+				c.Get("history").(es.HistoryReader),
 				c.Get("domainDispatcher").(*ddd.EventDispatcher[ddd.Event]),
 			),
 			c.Get("logger").(zerolog.Logger),
@@ -156,6 +198,7 @@ func (Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 		return err
 	}
 	startOutboxProcessor(ctx, container)
+	mono.Waiter().Add(container.Get("retention").(tm.Retention).Start)
 
 	return nil
 }
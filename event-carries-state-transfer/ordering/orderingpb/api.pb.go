@@ -9,6 +9,8 @@ package orderingpb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -373,6 +375,120 @@ func (x *GetOrderResponse) GetOrder() *Order {
 	return nil
 }
 
+// GetOrderAtRequest asks for the order as it was at a version or a point in
+// time; one of version or as_of must be set
+type GetOrderAtRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderAtRequest) Reset() {
+	*x = GetOrderAtRequest{}
+	mi := &file_orderingpb_api_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderAtRequest) ProtoMessage() {}
+
+func (x *GetOrderAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orderingpb_api_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderAtRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderAtRequest) Descriptor() ([]byte, []int) {
+	return file_orderingpb_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetOrderAtRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetOrderAtRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetOrderAtRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+type GetOrderAtResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderAtResponse) Reset() {
+	*x = GetOrderAtResponse{}
+	mi := &file_orderingpb_api_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderAtResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderAtResponse) ProtoMessage() {}
+
+func (x *GetOrderAtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orderingpb_api_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderAtResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderAtResponse) Descriptor() ([]byte, []int) {
+	return file_orderingpb_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetOrderAtResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *GetOrderAtResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type CancelOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -382,7 +498,7 @@ type CancelOrderRequest struct {
 
 func (x *CancelOrderRequest) Reset() {
 	*x = CancelOrderRequest{}
-	mi := &file_orderingpb_api_proto_msgTypes[6]
+	mi := &file_orderingpb_api_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -394,7 +510,7 @@ func (x *CancelOrderRequest) String() string {
 func (*CancelOrderRequest) ProtoMessage() {}
 
 func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orderingpb_api_proto_msgTypes[6]
+	mi := &file_orderingpb_api_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -407,7 +523,7 @@ func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
 func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
-	return file_orderingpb_api_proto_rawDescGZIP(), []int{6}
+	return file_orderingpb_api_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CancelOrderRequest) GetId() string {
@@ -425,7 +541,7 @@ type CancelOrderResponse struct {
 
 func (x *CancelOrderResponse) Reset() {
 	*x = CancelOrderResponse{}
-	mi := &file_orderingpb_api_proto_msgTypes[7]
+	mi := &file_orderingpb_api_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -437,7 +553,7 @@ func (x *CancelOrderResponse) String() string {
 func (*CancelOrderResponse) ProtoMessage() {}
 
 func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_orderingpb_api_proto_msgTypes[7]
+	mi := &file_orderingpb_api_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -450,7 +566,7 @@ func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
 func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
-	return file_orderingpb_api_proto_rawDescGZIP(), []int{7}
+	return file_orderingpb_api_proto_rawDescGZIP(), []int{9}
 }
 
 type ReadyOrderRequest struct {
@@ -462,7 +578,7 @@ type ReadyOrderRequest struct {
 
 func (x *ReadyOrderRequest) Reset() {
 	*x = ReadyOrderRequest{}
-	mi := &file_orderingpb_api_proto_msgTypes[8]
+	mi := &file_orderingpb_api_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -474,7 +590,7 @@ func (x *ReadyOrderRequest) String() string {
 func (*ReadyOrderRequest) ProtoMessage() {}
 
 func (x *ReadyOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orderingpb_api_proto_msgTypes[8]
+	mi := &file_orderingpb_api_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -487,7 +603,7 @@ func (x *ReadyOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadyOrderRequest.ProtoReflect.Descriptor instead.
 func (*ReadyOrderRequest) Descriptor() ([]byte, []int) {
-	return file_orderingpb_api_proto_rawDescGZIP(), []int{8}
+	return file_orderingpb_api_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ReadyOrderRequest) GetId() string {
@@ -505,7 +621,7 @@ type ReadyOrderResponse struct {
 
 func (x *ReadyOrderResponse) Reset() {
 	*x = ReadyOrderResponse{}
-	mi := &file_orderingpb_api_proto_msgTypes[9]
+	mi := &file_orderingpb_api_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -517,7 +633,7 @@ func (x *ReadyOrderResponse) String() string {
 func (*ReadyOrderResponse) ProtoMessage() {}
 
 func (x *ReadyOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_orderingpb_api_proto_msgTypes[9]
+	mi := &file_orderingpb_api_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -530,7 +646,7 @@ func (x *ReadyOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadyOrderResponse.ProtoReflect.Descriptor instead.
 func (*ReadyOrderResponse) Descriptor() ([]byte, []int) {
-	return file_orderingpb_api_proto_rawDescGZIP(), []int{9}
+	return file_orderingpb_api_proto_rawDescGZIP(), []int{11}
 }
 
 type CompleteOrderRequest struct {
@@ -543,7 +659,7 @@ type CompleteOrderRequest struct {
 
 func (x *CompleteOrderRequest) Reset() {
 	*x = CompleteOrderRequest{}
-	mi := &file_orderingpb_api_proto_msgTypes[10]
+	mi := &file_orderingpb_api_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -555,7 +671,7 @@ func (x *CompleteOrderRequest) String() string {
 func (*CompleteOrderRequest) ProtoMessage() {}
 
 func (x *CompleteOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_orderingpb_api_proto_msgTypes[10]
+	mi := &file_orderingpb_api_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -568,7 +684,7 @@ func (x *CompleteOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompleteOrderRequest.ProtoReflect.Descriptor instead.
 func (*CompleteOrderRequest) Descriptor() ([]byte, []int) {
-	return file_orderingpb_api_proto_rawDescGZIP(), []int{10}
+	return file_orderingpb_api_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CompleteOrderRequest) GetId() string {
@@ -593,7 +709,7 @@ type CompleteOrderResponse struct {
 
 func (x *CompleteOrderResponse) Reset() {
 	*x = CompleteOrderResponse{}
-	mi := &file_orderingpb_api_proto_msgTypes[11]
+	mi := &file_orderingpb_api_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -605,7 +721,7 @@ func (x *CompleteOrderResponse) String() string {
 func (*CompleteOrderResponse) ProtoMessage() {}
 
 func (x *CompleteOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_orderingpb_api_proto_msgTypes[11]
+	mi := &file_orderingpb_api_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -618,7 +734,180 @@ func (x *CompleteOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompleteOrderResponse.ProtoReflect.Descriptor instead.
 func (*CompleteOrderResponse) Descriptor() ([]byte, []int) {
-	return file_orderingpb_api_proto_rawDescGZIP(), []int{11}
+	return file_orderingpb_api_proto_rawDescGZIP(), []int{13}
+}
+
+// HistoryEvent is a stored event with its payload rendered as JSON
+type HistoryEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Payload       string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryEvent) Reset() {
+	*x = HistoryEvent{}
+	mi := &file_orderingpb_api_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryEvent) ProtoMessage() {}
+
+func (x *HistoryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_orderingpb_api_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryEvent.ProtoReflect.Descriptor instead.
+func (*HistoryEvent) Descriptor() ([]byte, []int) {
+	return file_orderingpb_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HistoryEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HistoryEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HistoryEvent) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *HistoryEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *HistoryEvent) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *HistoryEvent) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GetOrderHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryRequest) Reset() {
+	*x = GetOrderHistoryRequest{}
+	mi := &file_orderingpb_api_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryRequest) ProtoMessage() {}
+
+func (x *GetOrderHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orderingpb_api_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_orderingpb_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetOrderHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetOrderHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*HistoryEvent        `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderHistoryResponse) Reset() {
+	*x = GetOrderHistoryResponse{}
+	mi := &file_orderingpb_api_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryResponse) ProtoMessage() {}
+
+func (x *GetOrderHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orderingpb_api_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_orderingpb_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetOrderHistoryResponse) GetEvents() []*HistoryEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
 }
 
 var File_orderingpb_api_proto protoreflect.FileDescriptor
@@ -626,7 +915,7 @@ var File_orderingpb_api_proto protoreflect.FileDescriptor
 const file_orderingpb_api_proto_rawDesc = "" +
 	"\n" +
 	"\x14orderingpb/api.proto\x12\n" +
-	"orderingpb\"\x97\x01\n" +
+	"orderingpb\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x97\x01\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vcustomer_id\x18\x02 \x01(\tR\n" +
@@ -655,7 +944,14 @@ const file_orderingpb_api_proto_rawDesc = "" +
 	"\x0fGetOrderRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\";\n" +
 	"\x10GetOrderResponse\x12'\n" +
-	"\x05order\x18\x01 \x01(\v2\x11.orderingpb.OrderR\x05order\"$\n" +
+	"\x05order\x18\x01 \x01(\v2\x11.orderingpb.OrderR\x05order\"n\n" +
+	"\x11GetOrderAtRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12/\n" +
+	"\x05as_of\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"W\n" +
+	"\x12GetOrderAtResponse\x12'\n" +
+	"\x05order\x18\x01 \x01(\v2\x11.orderingpb.OrderR\x05order\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"$\n" +
 	"\x12CancelOrderRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\x15\n" +
 	"\x13CancelOrderResponse\"#\n" +
@@ -666,10 +962,25 @@ const file_orderingpb_api_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
 	"invoice_id\x18\x02 \x01(\tR\tinvoiceId\"\x17\n" +
-	"\x15CompleteOrderResponse2\x9b\x03\n" +
+	"\x15CompleteOrderResponse\"\xd8\x01\n" +
+	"\fHistoryEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x123\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\"(\n" +
+	"\x16GetOrderHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"K\n" +
+	"\x17GetOrderHistoryResponse\x120\n" +
+	"\x06events\x18\x01 \x03(\v2\x18.orderingpb.HistoryEventR\x06events2\xc4\x04\n" +
 	"\x0fOrderingService\x12N\n" +
 	"\vCreateOrder\x12\x1e.orderingpb.CreateOrderRequest\x1a\x1f.orderingpb.CreateOrderResponse\x12E\n" +
-	"\bGetOrder\x12\x1b.orderingpb.GetOrderRequest\x1a\x1c.orderingpb.GetOrderResponse\x12N\n" +
+	"\bGetOrder\x12\x1b.orderingpb.GetOrderRequest\x1a\x1c.orderingpb.GetOrderResponse\x12K\n" +
+	"\n" +
+	"GetOrderAt\x12\x1d.orderingpb.GetOrderAtRequest\x1a\x1e.orderingpb.GetOrderAtResponse\x12Z\n" +
+	"\x0fGetOrderHistory\x12\".orderingpb.GetOrderHistoryRequest\x1a#.orderingpb.GetOrderHistoryResponse\x12N\n" +
 	"\vCancelOrder\x12\x1e.orderingpb.CancelOrderRequest\x1a\x1f.orderingpb.CancelOrderResponse\x12K\n" +
 	"\n" +
 	"ReadyOrder\x12\x1d.orderingpb.ReadyOrderRequest\x1a\x1e.orderingpb.ReadyOrderResponse\x12T\n" +
@@ -691,40 +1002,56 @@ func file_orderingpb_api_proto_rawDescGZIP() []byte {
 	return file_orderingpb_api_proto_rawDescData
 }
 
-var file_orderingpb_api_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_orderingpb_api_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_orderingpb_api_proto_goTypes = []any{
-	(*Order)(nil),                 // 0: orderingpb.Order
-	(*Item)(nil),                  // 1: orderingpb.Item
-	(*CreateOrderRequest)(nil),    // 2: orderingpb.CreateOrderRequest
-	(*CreateOrderResponse)(nil),   // 3: orderingpb.CreateOrderResponse
-	(*GetOrderRequest)(nil),       // 4: orderingpb.GetOrderRequest
-	(*GetOrderResponse)(nil),      // 5: orderingpb.GetOrderResponse
-	(*CancelOrderRequest)(nil),    // 6: orderingpb.CancelOrderRequest
-	(*CancelOrderResponse)(nil),   // 7: orderingpb.CancelOrderResponse
-	(*ReadyOrderRequest)(nil),     // 8: orderingpb.ReadyOrderRequest
-	(*ReadyOrderResponse)(nil),    // 9: orderingpb.ReadyOrderResponse
-	(*CompleteOrderRequest)(nil),  // 10: orderingpb.CompleteOrderRequest
-	(*CompleteOrderResponse)(nil), // 11: orderingpb.CompleteOrderResponse
+	(*Order)(nil),                   // 0: orderingpb.Order
+	(*Item)(nil),                    // 1: orderingpb.Item
+	(*CreateOrderRequest)(nil),      // 2: orderingpb.CreateOrderRequest
+	(*CreateOrderResponse)(nil),     // 3: orderingpb.CreateOrderResponse
+	(*GetOrderRequest)(nil),         // 4: orderingpb.GetOrderRequest
+	(*GetOrderResponse)(nil),        // 5: orderingpb.GetOrderResponse
+	(*GetOrderAtRequest)(nil),       // 6: orderingpb.GetOrderAtRequest
+	(*GetOrderAtResponse)(nil),      // 7: orderingpb.GetOrderAtResponse
+	(*CancelOrderRequest)(nil),      // 8: orderingpb.CancelOrderRequest
+	(*CancelOrderResponse)(nil),     // 9: orderingpb.CancelOrderResponse
+	(*ReadyOrderRequest)(nil),       // 10: orderingpb.ReadyOrderRequest
+	(*ReadyOrderResponse)(nil),      // 11: orderingpb.ReadyOrderResponse
+	(*CompleteOrderRequest)(nil),    // 12: orderingpb.CompleteOrderRequest
+	(*CompleteOrderResponse)(nil),   // 13: orderingpb.CompleteOrderResponse
+	(*HistoryEvent)(nil),            // 14: orderingpb.HistoryEvent
+	(*GetOrderHistoryRequest)(nil),  // 15: orderingpb.GetOrderHistoryRequest
+	(*GetOrderHistoryResponse)(nil), // 16: orderingpb.GetOrderHistoryResponse
+	(*timestamppb.Timestamp)(nil),   // 17: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),         // 18: google.protobuf.Struct
 }
 var file_orderingpb_api_proto_depIdxs = []int32{
 	1,  // 0: orderingpb.Order.items:type_name -> orderingpb.Item
 	1,  // 1: orderingpb.CreateOrderRequest.items:type_name -> orderingpb.Item
 	0,  // 2: orderingpb.GetOrderResponse.order:type_name -> orderingpb.Order
-	2,  // 3: orderingpb.OrderingService.CreateOrder:input_type -> orderingpb.CreateOrderRequest
-	4,  // 4: orderingpb.OrderingService.GetOrder:input_type -> orderingpb.GetOrderRequest
-	6,  // 5: orderingpb.OrderingService.CancelOrder:input_type -> orderingpb.CancelOrderRequest
-	8,  // 6: orderingpb.OrderingService.ReadyOrder:input_type -> orderingpb.ReadyOrderRequest
-	10, // 7: orderingpb.OrderingService.CompleteOrder:input_type -> orderingpb.CompleteOrderRequest
-	3,  // 8: orderingpb.OrderingService.CreateOrder:output_type -> orderingpb.CreateOrderResponse
-	5,  // 9: orderingpb.OrderingService.GetOrder:output_type -> orderingpb.GetOrderResponse
-	7,  // 10: orderingpb.OrderingService.CancelOrder:output_type -> orderingpb.CancelOrderResponse
-	9,  // 11: orderingpb.OrderingService.ReadyOrder:output_type -> orderingpb.ReadyOrderResponse
-	11, // 12: orderingpb.OrderingService.CompleteOrder:output_type -> orderingpb.CompleteOrderResponse
-	8,  // [8:13] is the sub-list for method output_type
-	3,  // [3:8] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	17, // 3: orderingpb.GetOrderAtRequest.as_of:type_name -> google.protobuf.Timestamp
+	0,  // 4: orderingpb.GetOrderAtResponse.order:type_name -> orderingpb.Order
+	17, // 5: orderingpb.HistoryEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	18, // 6: orderingpb.HistoryEvent.metadata:type_name -> google.protobuf.Struct
+	14, // 7: orderingpb.GetOrderHistoryResponse.events:type_name -> orderingpb.HistoryEvent
+	2,  // 8: orderingpb.OrderingService.CreateOrder:input_type -> orderingpb.CreateOrderRequest
+	4,  // 9: orderingpb.OrderingService.GetOrder:input_type -> orderingpb.GetOrderRequest
+	6,  // 10: orderingpb.OrderingService.GetOrderAt:input_type -> orderingpb.GetOrderAtRequest
+	15, // 11: orderingpb.OrderingService.GetOrderHistory:input_type -> orderingpb.GetOrderHistoryRequest
+	8,  // 12: orderingpb.OrderingService.CancelOrder:input_type -> orderingpb.CancelOrderRequest
+	10, // 13: orderingpb.OrderingService.ReadyOrder:input_type -> orderingpb.ReadyOrderRequest
+	12, // 14: orderingpb.OrderingService.CompleteOrder:input_type -> orderingpb.CompleteOrderRequest
+	3,  // 15: orderingpb.OrderingService.CreateOrder:output_type -> orderingpb.CreateOrderResponse
+	5,  // 16: orderingpb.OrderingService.GetOrder:output_type -> orderingpb.GetOrderResponse
+	7,  // 17: orderingpb.OrderingService.GetOrderAt:output_type -> orderingpb.GetOrderAtResponse
+	16, // 18: orderingpb.OrderingService.GetOrderHistory:output_type -> orderingpb.GetOrderHistoryResponse
+	9,  // 19: orderingpb.OrderingService.CancelOrder:output_type -> orderingpb.CancelOrderResponse
+	11, // 20: orderingpb.OrderingService.ReadyOrder:output_type -> orderingpb.ReadyOrderResponse
+	13, // 21: orderingpb.OrderingService.CompleteOrder:output_type -> orderingpb.CompleteOrderResponse
+	15, // [15:22] is the sub-list for method output_type
+	8,  // [8:15] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_orderingpb_api_proto_init() }
@@ -738,7 +1065,7 @@ func file_orderingpb_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_orderingpb_api_proto_rawDesc), len(file_orderingpb_api_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,11 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrderingService_CreateOrder_FullMethodName   = "/orderingpb.OrderingService/CreateOrder"
-	OrderingService_GetOrder_FullMethodName      = "/orderingpb.OrderingService/GetOrder"
-	OrderingService_CancelOrder_FullMethodName   = "/orderingpb.OrderingService/CancelOrder"
-	OrderingService_ReadyOrder_FullMethodName    = "/orderingpb.OrderingService/ReadyOrder"
-	OrderingService_CompleteOrder_FullMethodName = "/orderingpb.OrderingService/CompleteOrder"
+	OrderingService_CreateOrder_FullMethodName     = "/orderingpb.OrderingService/CreateOrder"
+	OrderingService_GetOrder_FullMethodName        = "/orderingpb.OrderingService/GetOrder"
+	OrderingService_GetOrderAt_FullMethodName      = "/orderingpb.OrderingService/GetOrderAt"
+	OrderingService_GetOrderHistory_FullMethodName = "/orderingpb.OrderingService/GetOrderHistory"
+	OrderingService_CancelOrder_FullMethodName     = "/orderingpb.OrderingService/CancelOrder"
+	OrderingService_ReadyOrder_FullMethodName      = "/orderingpb.OrderingService/ReadyOrder"
+	OrderingService_CompleteOrder_FullMethodName   = "/orderingpb.OrderingService/CompleteOrder"
 )
 
 // OrderingServiceClient is the client API for OrderingService service.
@@ -32,6 +34,8 @@ const (
 type OrderingServiceClient interface {
 	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderResponse, error)
 	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*GetOrderResponse, error)
+	GetOrderAt(ctx context.Context, in *GetOrderAtRequest, opts ...grpc.CallOption) (*GetOrderAtResponse, error)
+	GetOrderHistory(ctx context.Context, in *GetOrderHistoryRequest, opts ...grpc.CallOption) (*GetOrderHistoryResponse, error)
 	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
 	ReadyOrder(ctx context.Context, in *ReadyOrderRequest, opts ...grpc.CallOption) (*ReadyOrderResponse, error)
 	CompleteOrder(ctx context.Context, in *CompleteOrderRequest, opts ...grpc.CallOption) (*CompleteOrderResponse, error)
@@ -65,6 +69,26 @@ func (c *orderingServiceClient) GetOrder(ctx context.Context, in *GetOrderReques
 	return out, nil
 }
 
+func (c *orderingServiceClient) GetOrderAt(ctx context.Context, in *GetOrderAtRequest, opts ...grpc.CallOption) (*GetOrderAtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrderAtResponse)
+	err := c.cc.Invoke(ctx, OrderingService_GetOrderAt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderingServiceClient) GetOrderHistory(ctx context.Context, in *GetOrderHistoryRequest, opts ...grpc.CallOption) (*GetOrderHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrderHistoryResponse)
+	err := c.cc.Invoke(ctx, OrderingService_GetOrderHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orderingServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CancelOrderResponse)
@@ -101,6 +125,8 @@ func (c *orderingServiceClient) CompleteOrder(ctx context.Context, in *CompleteO
 type OrderingServiceServer interface {
 	CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error)
 	GetOrder(context.Context, *GetOrderRequest) (*GetOrderResponse, error)
+	GetOrderAt(context.Context, *GetOrderAtRequest) (*GetOrderAtResponse, error)
+	GetOrderHistory(context.Context, *GetOrderHistoryRequest) (*GetOrderHistoryResponse, error)
 	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
 	ReadyOrder(context.Context, *ReadyOrderRequest) (*ReadyOrderResponse, error)
 	CompleteOrder(context.Context, *CompleteOrderRequest) (*CompleteOrderResponse, error)
@@ -120,6 +146,12 @@ func (UnimplementedOrderingServiceServer) CreateOrder(context.Context, *CreateOr
 func (UnimplementedOrderingServiceServer) GetOrder(context.Context, *GetOrderRequest) (*GetOrderResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetOrder not implemented")
 }
+func (UnimplementedOrderingServiceServer) GetOrderAt(context.Context, *GetOrderAtRequest) (*GetOrderAtResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOrderAt not implemented")
+}
+func (UnimplementedOrderingServiceServer) GetOrderHistory(context.Context, *GetOrderHistoryRequest) (*GetOrderHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOrderHistory not implemented")
+}
 func (UnimplementedOrderingServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CancelOrder not implemented")
 }
@@ -186,6 +218,42 @@ func _OrderingService_GetOrder_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderingService_GetOrderAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderingServiceServer).GetOrderAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderingService_GetOrderAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderingServiceServer).GetOrderAt(ctx, req.(*GetOrderAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderingService_GetOrderHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderingServiceServer).GetOrderHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderingService_GetOrderHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderingServiceServer).GetOrderHistory(ctx, req.(*GetOrderHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrderingService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CancelOrderRequest)
 	if err := dec(in); err != nil {
@@ -255,6 +323,14 @@ var OrderingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOrder",
 			Handler:    _OrderingService_GetOrder_Handler,
 		},
+		{
+			MethodName: "GetOrderAt",
+			Handler:    _OrderingService_GetOrderAt_Handler,
+		},
+		{
+			MethodName: "GetOrderHistory",
+			Handler:    _OrderingService_GetOrderHistory_Handler,
+		},
 		{
 			MethodName: "CancelOrder",
 			Handler:    _OrderingService_CancelOrder_Handler,
@@ -3,12 +3,19 @@ package grpc
 import (
 	"context"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+
+	"eda-in-golang/internal/rpc"
 )
 
 func Dial(ctx context.Context, endpoint string) (conn *grpc.ClientConn, err error) {
-	conn, err = grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err = grpc.NewClient(endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(rpc.MessageIDsClientInterceptor()),
+	)
 	if err != nil {
 		return
 	}
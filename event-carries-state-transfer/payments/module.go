@@ -13,6 +13,7 @@ import (
 	"eda-in-golang/internal/di"
 	"eda-in-golang/internal/jetstream"
 	"eda-in-golang/internal/monolith"
+	"eda-in-golang/internal/prom"
 	"eda-in-golang/internal/registry"
 	"eda-in-golang/internal/tm"
 	"eda-in-golang/ordering/orderingpb"
@@ -48,7 +49,10 @@ func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error)
 		return mono.Logger(), nil
 	})
 	container.AddSingleton("stream", func(c di.Container) (any, error) {
-		return jetstream.NewStream(mono.Config().Nats.Stream, mono.JS(), c.Get("logger").(zerolog.Logger)), nil
+		return am.RawMessageStreamWithMiddleware(
+			mono.Stream(c.Get("logger").(zerolog.Logger), jetstream.ConfirmPublish()),
+			prom.StreamMetrics(),
+		), nil
 	})
 	container.AddSingleton("domainDispatcher", func(c di.Container) (any, error) {
 		return ddd.NewEventDispatcher[ddd.Event](), nil
@@ -61,6 +65,25 @@ func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error)
 			c.Get("stream").(am.RawMessageStream),
 			pg.NewOutboxStore("payments.outbox", c.Get("db").(*sql.DB)),
 			c.Get("logger").(zerolog.Logger),
+			tm.OutboxBatchSize(mono.Config().Outbox.BatchSize),
+			tm.OutboxPollingInterval(mono.Config().Outbox.PollingInterval),
+			tm.OutboxWorkers(mono.Config().Outbox.Workers),
+			tm.OutboxLease(mono.Config().Outbox.Lease),
+			tm.OutboxOrderedBySubject(mono.Config().Outbox.OrderedBySubject),
+			tm.OutboxNotifications(pg.NewOutboxListener("payments.outbox", c.Get("db").(*sql.DB), c.Get("logger").(zerolog.Logger))),
+			tm.OutboxBacklogMetrics(pg.NewOutboxStore("payments.outbox", c.Get("db").(*sql.DB)), prom.OutboxMetrics("payments.outbox")),
+		), nil
+	})
+	container.AddSingleton("retention", func(c di.Container) (any, error) {
+		db := c.Get("db").(*sql.DB)
+		return tm.NewRetention(
+			pg.NewOutboxStore("payments.outbox", db),
+			pg.NewInboxStore("payments.inbox", db),
+			c.Get("logger").(zerolog.Logger),
+			tm.RetentionInterval(mono.Config().Retention.Interval),
+			tm.RetentionBatchSize(mono.Config().Retention.BatchSize),
+			tm.OutboxRetention(mono.Config().Retention.Outbox),
+			tm.InboxRetention(mono.Config().Retention.Inbox),
 		), nil
 	})
 	container.AddScoped("tx", func(c di.Container) (any, error) {
@@ -84,7 +107,12 @@ func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error)
 	container.AddScoped("inboxMiddleware", func(c di.Container) (any, error) {
 		tx := c.Get("tx").(*sql.Tx)
 		inboxStore := pg.NewInboxStore("payments.inbox", tx)
-		return tm.NewInboxHandlerMiddleware(inboxStore), nil
+		return tm.NewInboxHandlerMiddleware(
+			inboxStore,
+			tm.InboxHandlerName("payments"),
+			tm.InboxFailures(pg.NewInboxStore("payments.inbox", c.Get("db").(*sql.DB))),
+			tm.InboxDuplicateMetrics(prom.InboxMetrics()),
+		), nil
 	})
 	container.AddScoped("invoices", func(c di.Container) (any, error) {
 		return postgres.NewInvoiceRepository("payments.invoices", c.Get("tx").(*sql.Tx)), nil
@@ -137,6 +165,7 @@ func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error)
 		return err
 	}
 	startOutboxProcessor(ctx, container)
+	mono.Waiter().Add(container.Get("retention").(tm.Retention).Start)
 
 	return
 }
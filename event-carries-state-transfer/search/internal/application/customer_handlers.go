@@ -24,12 +24,22 @@ func (h CustomerHandlers[T]) HandleEvent(ctx context.Context, event T) error {
 	switch event.EventName() {
 	case customerspb.CustomerRegisteredEvent:
 		return h.onCustomerRegistered(ctx, event)
+	case customerspb.CustomerForgottenEvent:
+		return h.onCustomerForgotten(ctx, event)
 	}
 
 	return nil
 }
 
+// onCustomerRegistered caches the customer read from the customers module; the
+// name in the event is encrypted
 func (h CustomerHandlers[T]) onCustomerRegistered(ctx context.Context, event T) error {
 	payload := event.Payload().(*customerspb.CustomerRegistered)
-	return h.cache.Add(ctx, payload.GetId(), payload.GetName())
+	_, err := h.cache.Find(ctx, payload.GetId())
+	return err
+}
+
+func (h CustomerHandlers[T]) onCustomerForgotten(ctx context.Context, event T) error {
+	payload := event.Payload().(*customerspb.CustomerForgotten)
+	return h.cache.Remove(ctx, payload.GetId())
 }
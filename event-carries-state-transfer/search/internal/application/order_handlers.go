@@ -2,7 +2,6 @@ package application
 
 import (
 	"context"
-	"time"
 
 	"eda-in-golang/internal/ddd"
 	"eda-in-golang/ordering/orderingpb"
@@ -88,7 +87,7 @@ func (h OrderHandlers[T]) onOrderCreated(ctx context.Context, event T) error {
 		Items:        items,
 		Total:        total,
 		Status:       "New",
-		CreatedAt:    time.Now(),
+		CreatedAt:    event.OccurredAt(),
 	}
 	return h.orders.Add(ctx, order)
 }
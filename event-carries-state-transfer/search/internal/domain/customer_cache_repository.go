@@ -5,4 +5,5 @@ import "context"
 type CustomerCacheRepository interface {
 	CustomerRepository
 	Add(ctx context.Context, customerID, name string) error
+	Remove(ctx context.Context, customerID string) error
 }
@@ -41,6 +41,7 @@ func RegisterIntegrationEventHandlers(subscriber am.EventSubscriber, handler ddd
 
 	if err = subscriber.Subscribe(customerspb.CustomerAggregateChannel, evtMsgHandler, am.MessageFilter{
 		customerspb.CustomerRegisteredEvent,
+		customerspb.CustomerForgottenEvent,
 	}, am.GroupName("search-customers")); err != nil {
 		return
 	}
@@ -50,7 +51,7 @@ func RegisterIntegrationEventHandlers(subscriber am.EventSubscriber, handler ddd
 		orderingpb.OrderReadiedEvent,
 		orderingpb.OrderCanceledEvent,
 		orderingpb.OrderCompletedEvent,
-	}, am.GroupName("notification-orders")); err != nil {
+	}, am.GroupName("search-orders"), am.ShardBy{Key: ddd.AggregateIDKey, Workers: 4}); err != nil {
 		return
 	}
 
@@ -76,6 +77,8 @@ func (h integrationHandlers[T]) HandleEvent(ctx context.Context, event T) error
 	switch event.EventName() {
 	case customerspb.CustomerRegisteredEvent:
 		return h.onCustomerRegistered(ctx, event)
+	case customerspb.CustomerForgottenEvent:
+		return h.onCustomerForgotten(ctx, event)
 	case storespb.ProductAddedEvent:
 		return h.onProductAdded(ctx, event)
 	case storespb.ProductRebrandedEvent:
@@ -99,9 +102,17 @@ func (h integrationHandlers[T]) HandleEvent(ctx context.Context, event T) error
 	return nil
 }
 
+// onCustomerRegistered caches the customer read from the customers module; the
+// name in the event is encrypted
 func (h integrationHandlers[T]) onCustomerRegistered(ctx context.Context, event T) error {
 	payload := event.Payload().(*customerspb.CustomerRegistered)
-	return h.customers.Add(ctx, payload.GetId(), payload.GetName())
+	_, err := h.customers.Find(ctx, payload.GetId())
+	return err
+}
+
+func (h integrationHandlers[T]) onCustomerForgotten(ctx context.Context, event T) error {
+	payload := event.Payload().(*customerspb.CustomerForgotten)
+	return h.customers.Remove(ctx, payload.GetId())
 }
 
 func (h integrationHandlers[T]) onProductAdded(ctx context.Context, event ddd.Event) error {
@@ -164,6 +175,8 @@ func (h integrationHandlers[T]) onOrderCreated(ctx context.Context, event T) err
 		}
 		total += float64(item.Quantity) * item.Price
 	}
+	// a rebuild replays the event long after the order was created, so the time
+	// is taken from the event
 	order := &domain.Order{
 		ID:           payload.GetId(),
 		CustomerID:   customer.ID,
@@ -171,6 +184,7 @@ func (h integrationHandlers[T]) onOrderCreated(ctx context.Context, event T) err
 		Items:        items,
 		Total:        total,
 		Status:       "New",
+		CreatedAt:    event.OccurredAt(),
 	}
 	return h.orders.Add(ctx, order)
 }
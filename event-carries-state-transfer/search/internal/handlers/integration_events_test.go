@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eda-in-golang/customers/customerspb"
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/ordering/orderingpb"
+	"eda-in-golang/search/internal/domain"
+)
+
+// testCustomerCache reads the customers it does not have from the customers
+// module, as the postgres cache does
+type testCustomerCache struct {
+	cached    map[string]*domain.Customer
+	customers map[string]*domain.Customer
+}
+
+func (c testCustomerCache) Find(ctx context.Context, customerID string) (*domain.Customer, error) {
+	if customer, exists := c.cached[customerID]; exists {
+		return customer, nil
+	}
+	customer := c.customers[customerID]
+	return customer, c.Add(ctx, customer.ID, customer.Name)
+}
+
+func (c testCustomerCache) Add(_ context.Context, customerID, name string) error {
+	c.cached[customerID] = &domain.Customer{ID: customerID, Name: name}
+	return nil
+}
+
+func (c testCustomerCache) Remove(_ context.Context, customerID string) error {
+	delete(c.cached, customerID)
+	return nil
+}
+
+func newTestCustomerCache() testCustomerCache {
+	return testCustomerCache{
+		cached: map[string]*domain.Customer{
+			"other-id": {ID: "other-id", Name: "John"},
+		},
+		customers: map[string]*domain.Customer{
+			"customer-id": {ID: "customer-id", Name: "Jane"},
+			"other-id":    {ID: "other-id", Name: "John"},
+		},
+	}
+}
+
+func TestIntegrationHandlers_onCustomerRegistered(t *testing.T) {
+	customers := newTestCustomerCache()
+	handlers := NewIntegrationEventHandlers(nil, customers, nil, nil)
+
+	// the name in the event cannot be read outside of the customers module
+	err := handlers.HandleEvent(context.Background(), ddd.NewEvent(
+		customerspb.CustomerRegisteredEvent, &customerspb.CustomerRegistered{Id: "customer-id", Name: "pii:v1:encrypted"},
+	))
+	require.NoError(t, err)
+
+	assert.Equal(t, &domain.Customer{ID: "customer-id", Name: "Jane"}, customers.cached["customer-id"])
+}
+
+func TestIntegrationHandlers_onCustomerForgotten(t *testing.T) {
+	customers := newTestCustomerCache()
+	require.NoError(t, customers.Add(context.Background(), "customer-id", "Jane"))
+	handlers := NewIntegrationEventHandlers(nil, customers, nil, nil)
+
+	err := handlers.HandleEvent(context.Background(), ddd.NewEvent(
+		customerspb.CustomerForgottenEvent, &customerspb.CustomerForgotten{Id: "customer-id"},
+	))
+	require.NoError(t, err)
+
+	assert.NotContains(t, customers.cached, "customer-id")
+	assert.Contains(t, customers.cached, "other-id")
+}
+
+// testOrders keeps the orders that were added
+type testOrders struct {
+	domain.OrderRepository
+	added map[string]*domain.Order
+}
+
+func (r testOrders) Add(_ context.Context, order *domain.Order) error {
+	r.added[order.ID] = order
+	return nil
+}
+
+func TestIntegrationHandlers_onOrderCreated(t *testing.T) {
+	orders := testOrders{added: make(map[string]*domain.Order)}
+	handlers := NewIntegrationEventHandlers(orders, newTestCustomerCache(), nil, nil)
+
+	event := ddd.NewEvent(orderingpb.OrderCreatedEvent, &orderingpb.OrderCreated{Id: "order-id", CustomerId: "customer-id"})
+	require.NoError(t, handlers.HandleEvent(context.Background(), event))
+
+	require.Contains(t, orders.added, "order-id")
+	assert.Equal(t, event.OccurredAt(), orders.added["order-id"].CreatedAt)
+	assert.Equal(t, "Jane", orders.added["order-id"].CustomerName)
+}
+
+type testSubscriber struct {
+	groups map[string][]string
+}
+
+func (s testSubscriber) Subscribe(topicName string, _ am.MessageHandler[am.IncomingEventMessage], options ...am.SubscriberOption) error {
+	groupName := am.NewSubscriberConfig(options).GroupName()
+	s.groups[groupName] = append(s.groups[groupName], topicName)
+	return nil
+}
+
+func TestRegisterIntegrationEventHandlers(t *testing.T) {
+	subscriber := testSubscriber{groups: make(map[string][]string)}
+
+	require.NoError(t, RegisterIntegrationEventHandlers(subscriber, NewIntegrationEventHandlers(nil, nil, nil, nil)))
+
+	// a group shared with another module would split the messages between them
+	for groupName, topics := range subscriber.groups {
+		assert.True(t, strings.HasPrefix(groupName, "search-"), "the group %s is not owned by search", groupName)
+		assert.Len(t, topics, 1, "the group %s subscribes to more than one topic", groupName)
+	}
+}
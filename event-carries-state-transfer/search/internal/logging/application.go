@@ -5,6 +5,7 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"eda-in-golang/internal/am"
 	"eda-in-golang/search/internal/application"
 	"eda-in-golang/search/internal/domain"
 )
@@ -24,13 +25,13 @@ func LogApplicationAccess(application application.Application, logger zerolog.Lo
 }
 
 func (a Application) SearchOrders(ctx context.Context, search domain.SearchFilters) (orders []*domain.Order, err error) {
-	a.logger.Info().Msg("--> Search.SearchOrders")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Search.SearchOrders") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Search.SearchOrders")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Search.SearchOrders") }()
 	return a.Application.SearchOrders(ctx, search)
 }
 
 func (a Application) GetOrder(ctx context.Context, get application.GetOrder) (order *domain.Order, err error) {
-	a.logger.Info().Msg("--> Search.GetOrder")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Search.GetOrder") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Search.GetOrder")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Search.GetOrder") }()
 	return a.Application.GetOrder(ctx, get)
 }
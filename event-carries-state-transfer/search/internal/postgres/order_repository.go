@@ -7,28 +7,31 @@ import (
 	"fmt"
 	"strings"
 
-	"database/sql"
 	"database/sql/driver"
 
 	"github.com/stackus/errors"
 
+	pg "eda-in-golang/internal/postgres"
+
 	"eda-in-golang/search/internal/domain"
 )
 
 type OrderRepository struct {
 	tableName string
-	db        *sql.DB
+	db        pg.DB
 }
 
 var _ domain.OrderRepository = (*OrderRepository)(nil)
 
-func NewOrderRepository(tableName string, db *sql.DB) OrderRepository {
+func NewOrderRepository(tableName string, db pg.DB) OrderRepository {
 	return OrderRepository{
 		tableName: tableName,
 		db:        db,
 	}
 }
 
+// Add leaves an order that already exists alone; orders may be added again
+// when an OrderCreated event is redelivered after a rebuild replayed it
 func (r OrderRepository) Add(ctx context.Context, order *domain.Order) error {
 	const query = `INSERT INTO %s (
 		order_id, customer_id, customer_name,
@@ -37,7 +40,7 @@ func (r OrderRepository) Add(ctx context.Context, order *domain.Order) error {
 		$1, $2, $3,
 		$4, $5, $6, $7,
 		$8
-		)`
+		) ON CONFLICT (order_id) DO NOTHING`
 
 	items, err := json.Marshal(order.Items)
 	if err != nil {
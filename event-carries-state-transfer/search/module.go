@@ -6,8 +6,9 @@ import (
 	"eda-in-golang/customers/customerspb"
 	"eda-in-golang/internal/am"
 	"eda-in-golang/internal/ddd"
-	"eda-in-golang/internal/jetstream"
 	"eda-in-golang/internal/monolith"
+	"eda-in-golang/internal/prom"
+	"eda-in-golang/internal/rebuild"
 	"eda-in-golang/internal/registry"
 	"eda-in-golang/ordering/orderingpb"
 	"eda-in-golang/search/internal/application"
@@ -20,12 +21,20 @@ import (
 
 type Module struct{}
 
+var _ rebuild.Module = (*Module)(nil)
+
 func (Module) Name() string {
 	return "search"
 }
 
 func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error) {
 	// setup Driven adapters
+	conn, err := grpc.Dial(ctx, mono.Config().Rpc.Address())
+	if err != nil {
+		return err
+	}
+	// the personal data in customer events can only be read by the customers
+	// module; customers are read through its API instead
 	reg := registry.New()
 	if err = orderingpb.Registrations(reg); err != nil {
 		return err
@@ -36,12 +45,11 @@ func (m Module) Startup(ctx context.Context, mono monolith.Monolith) (err error)
 	if err = storespb.Registrations(reg); err != nil {
 		return err
 	}
-	stream := jetstream.NewStream(mono.Config().Nats.Stream, mono.JS(), mono.Logger())
+	stream := am.RawMessageStreamWithMiddleware(
+		mono.Stream(mono.Logger()),
+		prom.StreamMetrics(),
+	)
 	eventStream := am.NewEventStream(reg, stream)
-	conn, err := grpc.Dial(ctx, mono.Config().Rpc.Address())
-	if err != nil {
-		return err
-	}
 	customers := postgres.NewCustomerCacheRepository("search.customers_cache", mono.DB(), grpc.NewCustomerRepository(conn))
 	stores := postgres.NewStoreCacheRepository("search.stores_cache", mono.DB(), grpc.NewStoreRepository(conn))
 	products := postgres.NewProductCacheRepository("search.products_cache", mono.DB(), grpc.NewProductRepository(conn))
@@ -0,0 +1,152 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/rs/zerolog"
+
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/ddd"
+	"eda-in-golang/internal/jetstream"
+	"eda-in-golang/internal/monolith"
+	"eda-in-golang/internal/rebuild"
+	"eda-in-golang/internal/registry"
+	"eda-in-golang/ordering/orderingpb"
+	"eda-in-golang/search/internal/domain"
+	"eda-in-golang/search/internal/grpc"
+	"eda-in-golang/search/internal/handlers"
+	"eda-in-golang/search/internal/logging"
+	"eda-in-golang/search/internal/postgres"
+)
+
+// ordersReadModel rebuilds search.orders from the order events kept by the
+// stream. The events are replayed into a new table while the live subscription
+// keeps search.orders up to date. Writes to search.orders are then held back
+// only while the events stored since the replay began are replayed and the new
+// table takes the place of the old one.
+type ordersReadModel struct {
+	db        *sql.DB
+	reg       registry.Registry
+	stream    *jetstream.Stream
+	customers domain.CustomerCacheRepository
+	products  domain.ProductCacheRepository
+	stores    domain.StoreCacheRepository
+	logger    zerolog.Logger
+}
+
+var _ rebuild.ReadModel = (*ordersReadModel)(nil)
+
+// ReadModels returns the orders read model so it can be rebuilt from the
+// ordering events
+func (Module) ReadModels(ctx context.Context, mono monolith.Monolith) ([]rebuild.ReadModel, error) {
+	reg := registry.New()
+	if err := orderingpb.Registrations(reg); err != nil {
+		return nil, err
+	}
+	conn, err := grpc.Dial(ctx, mono.Config().Rpc.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	return []rebuild.ReadModel{
+		ordersReadModel{
+			db:        mono.DB(),
+			reg:       reg,
+			stream:    jetstream.NewStream(mono.Config().Nats.Stream, mono.JS(), mono.Logger()),
+			customers: postgres.NewCustomerCacheRepository("search.customers_cache", mono.DB(), grpc.NewCustomerRepository(conn)),
+			products:  postgres.NewProductCacheRepository("search.products_cache", mono.DB(), grpc.NewProductRepository(conn)),
+			stores:    postgres.NewStoreCacheRepository("search.stores_cache", mono.DB(), grpc.NewStoreRepository(conn)),
+			logger:    mono.Logger(),
+		},
+	}, nil
+}
+
+func (m ordersReadModel) ReadModelName() string {
+	return "search.orders"
+}
+
+func (m ordersReadModel) Rebuild(ctx context.Context, dryRun bool, progress rebuild.Progress) (err error) {
+	const createQuery = "CREATE TABLE search.orders_rebuild (LIKE search.orders INCLUDING ALL)"
+	const dropQuery = "DROP TABLE IF EXISTS search.orders_rebuild"
+
+	if _, err = m.db.ExecContext(ctx, dropQuery); err != nil {
+		return err
+	}
+	if _, err = m.db.ExecContext(ctx, createQuery); err != nil {
+		return err
+	}
+	defer func() {
+		// once swapped in there is no longer a table by this name to drop
+		if _, dropErr := m.db.ExecContext(context.Background(), dropQuery); dropErr != nil && err == nil {
+			err = dropErr
+		}
+	}()
+
+	var lastSequence uint64
+	var total int
+	replay := func(ctx context.Context, db *sql.Tx, sequence uint64) error {
+		orders := postgres.NewOrderRepository("search.orders_rebuild", db)
+		handler := logging.LogEventHandlerAccess[ddd.Event](
+			handlers.NewIntegrationEventHandlers(orders, m.customers, m.products, m.stores),
+			"IntegrationEvents", m.logger,
+		)
+		replayed := total
+
+		return m.stream.ReplayHistoryFrom(ctx, orderingpb.OrderAggregateChannel, sequence, am.NewEventMessageHandler(m.reg, handler),
+			func(sequence uint64, count int) {
+				lastSequence = sequence
+				total = replayed + count
+				if progress != nil {
+					progress(int64(sequence), total)
+				}
+			},
+		)
+	}
+
+	if err = m.inTx(ctx, func(tx *sql.Tx) error {
+		return replay(ctx, tx, 0)
+	}); err != nil || dryRun {
+		return err
+	}
+
+	return m.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "LOCK TABLE search.orders IN EXCLUSIVE MODE"); err != nil {
+			return err
+		}
+		// with the writes held back replay what the live subscription added
+		// while the first replay ran
+		if lastSequence > 0 {
+			if err := replay(ctx, tx, lastSequence+1); err != nil {
+				return err
+			}
+		}
+		for _, query := range []string{
+			"ALTER TABLE search.orders RENAME TO orders_replaced",
+			"ALTER TABLE search.orders_rebuild RENAME TO orders",
+			"DROP TABLE search.orders_replaced",
+		} {
+			if _, err := tx.ExecContext(ctx, query); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (m ordersReadModel) inTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	var tx *sql.Tx
+	tx, err = m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	return fn(tx)
+}
@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 
+	"eda-in-golang/internal/es"
 	"eda-in-golang/stores/internal/application/commands"
 	"eda-in-golang/stores/internal/application/queries"
 	"eda-in-golang/stores/internal/domain"
@@ -32,6 +33,9 @@ type (
 		GetParticipatingStores(ctx context.Context, query queries.GetParticipatingStores) ([]*domain.MallStore, error)
 		GetCatalog(ctx context.Context, query queries.GetCatalog) ([]*domain.CatalogProduct, error)
 		GetProduct(ctx context.Context, query queries.GetProduct) (*domain.CatalogProduct, error)
+		GetProductAt(ctx context.Context, query queries.GetProductAt) (*domain.Product, error)
+		GetStoreHistory(ctx context.Context, query queries.GetStoreHistory) ([]es.HistoryEvent, error)
+		GetProductHistory(ctx context.Context, query queries.GetProductHistory) ([]es.HistoryEvent, error)
 	}
 
 	Application struct {
@@ -56,7 +60,10 @@ type (
 		queries.GetStoreHandler
 		queries.GetParticipatingStoresHandler
 		queries.GetProductHandler
+		queries.GetProductAtHandler
 		queries.GetStoresHandler
+		queries.GetStoreHistoryHandler
+		queries.GetProductHistoryHandler
 	}
 )
 
@@ -67,6 +74,7 @@ func New(
 	products domain.ProductRepository,
 	catalog domain.CatalogRepository,
 	mall domain.MallRepository,
+	history es.HistoryReader,
 ) *Application {
 	return &Application{
 		appCommands: appCommands{
@@ -85,7 +93,10 @@ func New(
 			GetStoreHandler:               queries.NewGetStoreHandler(mall),
 			GetParticipatingStoresHandler: queries.NewGetParticipatingStoresHandler(mall),
 			GetProductHandler:             queries.NewGetProductHandler(catalog),
+			GetProductAtHandler:           queries.NewGetProductAtHandler(products),
 			GetStoresHandler:              queries.NewGetStoresHandler(mall),
+			GetStoreHistoryHandler:        queries.NewGetStoreHistoryHandler(history),
+			GetProductHistoryHandler:      queries.NewGetProductHistoryHandler(history),
 		},
 	}
 }
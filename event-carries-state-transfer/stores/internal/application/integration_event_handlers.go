@@ -25,6 +25,16 @@ func (h IntegrationEventHandlers[T]) HandleEvent(ctx context.Context, event T) e
 	switch event.EventName() {
 	case domain.StoreCreatedEvent:
 		return h.onStoreCreated(ctx, event)
+	case domain.ProductAddedEvent:
+		return h.onProductAdded(ctx, event)
+	case domain.ProductRebrandedEvent:
+		return h.onProductRebranded(ctx, event)
+	case domain.ProductPriceIncreasedEvent:
+		return h.onProductPriceChanged(ctx, storespb.ProductPriceIncreasedEvent, event)
+	case domain.ProductPriceDecreasedEvent:
+		return h.onProductPriceChanged(ctx, storespb.ProductPriceDecreasedEvent, event)
+	case domain.ProductRemovedEvent:
+		return h.onProductRemoved(ctx, event)
 	}
 
 	return nil
@@ -37,6 +47,49 @@ func (h IntegrationEventHandlers[T]) onStoreCreated(ctx context.Context, event T
 			Id:       event.AggregateID(),
 			Name:     payload.Name,
 			Location: payload.Location,
-		}),
+		}, ddd.Metadata{ddd.AggregateIDKey: event.AggregateID()}),
+	)
+}
+
+func (h IntegrationEventHandlers[T]) onProductAdded(ctx context.Context, event T) error {
+	payload := event.Payload().(*domain.ProductAdded)
+	return h.publisher.Publish(ctx, storespb.ProductAggregateChannel,
+		ddd.NewEvent(storespb.ProductAddedEvent, &storespb.ProductAdded{
+			Id:          event.AggregateID(),
+			StoreId:     payload.StoreID,
+			Name:        payload.Name,
+			Description: payload.Description,
+			Sku:         payload.SKU,
+			Price:       payload.Price,
+		}, ddd.Metadata{ddd.AggregateIDKey: event.AggregateID()}),
+	)
+}
+
+func (h IntegrationEventHandlers[T]) onProductRebranded(ctx context.Context, event T) error {
+	payload := event.Payload().(*domain.ProductRebranded)
+	return h.publisher.Publish(ctx, storespb.ProductAggregateChannel,
+		ddd.NewEvent(storespb.ProductRebrandedEvent, &storespb.ProductRebranded{
+			Id:          event.AggregateID(),
+			Name:        payload.Name,
+			Description: payload.Description,
+		}, ddd.Metadata{ddd.AggregateIDKey: event.AggregateID()}),
+	)
+}
+
+func (h IntegrationEventHandlers[T]) onProductPriceChanged(ctx context.Context, eventName string, event T) error {
+	payload := event.Payload().(*domain.ProductPriceChanged)
+	return h.publisher.Publish(ctx, storespb.ProductAggregateChannel,
+		ddd.NewEvent(eventName, &storespb.ProductPriceChanged{
+			Id:    event.AggregateID(),
+			Delta: payload.Delta,
+		}, ddd.Metadata{ddd.AggregateIDKey: event.AggregateID()}),
+	)
+}
+
+func (h IntegrationEventHandlers[T]) onProductRemoved(ctx context.Context, event T) error {
+	return h.publisher.Publish(ctx, storespb.ProductAggregateChannel,
+		ddd.NewEvent(storespb.ProductRemovedEvent, &storespb.ProductRemoved{
+			Id: event.AggregateID(),
+		}, ddd.Metadata{ddd.AggregateIDKey: event.AggregateID()}),
 	)
 }
@@ -0,0 +1,55 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/stackus/errors"
+
+	"eda-in-golang/stores/internal/domain"
+)
+
+// Create Query and Handler for get product at a version or a point in time
+type (
+	// GetProductAt needs exactly one of Version or AsOf
+	GetProductAt struct {
+		ID      string
+		Version int
+		AsOf    time.Time
+	}
+
+	GetProductAtHandler struct {
+		products domain.ProductRepository
+	}
+)
+
+func NewGetProductAtHandler(products domain.ProductRepository) GetProductAtHandler {
+	return GetProductAtHandler{
+		products: products,
+	}
+}
+
+// Implement Handle method; the product is loaded from its events rather than
+// the catalog, which only holds the current products
+func (h GetProductAtHandler) GetProductAt(ctx context.Context, query GetProductAt) (*domain.Product, error) {
+	var product *domain.Product
+	var err error
+
+	switch {
+	case query.Version > 0 && query.AsOf.IsZero():
+		product, err = h.products.LoadAtVersion(ctx, query.ID, query.Version)
+	case query.Version == 0 && !query.AsOf.IsZero():
+		product, err = h.products.LoadAsOf(ctx, query.ID, query.AsOf)
+	default:
+		return nil, errors.ErrInvalidArgument.Msg("either a version or a point in time is required")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "get product at query")
+	}
+
+	if product.Version() == 0 {
+		return nil, errors.ErrNotFound.Msgf("the product `%s` did not exist yet", query.ID)
+	}
+
+	return product, nil
+}
@@ -0,0 +1,35 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/stackus/errors"
+
+	"eda-in-golang/internal/es"
+	"eda-in-golang/stores/internal/domain"
+)
+
+type GetProductHistory struct {
+	ID string
+}
+
+type GetProductHistoryHandler struct {
+	history es.HistoryReader
+}
+
+func NewGetProductHistoryHandler(history es.HistoryReader) GetProductHistoryHandler {
+	return GetProductHistoryHandler{history: history}
+}
+
+func (h GetProductHistoryHandler) GetProductHistory(ctx context.Context, query GetProductHistory) ([]es.HistoryEvent, error) {
+	events, err := h.history.History(ctx, domain.ProductAggregate, query.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get product history query")
+	}
+
+	if len(events) == 0 {
+		return nil, errors.ErrNotFound.Msgf("the product `%s` has no history", query.ID)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,35 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/stackus/errors"
+
+	"eda-in-golang/internal/es"
+	"eda-in-golang/stores/internal/domain"
+)
+
+type GetStoreHistory struct {
+	ID string
+}
+
+type GetStoreHistoryHandler struct {
+	history es.HistoryReader
+}
+
+func NewGetStoreHistoryHandler(history es.HistoryReader) GetStoreHistoryHandler {
+	return GetStoreHistoryHandler{history: history}
+}
+
+func (h GetStoreHistoryHandler) GetStoreHistory(ctx context.Context, query GetStoreHistory) ([]es.HistoryEvent, error) {
+	events, err := h.history.History(ctx, domain.StoreAggregate, query.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get store history query")
+	}
+
+	if len(events) == 0 {
+		return nil, errors.ErrNotFound.Msgf("the store `%s` has no history", query.ID)
+	}
+
+	return events, nil
+}
@@ -2,9 +2,12 @@ package domain
 
 import (
 	"context"
+	"time"
 )
 
 type ProductRepository interface {
 	Load(ctx context.Context, id string) (*Product, error)
+	LoadAtVersion(ctx context.Context, id string, version int) (*Product, error)
+	LoadAsOf(ctx context.Context, id string, asOf time.Time) (*Product, error)
 	Save(ctx context.Context, product *Product) error
 }
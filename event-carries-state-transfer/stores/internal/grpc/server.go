@@ -5,7 +5,10 @@ import (
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"eda-in-golang/internal/es"
 	"eda-in-golang/stores/storespb"
 
 	"eda-in-golang/stores/internal/application"
@@ -177,6 +180,61 @@ func (s server) GetProduct(ctx context.Context, request *storespb.GetProductRequ
 	return &storespb.GetProductResponse{Product: s.productFromDomain(product)}, nil
 }
 
+func (s server) GetProductAt(ctx context.Context, request *storespb.GetProductAtRequest) (*storespb.GetProductAtResponse, error) {
+	query := queries.GetProductAt{
+		ID:      request.GetId(),
+		Version: int(request.GetVersion()),
+	}
+	if request.GetAsOf() != nil {
+		query.AsOf = request.GetAsOf().AsTime()
+	}
+
+	product, err := s.app.GetProductAt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storespb.GetProductAtResponse{
+		Product: &storespb.Product{
+			Id:          product.ID(),
+			StoreId:     product.StoreID,
+			Name:        product.Name,
+			Description: product.Description,
+			Sku:         product.SKU,
+			Price:       product.Price,
+		},
+		Version: int32(product.Version()),
+	}, nil
+}
+
+func (s server) GetStoreHistory(ctx context.Context, request *storespb.GetStoreHistoryRequest) (*storespb.GetStoreHistoryResponse, error) {
+	events, err := s.app.GetStoreHistory(ctx, queries.GetStoreHistory{ID: request.GetId()})
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.historyFromDomain(events)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storespb.GetStoreHistoryResponse{Events: history}, nil
+}
+
+func (s server) GetProductHistory(ctx context.Context, request *storespb.GetProductHistoryRequest) (*storespb.GetProductHistoryResponse, error) {
+	events, err := s.app.GetProductHistory(ctx, queries.GetProductHistory{ID: request.GetId()})
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.historyFromDomain(events)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storespb.GetProductHistoryResponse{Events: history}, nil
+}
+
 func (s server) GetCatalog(ctx context.Context, request *storespb.GetCatalogRequest) (*storespb.GetCatalogResponse, error) {
 	products, err := s.app.GetCatalog(ctx, queries.GetCatalog{StoreID: request.GetStoreId()})
 	if err != nil {
@@ -212,3 +270,23 @@ func (s server) productFromDomain(product *domain.CatalogProduct) *storespb.Prod
 		Price:       product.Price,
 	}
 }
+
+func (s server) historyFromDomain(events []es.HistoryEvent) ([]*storespb.HistoryEvent, error) {
+	history := make([]*storespb.HistoryEvent, len(events))
+	for i, event := range events {
+		metadata, err := structpb.NewStruct(event.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		history[i] = &storespb.HistoryEvent{
+			Id:         event.ID,
+			Name:       event.Name,
+			Version:    int32(event.Version),
+			OccurredAt: timestamppb.New(event.OccurredAt),
+			Payload:    string(event.Payload),
+			Metadata:   metadata,
+		}
+	}
+
+	return history, nil
+}
@@ -10,5 +10,10 @@ func RegisterIntegrationEventHandlers(handler ddd.EventHandler[ddd.AggregateEven
 		domain.StoreCreatedEvent,
 		domain.StoreParticipationEnabledEvent,
 		domain.StoreParticipationDisabledEvent,
-		domain.StoreRebrandedEvent)
+		domain.StoreRebrandedEvent,
+		domain.ProductAddedEvent,
+		domain.ProductRebrandedEvent,
+		domain.ProductPriceIncreasedEvent,
+		domain.ProductPriceDecreasedEvent,
+		domain.ProductRemovedEvent)
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"eda-in-golang/internal/am"
+	"eda-in-golang/internal/es"
 	"eda-in-golang/stores/internal/application"
 	"eda-in-golang/stores/internal/application/commands"
 	"eda-in-golang/stores/internal/application/queries"
@@ -26,85 +28,115 @@ func LogApplicationAccess(application application.App, logger zerolog.Logger) Ap
 }
 
 func (a Application) CreateStore(ctx context.Context, cmd commands.CreateStore) (err error) {
-	a.logger.Info().Msg("--> Stores.CreateStore")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.CreateStore") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.CreateStore")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.CreateStore") }()
 	return a.App.CreateStore(ctx, cmd)
 }
 
 func (a Application) EnableParticipation(ctx context.Context, cmd commands.EnableParticipation) (err error) {
-	a.logger.Info().Msg("--> Stores.EnableParticipation")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.EnableParticipation") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.EnableParticipation")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.EnableParticipation")
+	}()
 	return a.App.EnableParticipation(ctx, cmd)
 }
 
 func (a Application) DisableParticipation(ctx context.Context, cmd commands.DisableParticipation) (err error) {
-	a.logger.Info().Msg("--> Stores.DisableParticipation")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.DisableParticipation") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.DisableParticipation")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.DisableParticipation")
+	}()
 	return a.App.DisableParticipation(ctx, cmd)
 }
 
 func (a Application) RebrandStore(ctx context.Context, cmd commands.RebrandStore) (err error) {
-	a.logger.Info().Msg("--> Stores.RebrandStore")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.RebrandStore") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.RebrandStore")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.RebrandStore") }()
 	return a.App.RebrandStore(ctx, cmd)
 }
 
 func (a Application) AddProduct(ctx context.Context, cmd commands.AddProduct) (err error) {
-	a.logger.Info().Msg("--> Stores.AddProduct")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.AddProduct") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.AddProduct")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.AddProduct") }()
 	return a.App.AddProduct(ctx, cmd)
 }
 
 func (a Application) RebrandProduct(ctx context.Context, cmd commands.RebrandProduct) (err error) {
-	a.logger.Info().Msg("--> Stores.RebrandProduct")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.RebrandProduct") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.RebrandProduct")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.RebrandProduct") }()
 	return a.App.RebrandProduct(ctx, cmd)
 }
 
 func (a Application) IncreaseProductPrice(ctx context.Context, cmd commands.IncreaseProductPrice) (err error) {
-	a.logger.Info().Msg("--> Stores.IncreaseProductPrice")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.IncreaseProductPrice") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.IncreaseProductPrice")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.IncreaseProductPrice")
+	}()
 	return a.App.IncreaseProductPrice(ctx, cmd)
 }
 
 func (a Application) DecreaseProductPrice(ctx context.Context, cmd commands.DecreaseProductPrice) (err error) {
-	a.logger.Info().Msg("--> Stores.DecreaseProductPrice")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.DecreaseProductPrice") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.DecreaseProductPrice")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.DecreaseProductPrice")
+	}()
 	return a.App.DecreaseProductPrice(ctx, cmd)
 }
 
 func (a Application) RemoveProduct(ctx context.Context, cmd commands.RemoveProduct) (err error) {
-	a.logger.Info().Msg("--> Stores.RemoveProduct")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.RemoveProduct") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.RemoveProduct")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.RemoveProduct") }()
 	return a.App.RemoveProduct(ctx, cmd)
 }
 
 func (a Application) GetStore(ctx context.Context, query queries.GetStore) (store *domain.MallStore, err error) {
-	a.logger.Info().Msg("--> Stores.GetStore")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.GetStore") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.GetStore")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.GetStore") }()
 	return a.App.GetStore(ctx, query)
 }
 
 func (a Application) GetStores(ctx context.Context, query queries.GetStores) (stores []*domain.MallStore, err error) {
-	a.logger.Info().Msg("--> Stores.GetStores")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.GetStores") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.GetStores")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.GetStores") }()
 	return a.App.GetStores(ctx, query)
 }
 
 func (a Application) GetParticipatingStores(ctx context.Context, query queries.GetParticipatingStores) (stores []*domain.MallStore, err error) {
-	a.logger.Info().Msg("--> Stores.GetParticipatingStores")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.GetParticipatingStores") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.GetParticipatingStores")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.GetParticipatingStores")
+	}()
 	return a.App.GetParticipatingStores(ctx, query)
 }
 
 func (a Application) GetCatalog(ctx context.Context, query queries.GetCatalog) (catalog []*domain.CatalogProduct, err error) {
-	a.logger.Info().Msg("--> Stores.GetCatalog")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.GetCatalog") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.GetCatalog")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.GetCatalog") }()
 	return a.App.GetCatalog(ctx, query)
 }
 
 func (a Application) GetProduct(ctx context.Context, query queries.GetProduct) (product *domain.CatalogProduct, err error) {
-	a.logger.Info().Msg("--> Stores.GetProduct")
-	defer func() { a.logger.Info().Err(err).Msg("<-- Stores.GetProduct") }()
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.GetProduct")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.GetProduct") }()
 	return a.App.GetProduct(ctx, query)
 }
+
+func (a Application) GetProductAt(ctx context.Context, query queries.GetProductAt) (product *domain.Product, err error) {
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.GetProductAt")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.GetProductAt") }()
+	return a.App.GetProductAt(ctx, query)
+}
+
+func (a Application) GetStoreHistory(ctx context.Context, query queries.GetStoreHistory) (events []es.HistoryEvent, err error) {
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.GetStoreHistory")
+	defer func() { a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.GetStoreHistory") }()
+	return a.App.GetStoreHistory(ctx, query)
+}
+
+func (a Application) GetProductHistory(ctx context.Context, query queries.GetProductHistory) (events []es.HistoryEvent, err error) {
+	a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Msg("--> Stores.GetProductHistory")
+	defer func() {
+		a.logger.Info().EmbedObject(am.MessageIDsFrom(ctx)).Err(err).Msg("<-- Stores.GetProductHistory")
+	}()
+	return a.App.GetProductHistory(ctx, query)
+}
@@ -7,17 +7,19 @@ import (
 
 	"github.com/pkg/errors"
 
+	pg "eda-in-golang/internal/postgres"
+
 	"eda-in-golang/stores/internal/domain"
 )
 
 type CatalogRepository struct {
 	tableName string
-	db        *sql.DB
+	db        pg.DB
 }
 
 var _ domain.CatalogRepository = (*CatalogRepository)(nil)
 
-func NewCatalogRepository(tableName string, db *sql.DB) CatalogRepository {
+func NewCatalogRepository(tableName string, db pg.DB) CatalogRepository {
 	return CatalogRepository{
 		tableName: tableName,
 		db:        db,
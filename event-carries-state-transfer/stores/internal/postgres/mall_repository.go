@@ -7,17 +7,19 @@ import (
 
 	"github.com/pkg/errors"
 
+	pg "eda-in-golang/internal/postgres"
+
 	"eda-in-golang/stores/internal/domain"
 )
 
 type MallRepository struct {
 	tableName string
-	db        *sql.DB
+	db        pg.DB
 }
 
 var _ domain.MallRepository = (*MallRepository)(nil)
 
-func NewMallRepository(tableName string, db *sql.DB) MallRepository {
+func NewMallRepository(tableName string, db pg.DB) MallRepository {
 	return MallRepository{
 		tableName: tableName,
 		db:        db,
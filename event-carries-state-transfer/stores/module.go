@@ -8,8 +8,9 @@ import (
 	"eda-in-golang/internal/am"
 	"eda-in-golang/internal/ddd"
 	"eda-in-golang/internal/es"
-	"eda-in-golang/internal/jetstream"
 	"eda-in-golang/internal/monolith"
+	"eda-in-golang/internal/prom"
+	"eda-in-golang/internal/rebuild"
 	"eda-in-golang/internal/registry"
 	"eda-in-golang/internal/registry/serdes"
 	"eda-in-golang/stores/internal/application"
@@ -23,6 +24,8 @@ import (
 
 type Module struct{}
 
+var _ rebuild.Module = (*Module)(nil)
+
 func (Module) Name() string {
 	return "stores"
 }
@@ -36,29 +39,35 @@ func (m *Module) Startup(ctx context.Context, mono monolith.Monolith) (err error
 	if err = storespb.Registrations(reg); err != nil {
 		return err
 	}
-	stream := jetstream.NewStream(mono.Config().Nats.Stream, mono.JS(), mono.Logger())
+	stream := am.RawMessageStreamWithMiddleware(
+		mono.Stream(mono.Logger()),
+		prom.StreamMetrics(),
+	)
 	eventStream := am.NewEventStream(reg, stream)
 	domainDispatcher := ddd.NewEventDispatcher[ddd.AggregateEvent]()
+	eventStore := pg.NewEventStore("stores.events", mono.DB(), reg)
 	aggregateStore := es.AggregateStoreWithMiddleware(
-		pg.NewEventStore("stores.events", mono.DB(), reg),
+		eventStore,
 		es.NewEventPublisher(domainDispatcher),
-		pg.NewSnapshotStore("stores.snapshots", mono.DB(), reg),
+		pg.NewSnapshotStore("stores.snapshots", mono.DB(), reg,
+			es.WithSnapshotStrategy(domain.ProductAggregate, es.AnyOf(
+				es.EveryNEvents(3),
+				es.OnEvents(domain.ProductRemovedEvent),
+			)),
+			es.WithSnapshotMetrics(prom.SnapshotMetrics()),
+		),
+		prom.AggregateStoreMetrics(),
 	)
 	stores := es.NewAggregateRepository[*domain.Store](domain.StoreAggregate, reg, aggregateStore)
 	products := es.NewAggregateRepository[*domain.Product](domain.ProductAggregate, reg, aggregateStore)
 	catalog := postgres.NewCatalogRepository("stores.products", mono.DB())
 	mall := postgres.NewMallRepository("stores.stores", mono.DB())
+	catalogProjection, mallProjection := projections(mono)
 
 	// setup application
-	app := logging.LogApplicationAccess(application.New(stores, products, catalog, mall), mono.Logger())
-	catalogHandlers := logging.LogEventHandlerAccess[ddd.AggregateEvent](
-		application.NewCatalogHandlers(catalog),
-		"Catalog", mono.Logger(),
-	)
-	mallHandlers := logging.LogEventHandlerAccess[ddd.AggregateEvent](
-		application.NewMallHandlers(mall),
-		"Mall", mono.Logger(),
-	)
+	app := logging.LogApplicationAccess(application.New(stores, products, catalog, mall, eventStore), mono.Logger())
+	catalogProjector := es.NewProjector(eventStore, catalogProjection, mono.Logger())
+	mallProjector := es.NewProjector(eventStore, mallProjection, mono.Logger())
 	integrationEventHandlers := logging.LogEventHandlerAccess[ddd.AggregateEvent](
 		application.NewIntegrationEventHandlers(eventStream),
 		"IntegrationEvents", mono.Logger(),
@@ -68,13 +77,56 @@ func (m *Module) Startup(ctx context.Context, mono monolith.Monolith) (err error
 	if err = grpc.RegisterServer(ctx, app, mono.RPC()); err != nil {
 		return err
 	}
-	handlers.RegisterCatalogHandlers(catalogHandlers, domainDispatcher)
-	handlers.RegisterMallHandlers(mallHandlers, domainDispatcher)
+	// the projectors sync as events are saved and follow the event store for
+	// the events saved while a sync could not run
+	handlers.RegisterCatalogHandlers(catalogProjector, domainDispatcher)
+	handlers.RegisterMallHandlers(mallProjector, domainDispatcher)
 	handlers.RegisterIntegrationEventHandlers(integrationEventHandlers, domainDispatcher)
+	mono.Waiter().Add(catalogProjector.Run, mallProjector.Run)
 
 	return nil
 }
 
+// ReadModels returns the catalog and mall read models so they can be rebuilt
+// from the stores event store
+func (Module) ReadModels(_ context.Context, mono monolith.Monolith) ([]rebuild.ReadModel, error) {
+	reg := registry.New()
+	if err := registrations(reg); err != nil {
+		return nil, err
+	}
+
+	eventStore := pg.NewEventStore("stores.events", mono.DB(), reg)
+	catalogProjection, mallProjection := projections(mono)
+
+	return []rebuild.ReadModel{
+		rebuild.FromProjection(catalogProjection, eventStore, mono.Logger()),
+		rebuild.FromProjection(mallProjection, eventStore, mono.Logger()),
+	}, nil
+}
+
+func projections(mono monolith.Monolith) (catalog, mall pg.Projection) {
+	catalog = pg.NewProjection("stores.catalog", "stores.checkpoints", mono.DB(),
+		func(db pg.DB) ddd.EventHandler[ddd.AggregateEvent] {
+			return logging.LogEventHandlerAccess[ddd.AggregateEvent](
+				application.NewCatalogHandlers(postgres.NewCatalogRepository("stores.products", db)),
+				"Catalog", mono.Logger(),
+			)
+		},
+		pg.ProjectionTables("stores.products"),
+	)
+	mall = pg.NewProjection("stores.mall", "stores.checkpoints", mono.DB(),
+		func(db pg.DB) ddd.EventHandler[ddd.AggregateEvent] {
+			return logging.LogEventHandlerAccess[ddd.AggregateEvent](
+				application.NewMallHandlers(postgres.NewMallRepository("stores.stores", db)),
+				"Mall", mono.Logger(),
+			)
+		},
+		pg.ProjectionTables("stores.stores"),
+	)
+
+	return catalog, mall
+}
+
 func registrations(reg registry.Registry) (err error) {
 	serde := serdes.NewJsonSerde(reg)
 
@@ -9,6 +9,8 @@ package storespb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -1413,11 +1415,386 @@ func (x *GetProductResponse) GetProduct() *Product {
 	return nil
 }
 
+// GetProductAtRequest asks for the product as it was at a version or a point
+// in time; one of version or as_of must be set
+type GetProductAtRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductAtRequest) Reset() {
+	*x = GetProductAtRequest{}
+	mi := &file_storespb_api_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductAtRequest) ProtoMessage() {}
+
+func (x *GetProductAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storespb_api_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductAtRequest.ProtoReflect.Descriptor instead.
+func (*GetProductAtRequest) Descriptor() ([]byte, []int) {
+	return file_storespb_api_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetProductAtRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetProductAtRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetProductAtRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+type GetProductAtResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductAtResponse) Reset() {
+	*x = GetProductAtResponse{}
+	mi := &file_storespb_api_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductAtResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductAtResponse) ProtoMessage() {}
+
+func (x *GetProductAtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storespb_api_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductAtResponse.ProtoReflect.Descriptor instead.
+func (*GetProductAtResponse) Descriptor() ([]byte, []int) {
+	return file_storespb_api_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetProductAtResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *GetProductAtResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// HistoryEvent is a stored event with its payload rendered as JSON
+type HistoryEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Payload       string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryEvent) Reset() {
+	*x = HistoryEvent{}
+	mi := &file_storespb_api_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryEvent) ProtoMessage() {}
+
+func (x *HistoryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_storespb_api_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryEvent.ProtoReflect.Descriptor instead.
+func (*HistoryEvent) Descriptor() ([]byte, []int) {
+	return file_storespb_api_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *HistoryEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HistoryEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HistoryEvent) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *HistoryEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *HistoryEvent) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *HistoryEvent) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GetStoreHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStoreHistoryRequest) Reset() {
+	*x = GetStoreHistoryRequest{}
+	mi := &file_storespb_api_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStoreHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStoreHistoryRequest) ProtoMessage() {}
+
+func (x *GetStoreHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storespb_api_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStoreHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetStoreHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_storespb_api_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetStoreHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetStoreHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*HistoryEvent        `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStoreHistoryResponse) Reset() {
+	*x = GetStoreHistoryResponse{}
+	mi := &file_storespb_api_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStoreHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStoreHistoryResponse) ProtoMessage() {}
+
+func (x *GetStoreHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storespb_api_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStoreHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetStoreHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_storespb_api_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetStoreHistoryResponse) GetEvents() []*HistoryEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type GetProductHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductHistoryRequest) Reset() {
+	*x = GetProductHistoryRequest{}
+	mi := &file_storespb_api_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductHistoryRequest) ProtoMessage() {}
+
+func (x *GetProductHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storespb_api_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetProductHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_storespb_api_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetProductHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetProductHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*HistoryEvent        `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductHistoryResponse) Reset() {
+	*x = GetProductHistoryResponse{}
+	mi := &file_storespb_api_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductHistoryResponse) ProtoMessage() {}
+
+func (x *GetProductHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storespb_api_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetProductHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_storespb_api_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetProductHistoryResponse) GetEvents() []*HistoryEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
 var File_storespb_api_proto protoreflect.FileDescriptor
 
 const file_storespb_api_proto_rawDesc = "" +
 	"\n" +
-	"\x12storespb/api.proto\x12\bstorespb\"m\n" +
+	"\x12storespb/api.proto\x12\bstorespb\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"m\n" +
 	"\x05Store\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
@@ -1486,13 +1863,37 @@ const file_storespb_api_proto_rawDesc = "" +
 	"\x11GetProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"A\n" +
 	"\x12GetProductResponse\x12+\n" +
-	"\aproduct\x18\x01 \x01(\v2\x11.storespb.ProductR\aproduct2\xd7\t\n" +
+	"\aproduct\x18\x01 \x01(\v2\x11.storespb.ProductR\aproduct\"p\n" +
+	"\x13GetProductAtRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12/\n" +
+	"\x05as_of\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"]\n" +
+	"\x14GetProductAtResponse\x12+\n" +
+	"\aproduct\x18\x01 \x01(\v2\x11.storespb.ProductR\aproduct\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\xd8\x01\n" +
+	"\fHistoryEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x123\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\"(\n" +
+	"\x16GetStoreHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x17GetStoreHistoryResponse\x12.\n" +
+	"\x06events\x18\x01 \x03(\v2\x16.storespb.HistoryEventR\x06events\"*\n" +
+	"\x18GetProductHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"K\n" +
+	"\x19GetProductHistoryResponse\x12.\n" +
+	"\x06events\x18\x01 \x03(\v2\x16.storespb.HistoryEventR\x06events2\xe2\v\n" +
 	"\rStoresService\x12L\n" +
 	"\vCreateStore\x12\x1c.storespb.CreateStoreRequest\x1a\x1d.storespb.CreateStoreResponse\"\x00\x12d\n" +
 	"\x13EnableParticipation\x12$.storespb.EnableParticipationRequest\x1a%.storespb.EnableParticipationResponse\"\x00\x12g\n" +
 	"\x14DisableParticipation\x12%.storespb.DisableParticipationRequest\x1a&.storespb.DisableParticipationResponse\"\x00\x12O\n" +
 	"\fRebrandStore\x12\x1d.storespb.RebrandStoreRequest\x1a\x1e.storespb.RebrandStoreResponse\"\x00\x12C\n" +
-	"\bGetStore\x12\x19.storespb.GetStoreRequest\x1a\x1a.storespb.GetStoreResponse\"\x00\x12F\n" +
+	"\bGetStore\x12\x19.storespb.GetStoreRequest\x1a\x1a.storespb.GetStoreResponse\"\x00\x12X\n" +
+	"\x0fGetStoreHistory\x12 .storespb.GetStoreHistoryRequest\x1a!.storespb.GetStoreHistoryResponse\"\x00\x12F\n" +
 	"\tGetStores\x12\x1a.storespb.GetStoresRequest\x1a\x1b.storespb.GetStoresResponse\"\x00\x12m\n" +
 	"\x16GetParticipatingStores\x12'.storespb.GetParticipatingStoresRequest\x1a(.storespb.GetParticipatingStoresResponse\"\x00\x12I\n" +
 	"\n" +
@@ -1502,7 +1903,9 @@ const file_storespb_api_proto_rawDesc = "" +
 	"\x14DecreaseProductPrice\x12%.storespb.DecreaseProductPriceRequest\x1a&.storespb.DecreaseProductPriceResponse\"\x00\x12R\n" +
 	"\rRemoveProduct\x12\x1e.storespb.RemoveProductRequest\x1a\x1f.storespb.RemoveProductResponse\"\x00\x12I\n" +
 	"\n" +
-	"GetProduct\x12\x1b.storespb.GetProductRequest\x1a\x1c.storespb.GetProductResponse\"\x00\x12I\n" +
+	"GetProduct\x12\x1b.storespb.GetProductRequest\x1a\x1c.storespb.GetProductResponse\"\x00\x12O\n" +
+	"\fGetProductAt\x12\x1d.storespb.GetProductAtRequest\x1a\x1e.storespb.GetProductAtResponse\"\x00\x12^\n" +
+	"\x11GetProductHistory\x12\".storespb.GetProductHistoryRequest\x1a#.storespb.GetProductHistoryResponse\"\x00\x12I\n" +
 	"\n" +
 	"GetCatalog\x12\x1b.storespb.GetCatalogRequest\x1a\x1c.storespb.GetCatalogResponse\"\x00B\x80\x01\n" +
 	"\fcom.storespbB\bApiProtoP\x01Z&eda-in-golang/stores/storespb/storespb\xa2\x02\x03SXX\xaa\x02\bStorespb\xca\x02\bStorespb\xe2\x02\x14Storespb\\GPBMetadata\xea\x02\bStorespbb\x06proto3"
@@ -1519,7 +1922,7 @@ func file_storespb_api_proto_rawDescGZIP() []byte {
 	return file_storespb_api_proto_rawDescData
 }
 
-var file_storespb_api_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_storespb_api_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_storespb_api_proto_goTypes = []any{
 	(*Store)(nil),                          // 0: storespb.Store
 	(*Product)(nil),                        // 1: storespb.Product
@@ -1551,6 +1954,15 @@ var file_storespb_api_proto_goTypes = []any{
 	(*GetCatalogResponse)(nil),             // 27: storespb.GetCatalogResponse
 	(*GetProductRequest)(nil),              // 28: storespb.GetProductRequest
 	(*GetProductResponse)(nil),             // 29: storespb.GetProductResponse
+	(*GetProductAtRequest)(nil),            // 30: storespb.GetProductAtRequest
+	(*GetProductAtResponse)(nil),           // 31: storespb.GetProductAtResponse
+	(*HistoryEvent)(nil),                   // 32: storespb.HistoryEvent
+	(*GetStoreHistoryRequest)(nil),         // 33: storespb.GetStoreHistoryRequest
+	(*GetStoreHistoryResponse)(nil),        // 34: storespb.GetStoreHistoryResponse
+	(*GetProductHistoryRequest)(nil),       // 35: storespb.GetProductHistoryRequest
+	(*GetProductHistoryResponse)(nil),      // 36: storespb.GetProductHistoryResponse
+	(*timestamppb.Timestamp)(nil),          // 37: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),                // 38: google.protobuf.Struct
 }
 var file_storespb_api_proto_depIdxs = []int32{
 	0,  // 0: storespb.GetStoreResponse.store:type_name -> storespb.Store
@@ -1558,39 +1970,51 @@ var file_storespb_api_proto_depIdxs = []int32{
 	0,  // 2: storespb.GetParticipatingStoresResponse.stores:type_name -> storespb.Store
 	1,  // 3: storespb.GetCatalogResponse.products:type_name -> storespb.Product
 	1,  // 4: storespb.GetProductResponse.product:type_name -> storespb.Product
-	2,  // 5: storespb.StoresService.CreateStore:input_type -> storespb.CreateStoreRequest
-	4,  // 6: storespb.StoresService.EnableParticipation:input_type -> storespb.EnableParticipationRequest
-	6,  // 7: storespb.StoresService.DisableParticipation:input_type -> storespb.DisableParticipationRequest
-	8,  // 8: storespb.StoresService.RebrandStore:input_type -> storespb.RebrandStoreRequest
-	10, // 9: storespb.StoresService.GetStore:input_type -> storespb.GetStoreRequest
-	12, // 10: storespb.StoresService.GetStores:input_type -> storespb.GetStoresRequest
-	14, // 11: storespb.StoresService.GetParticipatingStores:input_type -> storespb.GetParticipatingStoresRequest
-	16, // 12: storespb.StoresService.AddProduct:input_type -> storespb.AddProductRequest
-	18, // 13: storespb.StoresService.RebrandProduct:input_type -> storespb.RebrandProductRequest
-	20, // 14: storespb.StoresService.IncreaseProductPrice:input_type -> storespb.IncreaseProductPriceRequest
-	22, // 15: storespb.StoresService.DecreaseProductPrice:input_type -> storespb.DecreaseProductPriceRequest
-	24, // 16: storespb.StoresService.RemoveProduct:input_type -> storespb.RemoveProductRequest
-	28, // 17: storespb.StoresService.GetProduct:input_type -> storespb.GetProductRequest
-	26, // 18: storespb.StoresService.GetCatalog:input_type -> storespb.GetCatalogRequest
-	3,  // 19: storespb.StoresService.CreateStore:output_type -> storespb.CreateStoreResponse
-	5,  // 20: storespb.StoresService.EnableParticipation:output_type -> storespb.EnableParticipationResponse
-	7,  // 21: storespb.StoresService.DisableParticipation:output_type -> storespb.DisableParticipationResponse
-	9,  // 22: storespb.StoresService.RebrandStore:output_type -> storespb.RebrandStoreResponse
-	11, // 23: storespb.StoresService.GetStore:output_type -> storespb.GetStoreResponse
-	13, // 24: storespb.StoresService.GetStores:output_type -> storespb.GetStoresResponse
-	15, // 25: storespb.StoresService.GetParticipatingStores:output_type -> storespb.GetParticipatingStoresResponse
-	17, // 26: storespb.StoresService.AddProduct:output_type -> storespb.AddProductResponse
-	19, // 27: storespb.StoresService.RebrandProduct:output_type -> storespb.RebrandProductResponse
-	21, // 28: storespb.StoresService.IncreaseProductPrice:output_type -> storespb.IncreaseProductPriceResponse
-	23, // 29: storespb.StoresService.DecreaseProductPrice:output_type -> storespb.DecreaseProductPriceResponse
-	25, // 30: storespb.StoresService.RemoveProduct:output_type -> storespb.RemoveProductResponse
-	29, // 31: storespb.StoresService.GetProduct:output_type -> storespb.GetProductResponse
-	27, // 32: storespb.StoresService.GetCatalog:output_type -> storespb.GetCatalogResponse
-	19, // [19:33] is the sub-list for method output_type
-	5,  // [5:19] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	37, // 5: storespb.GetProductAtRequest.as_of:type_name -> google.protobuf.Timestamp
+	1,  // 6: storespb.GetProductAtResponse.product:type_name -> storespb.Product
+	37, // 7: storespb.HistoryEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	38, // 8: storespb.HistoryEvent.metadata:type_name -> google.protobuf.Struct
+	32, // 9: storespb.GetStoreHistoryResponse.events:type_name -> storespb.HistoryEvent
+	32, // 10: storespb.GetProductHistoryResponse.events:type_name -> storespb.HistoryEvent
+	2,  // 11: storespb.StoresService.CreateStore:input_type -> storespb.CreateStoreRequest
+	4,  // 12: storespb.StoresService.EnableParticipation:input_type -> storespb.EnableParticipationRequest
+	6,  // 13: storespb.StoresService.DisableParticipation:input_type -> storespb.DisableParticipationRequest
+	8,  // 14: storespb.StoresService.RebrandStore:input_type -> storespb.RebrandStoreRequest
+	10, // 15: storespb.StoresService.GetStore:input_type -> storespb.GetStoreRequest
+	33, // 16: storespb.StoresService.GetStoreHistory:input_type -> storespb.GetStoreHistoryRequest
+	12, // 17: storespb.StoresService.GetStores:input_type -> storespb.GetStoresRequest
+	14, // 18: storespb.StoresService.GetParticipatingStores:input_type -> storespb.GetParticipatingStoresRequest
+	16, // 19: storespb.StoresService.AddProduct:input_type -> storespb.AddProductRequest
+	18, // 20: storespb.StoresService.RebrandProduct:input_type -> storespb.RebrandProductRequest
+	20, // 21: storespb.StoresService.IncreaseProductPrice:input_type -> storespb.IncreaseProductPriceRequest
+	22, // 22: storespb.StoresService.DecreaseProductPrice:input_type -> storespb.DecreaseProductPriceRequest
+	24, // 23: storespb.StoresService.RemoveProduct:input_type -> storespb.RemoveProductRequest
+	28, // 24: storespb.StoresService.GetProduct:input_type -> storespb.GetProductRequest
+	30, // 25: storespb.StoresService.GetProductAt:input_type -> storespb.GetProductAtRequest
+	35, // 26: storespb.StoresService.GetProductHistory:input_type -> storespb.GetProductHistoryRequest
+	26, // 27: storespb.StoresService.GetCatalog:input_type -> storespb.GetCatalogRequest
+	3,  // 28: storespb.StoresService.CreateStore:output_type -> storespb.CreateStoreResponse
+	5,  // 29: storespb.StoresService.EnableParticipation:output_type -> storespb.EnableParticipationResponse
+	7,  // 30: storespb.StoresService.DisableParticipation:output_type -> storespb.DisableParticipationResponse
+	9,  // 31: storespb.StoresService.RebrandStore:output_type -> storespb.RebrandStoreResponse
+	11, // 32: storespb.StoresService.GetStore:output_type -> storespb.GetStoreResponse
+	34, // 33: storespb.StoresService.GetStoreHistory:output_type -> storespb.GetStoreHistoryResponse
+	13, // 34: storespb.StoresService.GetStores:output_type -> storespb.GetStoresResponse
+	15, // 35: storespb.StoresService.GetParticipatingStores:output_type -> storespb.GetParticipatingStoresResponse
+	17, // 36: storespb.StoresService.AddProduct:output_type -> storespb.AddProductResponse
+	19, // 37: storespb.StoresService.RebrandProduct:output_type -> storespb.RebrandProductResponse
+	21, // 38: storespb.StoresService.IncreaseProductPrice:output_type -> storespb.IncreaseProductPriceResponse
+	23, // 39: storespb.StoresService.DecreaseProductPrice:output_type -> storespb.DecreaseProductPriceResponse
+	25, // 40: storespb.StoresService.RemoveProduct:output_type -> storespb.RemoveProductResponse
+	29, // 41: storespb.StoresService.GetProduct:output_type -> storespb.GetProductResponse
+	31, // 42: storespb.StoresService.GetProductAt:output_type -> storespb.GetProductAtResponse
+	36, // 43: storespb.StoresService.GetProductHistory:output_type -> storespb.GetProductHistoryResponse
+	27, // 44: storespb.StoresService.GetCatalog:output_type -> storespb.GetCatalogResponse
+	28, // [28:45] is the sub-list for method output_type
+	11, // [11:28] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_storespb_api_proto_init() }
@@ -1604,7 +2028,7 @@ func file_storespb_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_storespb_api_proto_rawDesc), len(file_storespb_api_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   30,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
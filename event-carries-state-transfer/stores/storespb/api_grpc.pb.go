@@ -24,6 +24,7 @@ const (
 	StoresService_DisableParticipation_FullMethodName   = "/storespb.StoresService/DisableParticipation"
 	StoresService_RebrandStore_FullMethodName           = "/storespb.StoresService/RebrandStore"
 	StoresService_GetStore_FullMethodName               = "/storespb.StoresService/GetStore"
+	StoresService_GetStoreHistory_FullMethodName        = "/storespb.StoresService/GetStoreHistory"
 	StoresService_GetStores_FullMethodName              = "/storespb.StoresService/GetStores"
 	StoresService_GetParticipatingStores_FullMethodName = "/storespb.StoresService/GetParticipatingStores"
 	StoresService_AddProduct_FullMethodName             = "/storespb.StoresService/AddProduct"
@@ -32,6 +33,8 @@ const (
 	StoresService_DecreaseProductPrice_FullMethodName   = "/storespb.StoresService/DecreaseProductPrice"
 	StoresService_RemoveProduct_FullMethodName          = "/storespb.StoresService/RemoveProduct"
 	StoresService_GetProduct_FullMethodName             = "/storespb.StoresService/GetProduct"
+	StoresService_GetProductAt_FullMethodName           = "/storespb.StoresService/GetProductAt"
+	StoresService_GetProductHistory_FullMethodName      = "/storespb.StoresService/GetProductHistory"
 	StoresService_GetCatalog_FullMethodName             = "/storespb.StoresService/GetCatalog"
 )
 
@@ -44,6 +47,7 @@ type StoresServiceClient interface {
 	DisableParticipation(ctx context.Context, in *DisableParticipationRequest, opts ...grpc.CallOption) (*DisableParticipationResponse, error)
 	RebrandStore(ctx context.Context, in *RebrandStoreRequest, opts ...grpc.CallOption) (*RebrandStoreResponse, error)
 	GetStore(ctx context.Context, in *GetStoreRequest, opts ...grpc.CallOption) (*GetStoreResponse, error)
+	GetStoreHistory(ctx context.Context, in *GetStoreHistoryRequest, opts ...grpc.CallOption) (*GetStoreHistoryResponse, error)
 	GetStores(ctx context.Context, in *GetStoresRequest, opts ...grpc.CallOption) (*GetStoresResponse, error)
 	GetParticipatingStores(ctx context.Context, in *GetParticipatingStoresRequest, opts ...grpc.CallOption) (*GetParticipatingStoresResponse, error)
 	AddProduct(ctx context.Context, in *AddProductRequest, opts ...grpc.CallOption) (*AddProductResponse, error)
@@ -52,6 +56,8 @@ type StoresServiceClient interface {
 	DecreaseProductPrice(ctx context.Context, in *DecreaseProductPriceRequest, opts ...grpc.CallOption) (*DecreaseProductPriceResponse, error)
 	RemoveProduct(ctx context.Context, in *RemoveProductRequest, opts ...grpc.CallOption) (*RemoveProductResponse, error)
 	GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*GetProductResponse, error)
+	GetProductAt(ctx context.Context, in *GetProductAtRequest, opts ...grpc.CallOption) (*GetProductAtResponse, error)
+	GetProductHistory(ctx context.Context, in *GetProductHistoryRequest, opts ...grpc.CallOption) (*GetProductHistoryResponse, error)
 	GetCatalog(ctx context.Context, in *GetCatalogRequest, opts ...grpc.CallOption) (*GetCatalogResponse, error)
 }
 
@@ -113,6 +119,16 @@ func (c *storesServiceClient) GetStore(ctx context.Context, in *GetStoreRequest,
 	return out, nil
 }
 
+func (c *storesServiceClient) GetStoreHistory(ctx context.Context, in *GetStoreHistoryRequest, opts ...grpc.CallOption) (*GetStoreHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStoreHistoryResponse)
+	err := c.cc.Invoke(ctx, StoresService_GetStoreHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storesServiceClient) GetStores(ctx context.Context, in *GetStoresRequest, opts ...grpc.CallOption) (*GetStoresResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetStoresResponse)
@@ -193,6 +209,26 @@ func (c *storesServiceClient) GetProduct(ctx context.Context, in *GetProductRequ
 	return out, nil
 }
 
+func (c *storesServiceClient) GetProductAt(ctx context.Context, in *GetProductAtRequest, opts ...grpc.CallOption) (*GetProductAtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductAtResponse)
+	err := c.cc.Invoke(ctx, StoresService_GetProductAt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storesServiceClient) GetProductHistory(ctx context.Context, in *GetProductHistoryRequest, opts ...grpc.CallOption) (*GetProductHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductHistoryResponse)
+	err := c.cc.Invoke(ctx, StoresService_GetProductHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storesServiceClient) GetCatalog(ctx context.Context, in *GetCatalogRequest, opts ...grpc.CallOption) (*GetCatalogResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetCatalogResponse)
@@ -212,6 +248,7 @@ type StoresServiceServer interface {
 	DisableParticipation(context.Context, *DisableParticipationRequest) (*DisableParticipationResponse, error)
 	RebrandStore(context.Context, *RebrandStoreRequest) (*RebrandStoreResponse, error)
 	GetStore(context.Context, *GetStoreRequest) (*GetStoreResponse, error)
+	GetStoreHistory(context.Context, *GetStoreHistoryRequest) (*GetStoreHistoryResponse, error)
 	GetStores(context.Context, *GetStoresRequest) (*GetStoresResponse, error)
 	GetParticipatingStores(context.Context, *GetParticipatingStoresRequest) (*GetParticipatingStoresResponse, error)
 	AddProduct(context.Context, *AddProductRequest) (*AddProductResponse, error)
@@ -220,6 +257,8 @@ type StoresServiceServer interface {
 	DecreaseProductPrice(context.Context, *DecreaseProductPriceRequest) (*DecreaseProductPriceResponse, error)
 	RemoveProduct(context.Context, *RemoveProductRequest) (*RemoveProductResponse, error)
 	GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error)
+	GetProductAt(context.Context, *GetProductAtRequest) (*GetProductAtResponse, error)
+	GetProductHistory(context.Context, *GetProductHistoryRequest) (*GetProductHistoryResponse, error)
 	GetCatalog(context.Context, *GetCatalogRequest) (*GetCatalogResponse, error)
 	mustEmbedUnimplementedStoresServiceServer()
 }
@@ -246,6 +285,9 @@ func (UnimplementedStoresServiceServer) RebrandStore(context.Context, *RebrandSt
 func (UnimplementedStoresServiceServer) GetStore(context.Context, *GetStoreRequest) (*GetStoreResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetStore not implemented")
 }
+func (UnimplementedStoresServiceServer) GetStoreHistory(context.Context, *GetStoreHistoryRequest) (*GetStoreHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStoreHistory not implemented")
+}
 func (UnimplementedStoresServiceServer) GetStores(context.Context, *GetStoresRequest) (*GetStoresResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetStores not implemented")
 }
@@ -270,6 +312,12 @@ func (UnimplementedStoresServiceServer) RemoveProduct(context.Context, *RemovePr
 func (UnimplementedStoresServiceServer) GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetProduct not implemented")
 }
+func (UnimplementedStoresServiceServer) GetProductAt(context.Context, *GetProductAtRequest) (*GetProductAtResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProductAt not implemented")
+}
+func (UnimplementedStoresServiceServer) GetProductHistory(context.Context, *GetProductHistoryRequest) (*GetProductHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProductHistory not implemented")
+}
 func (UnimplementedStoresServiceServer) GetCatalog(context.Context, *GetCatalogRequest) (*GetCatalogResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetCatalog not implemented")
 }
@@ -384,6 +432,24 @@ func _StoresService_GetStore_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StoresService_GetStoreHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStoreHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoresServiceServer).GetStoreHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoresService_GetStoreHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoresServiceServer).GetStoreHistory(ctx, req.(*GetStoreHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StoresService_GetStores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetStoresRequest)
 	if err := dec(in); err != nil {
@@ -528,6 +594,42 @@ func _StoresService_GetProduct_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StoresService_GetProductAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoresServiceServer).GetProductAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoresService_GetProductAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoresServiceServer).GetProductAt(ctx, req.(*GetProductAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoresService_GetProductHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoresServiceServer).GetProductHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoresService_GetProductHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoresServiceServer).GetProductHistory(ctx, req.(*GetProductHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StoresService_GetCatalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCatalogRequest)
 	if err := dec(in); err != nil {
@@ -573,6 +675,10 @@ var StoresService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStore",
 			Handler:    _StoresService_GetStore_Handler,
 		},
+		{
+			MethodName: "GetStoreHistory",
+			Handler:    _StoresService_GetStoreHistory_Handler,
+		},
 		{
 			MethodName: "GetStores",
 			Handler:    _StoresService_GetStores_Handler,
@@ -605,6 +711,14 @@ var StoresService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProduct",
 			Handler:    _StoresService_GetProduct_Handler,
 		},
+		{
+			MethodName: "GetProductAt",
+			Handler:    _StoresService_GetProductAt_Handler,
+		},
+		{
+			MethodName: "GetProductHistory",
+			Handler:    _StoresService_GetProductHistory_Handler,
+		},
 		{
 			MethodName: "GetCatalog",
 			Handler:    _StoresService_GetCatalog_Handler,